@@ -86,9 +86,15 @@ type Container struct {
 	// Snapshots referenced in this field will not be garbage collected.
 	//
 	// This field may be updated.
-	RootFS    string    `protobuf:"bytes,7,opt,name=rootfs,proto3" json:"rootfs,omitempty"`
-	CreatedAt time.Time `protobuf:"bytes,8,opt,name=created_at,json=createdAt,stdtime" json:"created_at"`
-	UpdatedAt time.Time `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,stdtime" json:"updated_at"`
+	RootFS string `protobuf:"bytes,7,opt,name=rootfs,proto3" json:"rootfs,omitempty"`
+	// Snapshotter specifies the snapshotter owning the RootFS snapshot. If
+	// empty on creation, it is set to the default snapshotter of the daemon.
+	// It must name a snapshotter loaded by the daemon.
+	//
+	// This field cannot be updated once the container has a rootfs.
+	Snapshotter string    `protobuf:"bytes,10,opt,name=snapshotter,proto3" json:"snapshotter,omitempty"`
+	CreatedAt   time.Time `protobuf:"bytes,8,opt,name=created_at,json=createdAt,stdtime" json:"created_at"`
+	UpdatedAt   time.Time `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,stdtime" json:"updated_at"`
 }
 
 func (m *Container) Reset()                    { *m = Container{} }
@@ -123,17 +129,21 @@ type ListContainersResponse struct {
 	Containers []Container `protobuf:"bytes,1,rep,name=containers" json:"containers"`
 }
 
-func (m *ListContainersResponse) Reset()                    { *m = ListContainersResponse{} }
-func (*ListContainersResponse) ProtoMessage()               {}
-func (*ListContainersResponse) Descriptor() ([]byte, []int) { return fileDescriptorContainers, []int{4} }
+func (m *ListContainersResponse) Reset()      { *m = ListContainersResponse{} }
+func (*ListContainersResponse) ProtoMessage() {}
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorContainers, []int{4}
+}
 
 type CreateContainerRequest struct {
 	Container Container `protobuf:"bytes,1,opt,name=container" json:"container"`
 }
 
-func (m *CreateContainerRequest) Reset()                    { *m = CreateContainerRequest{} }
-func (*CreateContainerRequest) ProtoMessage()               {}
-func (*CreateContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptorContainers, []int{5} }
+func (m *CreateContainerRequest) Reset()      { *m = CreateContainerRequest{} }
+func (*CreateContainerRequest) ProtoMessage() {}
+func (*CreateContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorContainers, []int{5}
+}
 
 type CreateContainerResponse struct {
 	Container Container `protobuf:"bytes,1,opt,name=container" json:"container"`
@@ -160,9 +170,11 @@ type UpdateContainerRequest struct {
 	UpdateMask *google_protobuf3.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
 }
 
-func (m *UpdateContainerRequest) Reset()                    { *m = UpdateContainerRequest{} }
-func (*UpdateContainerRequest) ProtoMessage()               {}
-func (*UpdateContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptorContainers, []int{7} }
+func (m *UpdateContainerRequest) Reset()      { *m = UpdateContainerRequest{} }
+func (*UpdateContainerRequest) ProtoMessage() {}
+func (*UpdateContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorContainers, []int{7}
+}
 
 type UpdateContainerResponse struct {
 	Container Container `protobuf:"bytes,1,opt,name=container" json:"container"`
@@ -178,9 +190,11 @@ type DeleteContainerRequest struct {
 	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *DeleteContainerRequest) Reset()                    { *m = DeleteContainerRequest{} }
-func (*DeleteContainerRequest) ProtoMessage()               {}
-func (*DeleteContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptorContainers, []int{9} }
+func (m *DeleteContainerRequest) Reset()      { *m = DeleteContainerRequest{} }
+func (*DeleteContainerRequest) ProtoMessage() {}
+func (*DeleteContainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorContainers, []int{9}
+}
 
 func init() {
 	proto.RegisterType((*Container)(nil), "containerd.v1.Container")
@@ -481,6 +495,12 @@ func (m *Container) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n3
+	if len(m.Snapshotter) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintContainers(dAtA, i, uint64(len(m.Snapshotter)))
+		i += copy(dAtA[i:], m.Snapshotter)
+	}
 	return i, nil
 }
 
@@ -788,6 +808,10 @@ func (m *Container) Size() (n int) {
 	n += 1 + l + sovContainers(uint64(l))
 	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)
 	n += 1 + l + sovContainers(uint64(l))
+	l = len(m.Snapshotter)
+	if l > 0 {
+		n += 1 + l + sovContainers(uint64(l))
+	}
 	return n
 }
 
@@ -913,6 +937,7 @@ func (this *Container) String() string {
 		`RootFS:` + fmt.Sprintf("%v", this.RootFS) + `,`,
 		`CreatedAt:` + strings.Replace(strings.Replace(this.CreatedAt.String(), "Timestamp", "google_protobuf4.Timestamp", 1), `&`, ``, 1) + `,`,
 		`UpdatedAt:` + strings.Replace(strings.Replace(this.UpdatedAt.String(), "Timestamp", "google_protobuf4.Timestamp", 1), `&`, ``, 1) + `,`,
+		`Snapshotter:` + fmt.Sprintf("%v", this.Snapshotter) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1370,6 +1395,35 @@ func (m *Container) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshotter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContainers
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthContainers
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Snapshotter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipContainers(dAtA[iNdEx:])
@@ -2252,48 +2306,49 @@ func init() {
 }
 
 var fileDescriptorContainers = []byte{
-	// 680 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0x93, 0xe0, 0x36, 0x13, 0x21, 0xa1, 0x25, 0x04, 0x63, 0xa4, 0x24, 0x32, 0x3f, 0xca,
-	0x05, 0x1b, 0xc2, 0x85, 0x9f, 0x0a, 0xa9, 0xe9, 0x9f, 0x2a, 0x15, 0x0e, 0x6e, 0xa1, 0xdc, 0x2a,
-	0x27, 0xde, 0x04, 0xab, 0x8e, 0xd7, 0x78, 0x37, 0x95, 0x72, 0xe3, 0x11, 0x90, 0x78, 0x05, 0x8e,
-	0x3c, 0x48, 0x8f, 0x1c, 0x39, 0x15, 0x9a, 0x27, 0x41, 0xbb, 0x5e, 0xd7, 0xa9, 0xed, 0x50, 0x10,
-	0xbd, 0xcd, 0x78, 0xbe, 0xf9, 0x32, 0xf3, 0xcd, 0x67, 0x07, 0x76, 0x46, 0x1e, 0xfb, 0x30, 0xe9,
-	0x9b, 0x03, 0x32, 0xb6, 0x06, 0x24, 0x60, 0x8e, 0x17, 0xe0, 0xc8, 0x9d, 0x0f, 0x9d, 0xd0, 0xb3,
-	0x28, 0x8e, 0x8e, 0xbd, 0x01, 0xa6, 0xe9, 0xf3, 0xf9, 0xd0, 0x0c, 0x23, 0xc2, 0x08, 0xba, 0x9e,
-	0x36, 0x99, 0xc7, 0x4f, 0xf4, 0xfa, 0x88, 0x8c, 0x88, 0xa8, 0x58, 0x3c, 0x8a, 0x41, 0xfa, 0x9d,
-	0x11, 0x21, 0x23, 0x1f, 0x5b, 0x22, 0xeb, 0x4f, 0x86, 0x96, 0x13, 0x4c, 0x65, 0xe9, 0x6e, 0xb6,
-	0x84, 0xc7, 0x21, 0x4b, 0x8a, 0xed, 0x6c, 0x71, 0xe8, 0x61, 0xdf, 0x3d, 0x1c, 0x3b, 0xf4, 0x48,
-	0x22, 0x5a, 0x59, 0x04, 0xf3, 0xc6, 0x98, 0x32, 0x67, 0x1c, 0x4a, 0xc0, 0xd6, 0x5f, 0xad, 0xca,
-	0xa6, 0x21, 0xa6, 0x96, 0x8b, 0xe9, 0x20, 0xf2, 0x42, 0x46, 0xa2, 0xb9, 0x30, 0xe6, 0x31, 0xbe,
-	0x96, 0xa1, 0xba, 0x9e, 0x34, 0xa1, 0x06, 0x94, 0x3c, 0x57, 0x53, 0xda, 0x4a, 0xa7, 0xda, 0x53,
-	0x67, 0xa7, 0xad, 0xd2, 0xce, 0x86, 0x5d, 0xf2, 0x5c, 0xb4, 0x0a, 0xaa, 0xef, 0xf4, 0xb1, 0x4f,
-	0xb5, 0x52, 0xbb, 0xdc, 0xa9, 0x75, 0xef, 0x9b, 0x17, 0xe4, 0x31, 0xcf, 0x19, 0xcc, 0x5d, 0x01,
-	0xdb, 0x0c, 0x58, 0x34, 0xb5, 0x65, 0x0f, 0xaa, 0xc3, 0x35, 0x6f, 0xec, 0x8c, 0xb0, 0x56, 0xe6,
-	0xc4, 0x76, 0x9c, 0x20, 0x0d, 0x96, 0xa3, 0x49, 0xc0, 0xf7, 0xd2, 0x2a, 0xe2, 0x79, 0x92, 0xa2,
-	0x0e, 0x54, 0x68, 0x88, 0x07, 0x9a, 0xda, 0x56, 0x3a, 0xb5, 0x6e, 0xdd, 0x8c, 0xb5, 0x30, 0x13,
-	0x2d, 0xcc, 0xb5, 0x60, 0x6a, 0x0b, 0x04, 0x32, 0x40, 0x8d, 0x08, 0x61, 0x43, 0xaa, 0x2d, 0x8b,
-	0x99, 0x61, 0x76, 0xda, 0x52, 0x6d, 0x42, 0xd8, 0xd6, 0x9e, 0x2d, 0x2b, 0x68, 0x1d, 0x60, 0x10,
-	0x61, 0x87, 0x61, 0xf7, 0xd0, 0x61, 0xda, 0x8a, 0xe0, 0xd4, 0x73, 0x9c, 0xfb, 0x89, 0xbe, 0xbd,
-	0x95, 0x93, 0xd3, 0xd6, 0xd2, 0xe7, 0x9f, 0x2d, 0xc5, 0xae, 0xca, 0xbe, 0x35, 0xc6, 0x49, 0x26,
-	0xa1, 0x9b, 0x90, 0x54, 0xff, 0x85, 0x44, 0xf6, 0xad, 0x31, 0xfd, 0x39, 0xd4, 0xe6, 0xe4, 0x41,
-	0x37, 0xa0, 0x7c, 0x84, 0xa7, 0xb1, 0xda, 0x36, 0x0f, 0xb9, 0x50, 0xc7, 0x8e, 0x3f, 0xc1, 0x5a,
-	0x29, 0x16, 0x4a, 0x24, 0x2f, 0x4a, 0xcf, 0x14, 0xe3, 0x11, 0xdc, 0xdc, 0xc6, 0xec, 0x5c, 0x66,
-	0x1b, 0x7f, 0x9c, 0x60, 0xca, 0x16, 0xdd, 0xcb, 0xd8, 0x87, 0xfa, 0x45, 0x38, 0x0d, 0x49, 0x40,
-	0x31, 0x5a, 0x85, 0xea, 0xf9, 0xe1, 0x44, 0x5b, 0xad, 0xab, 0x2d, 0x3a, 0x65, 0xaf, 0xc2, 0x77,
-	0xb0, 0xd3, 0x06, 0xc3, 0x82, 0x5b, 0xbb, 0x1e, 0x4d, 0x69, 0x69, 0x3a, 0x86, 0x3a, 0xf4, 0x7c,
-	0x26, 0x39, 0xab, 0xb6, 0xcc, 0x8c, 0xf7, 0xd0, 0xc8, 0x36, 0xc8, 0x41, 0x5e, 0x01, 0xa4, 0xaf,
-	0x9c, 0xa6, 0x08, 0x53, 0x5d, 0x36, 0xc9, 0x5c, 0x87, 0xf1, 0x0e, 0x1a, 0xeb, 0xe2, 0x38, 0x39,
-	0x49, 0xfe, 0x6f, 0xc5, 0x03, 0xb8, 0x9d, 0xe3, 0xbd, 0x12, 0xed, 0xbe, 0x28, 0xd0, 0x78, 0x2b,
-	0x9c, 0x70, 0xb5, 0x13, 0xa3, 0x97, 0x50, 0x8b, 0x1d, 0x26, 0x3e, 0x1f, 0xc2, 0x39, 0x45, 0xd6,
-	0xdc, 0xe2, 0x5f, 0x98, 0xd7, 0x0e, 0x3d, 0xb2, 0xa5, 0x91, 0x79, 0xcc, 0xd7, 0xcd, 0x0d, 0x75,
-	0x25, 0xeb, 0x3e, 0x86, 0xc6, 0x06, 0xf6, 0x71, 0xc1, 0xb6, 0x0b, 0x2c, 0xdb, 0xfd, 0x56, 0x06,
-	0x48, 0x8d, 0x82, 0xde, 0x40, 0x79, 0x1b, 0x33, 0x64, 0x64, 0x7e, 0xb2, 0xe0, 0x25, 0xd0, 0xef,
-	0xfd, 0x11, 0x23, 0xd7, 0xd9, 0x83, 0x0a, 0xb7, 0x22, 0xca, 0x7e, 0xb9, 0x0a, 0x0d, 0xad, 0x3f,
-	0xb8, 0x04, 0x25, 0x49, 0x0f, 0x40, 0x8d, 0xdd, 0x82, 0xb2, 0x0d, 0xc5, 0xe6, 0xd4, 0x1f, 0x5e,
-	0x06, 0x4b, 0x89, 0xe3, 0xbb, 0xe4, 0x88, 0x8b, 0x3d, 0x94, 0x23, 0x5e, 0x74, 0xd5, 0x6d, 0x50,
-	0xe3, 0xbb, 0xe4, 0x88, 0x8b, 0xcf, 0xa5, 0x37, 0x72, 0x4e, 0xda, 0xe4, 0x7f, 0x64, 0x3d, 0xed,
-	0xe4, 0xac, 0xb9, 0xf4, 0xe3, 0xac, 0xb9, 0xf4, 0x69, 0xd6, 0x54, 0x4e, 0x66, 0x4d, 0xe5, 0xfb,
-	0xac, 0xa9, 0xfc, 0x9a, 0x35, 0x95, 0xbe, 0x2a, 0x90, 0x4f, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff,
-	0xc1, 0x18, 0xeb, 0x02, 0x8d, 0x07, 0x00, 0x00,
+	// 693 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xad, 0xed, 0x7c, 0x6e, 0x73, 0xa3, 0x4f, 0x42, 0x43, 0x08, 0x83, 0x91, 0x92, 0xc8, 0x40,
+	0x95, 0x0d, 0x36, 0x84, 0x0d, 0x3f, 0x15, 0x52, 0xd3, 0x3f, 0x55, 0x2a, 0x2c, 0xdc, 0x42, 0xd9,
+	0x55, 0x4e, 0x3c, 0x49, 0xad, 0x3a, 0x1e, 0xe3, 0x99, 0x54, 0xca, 0x8e, 0x47, 0x40, 0xe2, 0x35,
+	0x78, 0x0e, 0xd4, 0x25, 0x4b, 0x56, 0x85, 0xe6, 0x49, 0x90, 0xc7, 0x93, 0x3a, 0xb5, 0x53, 0x5a,
+	0x44, 0x77, 0x77, 0xe6, 0x9e, 0x73, 0x72, 0x7f, 0x8e, 0x27, 0xb0, 0x3d, 0xf0, 0xf9, 0xe1, 0xa8,
+	0x6b, 0xf5, 0xe8, 0xd0, 0xee, 0xd1, 0x90, 0xbb, 0x7e, 0x48, 0x62, 0x6f, 0x36, 0x74, 0x23, 0xdf,
+	0x66, 0x24, 0x3e, 0xf6, 0x7b, 0x84, 0x65, 0xf7, 0xb3, 0xa1, 0x15, 0xc5, 0x94, 0x53, 0xf4, 0x7f,
+	0x46, 0xb2, 0x8e, 0x9f, 0x1a, 0xd5, 0x01, 0x1d, 0x50, 0x91, 0xb1, 0x93, 0x28, 0x05, 0x19, 0xf7,
+	0x06, 0x94, 0x0e, 0x02, 0x62, 0x8b, 0x53, 0x77, 0xd4, 0xb7, 0xdd, 0x70, 0x2c, 0x53, 0xf7, 0xf3,
+	0x29, 0x32, 0x8c, 0xf8, 0x34, 0xd9, 0xcc, 0x27, 0xfb, 0x3e, 0x09, 0xbc, 0x83, 0xa1, 0xcb, 0x8e,
+	0x24, 0xa2, 0x91, 0x47, 0x70, 0x7f, 0x48, 0x18, 0x77, 0x87, 0x91, 0x04, 0x6c, 0x5e, 0xab, 0x55,
+	0x3e, 0x8e, 0x08, 0xb3, 0x3d, 0xc2, 0x7a, 0xb1, 0x1f, 0x71, 0x1a, 0xcf, 0x84, 0xa9, 0x8e, 0xf9,
+	0x4d, 0x83, 0xf2, 0xda, 0x94, 0x84, 0x6a, 0xa0, 0xfa, 0x1e, 0x56, 0x9a, 0x4a, 0xab, 0xdc, 0xd1,
+	0x27, 0xa7, 0x0d, 0x75, 0x7b, 0xdd, 0x51, 0x7d, 0x0f, 0xad, 0x80, 0x1e, 0xb8, 0x5d, 0x12, 0x30,
+	0xac, 0x36, 0xb5, 0x56, 0xa5, 0xfd, 0xd0, 0xba, 0x30, 0x1e, 0xeb, 0x5c, 0xc1, 0xda, 0x11, 0xb0,
+	0x8d, 0x90, 0xc7, 0x63, 0x47, 0x72, 0x50, 0x15, 0xfe, 0xf3, 0x87, 0xee, 0x80, 0x60, 0x2d, 0x11,
+	0x76, 0xd2, 0x03, 0xc2, 0xb0, 0x18, 0x8f, 0xc2, 0xa4, 0x2f, 0x5c, 0x12, 0xf7, 0xd3, 0x23, 0x6a,
+	0x41, 0x89, 0x45, 0xa4, 0x87, 0xf5, 0xa6, 0xd2, 0xaa, 0xb4, 0xab, 0x56, 0x3a, 0x0b, 0x6b, 0x3a,
+	0x0b, 0x6b, 0x35, 0x1c, 0x3b, 0x02, 0x81, 0x4c, 0xd0, 0x63, 0x4a, 0x79, 0x9f, 0xe1, 0x45, 0x51,
+	0x33, 0x4c, 0x4e, 0x1b, 0xba, 0x43, 0x29, 0xdf, 0xdc, 0x75, 0x64, 0x06, 0xad, 0x01, 0xf4, 0x62,
+	0xe2, 0x72, 0xe2, 0x1d, 0xb8, 0x1c, 0x2f, 0x09, 0x4d, 0xa3, 0xa0, 0xb9, 0x37, 0x9d, 0x6f, 0x67,
+	0xe9, 0xe4, 0xb4, 0xb1, 0xf0, 0xf9, 0x67, 0x43, 0x71, 0xca, 0x92, 0xb7, 0xca, 0x13, 0x91, 0x51,
+	0xe4, 0x4d, 0x45, 0xca, 0x7f, 0x23, 0x22, 0x79, 0xab, 0x1c, 0x35, 0xa1, 0xc2, 0x42, 0x37, 0x62,
+	0x87, 0x94, 0x73, 0x12, 0x63, 0x10, 0x5d, 0xcf, 0x5e, 0x19, 0x2f, 0xa0, 0x32, 0x33, 0x40, 0x74,
+	0x0b, 0xb4, 0x23, 0x32, 0x4e, 0xf7, 0xe1, 0x24, 0x61, 0x32, 0xca, 0x63, 0x37, 0x18, 0x11, 0xac,
+	0xa6, 0xa3, 0x14, 0x87, 0x97, 0xea, 0x73, 0xc5, 0x7c, 0x0c, 0xb7, 0xb7, 0x08, 0x3f, 0x5f, 0x84,
+	0x43, 0x3e, 0x8e, 0x08, 0xe3, 0x97, 0x6d, 0xd4, 0xdc, 0x83, 0xea, 0x45, 0x38, 0x8b, 0x68, 0xc8,
+	0x08, 0x5a, 0x81, 0xf2, 0xf9, 0x6a, 0x05, 0xad, 0xd2, 0xc6, 0x97, 0x2d, 0xbb, 0x53, 0x4a, 0xba,
+	0x74, 0x32, 0x82, 0x69, 0xc3, 0x9d, 0x1d, 0x9f, 0x65, 0xb2, 0x2c, 0x2b, 0x43, 0xef, 0xfb, 0x01,
+	0x97, 0x9a, 0x65, 0x47, 0x9e, 0xcc, 0x0f, 0x50, 0xcb, 0x13, 0x64, 0x21, 0xaf, 0x01, 0xb2, 0x8f,
+	0x12, 0x2b, 0x4d, 0xed, 0x1a, 0x95, 0xcc, 0x30, 0xcc, 0xf7, 0x50, 0x5b, 0x13, 0xeb, 0x2b, 0x8c,
+	0xe4, 0xdf, 0x5a, 0xdc, 0x87, 0xbb, 0x05, 0xdd, 0x1b, 0x99, 0xdd, 0x17, 0x05, 0x6a, 0xef, 0x84,
+	0x57, 0x6e, 0xb6, 0x62, 0xf4, 0x0a, 0x2a, 0xa9, 0x07, 0xc5, 0x03, 0x83, 0xd5, 0x4b, 0xcc, 0xbb,
+	0x99, 0xbc, 0x41, 0x6f, 0x5c, 0x76, 0xe4, 0x48, 0xab, 0x27, 0x71, 0xd2, 0x6e, 0xa1, 0xa8, 0x1b,
+	0x69, 0xf7, 0x09, 0xd4, 0xd6, 0x49, 0x40, 0x38, 0xb9, 0xae, 0x65, 0xdb, 0x5f, 0x35, 0x80, 0xcc,
+	0x28, 0xe8, 0x2d, 0x68, 0x5b, 0x84, 0x23, 0x33, 0xf7, 0x93, 0x73, 0x3e, 0x02, 0xe3, 0xc1, 0x1f,
+	0x31, 0xb2, 0x9d, 0x5d, 0x28, 0x25, 0x56, 0x44, 0xf9, 0xb7, 0x6d, 0xae, 0xa1, 0x8d, 0x47, 0x57,
+	0xa0, 0xa4, 0xe8, 0x3e, 0xe8, 0xa9, 0x5b, 0x50, 0x9e, 0x30, 0xdf, 0x9c, 0xc6, 0xf2, 0x55, 0xb0,
+	0x4c, 0x38, 0xdd, 0x4b, 0x41, 0x78, 0xbe, 0x87, 0x8c, 0xe5, 0xab, 0x60, 0x52, 0x78, 0x0b, 0xf4,
+	0x74, 0x2f, 0x05, 0xe1, 0xf9, 0xeb, 0x32, 0x6a, 0x05, 0x27, 0x6d, 0x24, 0x7f, 0x75, 0x1d, 0x7c,
+	0x72, 0x56, 0x5f, 0xf8, 0x71, 0x56, 0x5f, 0xf8, 0x34, 0xa9, 0x2b, 0x27, 0x93, 0xba, 0xf2, 0x7d,
+	0x52, 0x57, 0x7e, 0x4d, 0xea, 0x4a, 0x57, 0x17, 0xc8, 0x67, 0xbf, 0x07, 0x00, 0xca, 0xb4, 0x48,
+	0x0c, 0xaf, 0x07, 0x00, 0x00,
 }
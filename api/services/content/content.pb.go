@@ -12,6 +12,8 @@
 		Info
 		InfoRequest
 		InfoResponse
+		UpdateRequest
+		UpdateResponse
 		ListContentRequest
 		ListContentResponse
 		DeleteContentRequest
@@ -32,6 +34,7 @@ import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 import _ "github.com/gogo/protobuf/types"
 import google_protobuf2 "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf3 "github.com/gogo/protobuf/types"
 
 import github_com_opencontainers_go_digest "github.com/opencontainers/go-digest"
 import time "time"
@@ -45,6 +48,7 @@ import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 
 import strings "strings"
 import reflect "reflect"
+import github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 
 import io "io"
 
@@ -107,6 +111,9 @@ type Info struct {
 	Size_ int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	// CommittedAt provides the time at which the blob was committed.
 	CommittedAt time.Time `protobuf:"bytes,3,opt,name=committed_at,json=committedAt,stdtime" json:"committed_at"`
+	// Labels are arbitrary data on the content, such as the repositories
+	// it was fetched from.
+	Labels map[string]string `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -129,12 +136,39 @@ func (m *InfoResponse) Reset()                    { *m = InfoResponse{} }
 func (*InfoResponse) ProtoMessage()               {}
 func (*InfoResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{2} }
 
+// UpdateRequest updates the labels of committed content.
+//
+// The operation should follow semantics described in
+// https://developers.google.com/protocol-buffers/docs/reference/csharp/class/google/protobuf/well-known-types/field-mask,
+// unless otherwise qualified.
+type UpdateRequest struct {
+	// Info provides the target values, as declared by the mask, for the
+	// update. The digest field must be set.
+	Info Info `protobuf:"bytes,1,opt,name=info" json:"info"`
+	// UpdateMask specifies which fields to perform the update on. If empty,
+	// the labels are replaced. Only labels may be updated, we take everything
+	// after the "labels." as the map key.
+	UpdateMask *google_protobuf3.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
+}
+
+func (m *UpdateRequest) Reset()                    { *m = UpdateRequest{} }
+func (*UpdateRequest) ProtoMessage()               {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{3} }
+
+type UpdateResponse struct {
+	Info Info `protobuf:"bytes,1,opt,name=info" json:"info"`
+}
+
+func (m *UpdateResponse) Reset()                    { *m = UpdateResponse{} }
+func (*UpdateResponse) ProtoMessage()               {}
+func (*UpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{4} }
+
 type ListContentRequest struct {
 }
 
 func (m *ListContentRequest) Reset()                    { *m = ListContentRequest{} }
 func (*ListContentRequest) ProtoMessage()               {}
-func (*ListContentRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{3} }
+func (*ListContentRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{5} }
 
 type ListContentResponse struct {
 	Info []Info `protobuf:"bytes,1,rep,name=info" json:"info"`
@@ -142,7 +176,7 @@ type ListContentResponse struct {
 
 func (m *ListContentResponse) Reset()                    { *m = ListContentResponse{} }
 func (*ListContentResponse) ProtoMessage()               {}
-func (*ListContentResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{4} }
+func (*ListContentResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{6} }
 
 type DeleteContentRequest struct {
 	// Digest specifies which content to delete.
@@ -151,7 +185,7 @@ type DeleteContentRequest struct {
 
 func (m *DeleteContentRequest) Reset()                    { *m = DeleteContentRequest{} }
 func (*DeleteContentRequest) ProtoMessage()               {}
-func (*DeleteContentRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{5} }
+func (*DeleteContentRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{7} }
 
 // ReadRequest defines the fields that make up a request to read a portion of
 // data from a stored object.
@@ -169,7 +203,7 @@ type ReadRequest struct {
 
 func (m *ReadRequest) Reset()                    { *m = ReadRequest{} }
 func (*ReadRequest) ProtoMessage()               {}
-func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{6} }
+func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{8} }
 
 // ReadResponse carries byte data for a read request.
 type ReadResponse struct {
@@ -179,7 +213,7 @@ type ReadResponse struct {
 
 func (m *ReadResponse) Reset()                    { *m = ReadResponse{} }
 func (*ReadResponse) ProtoMessage()               {}
-func (*ReadResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{7} }
+func (*ReadResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{9} }
 
 type StatusRequest struct {
 	Regexp string `protobuf:"bytes,1,opt,name=regexp,proto3" json:"regexp,omitempty"`
@@ -187,7 +221,7 @@ type StatusRequest struct {
 
 func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
 func (*StatusRequest) ProtoMessage()               {}
-func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{8} }
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{10} }
 
 type Status struct {
 	StartedAt time.Time                                  `protobuf:"bytes,1,opt,name=started_at,json=startedAt,stdtime" json:"started_at"`
@@ -200,7 +234,7 @@ type Status struct {
 
 func (m *Status) Reset()                    { *m = Status{} }
 func (*Status) ProtoMessage()               {}
-func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{9} }
+func (*Status) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{11} }
 
 type StatusResponse struct {
 	Statuses []Status `protobuf:"bytes,1,rep,name=statuses" json:"statuses"`
@@ -208,7 +242,7 @@ type StatusResponse struct {
 
 func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
 func (*StatusResponse) ProtoMessage()               {}
-func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{10} }
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{12} }
 
 // WriteRequest writes data to the request ref at offset.
 type WriteRequest struct {
@@ -265,7 +299,7 @@ type WriteRequest struct {
 
 func (m *WriteRequest) Reset()                    { *m = WriteRequest{} }
 func (*WriteRequest) ProtoMessage()               {}
-func (*WriteRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{11} }
+func (*WriteRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{13} }
 
 // WriteResponse is returned on the culmination of a write call.
 type WriteResponse struct {
@@ -299,7 +333,7 @@ type WriteResponse struct {
 
 func (m *WriteResponse) Reset()                    { *m = WriteResponse{} }
 func (*WriteResponse) ProtoMessage()               {}
-func (*WriteResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{12} }
+func (*WriteResponse) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{14} }
 
 type AbortRequest struct {
 	Ref string `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
@@ -307,12 +341,14 @@ type AbortRequest struct {
 
 func (m *AbortRequest) Reset()                    { *m = AbortRequest{} }
 func (*AbortRequest) ProtoMessage()               {}
-func (*AbortRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{13} }
+func (*AbortRequest) Descriptor() ([]byte, []int) { return fileDescriptorContent, []int{15} }
 
 func init() {
 	proto.RegisterType((*Info)(nil), "containerd.v1.Info")
 	proto.RegisterType((*InfoRequest)(nil), "containerd.v1.InfoRequest")
 	proto.RegisterType((*InfoResponse)(nil), "containerd.v1.InfoResponse")
+	proto.RegisterType((*UpdateRequest)(nil), "containerd.v1.UpdateRequest")
+	proto.RegisterType((*UpdateResponse)(nil), "containerd.v1.UpdateResponse")
 	proto.RegisterType((*ListContentRequest)(nil), "containerd.v1.ListContentRequest")
 	proto.RegisterType((*ListContentResponse)(nil), "containerd.v1.ListContentResponse")
 	proto.RegisterType((*DeleteContentRequest)(nil), "containerd.v1.DeleteContentRequest")
@@ -343,6 +379,8 @@ type ContentClient interface {
 	// This call can be used for getting the size of content and checking for
 	// existence.
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// Update updates the labels of committed content.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// List streams the entire set of content as Info objects and closes the
 	// stream.
 	//
@@ -402,6 +440,15 @@ func (c *contentClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *contentClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := grpc.Invoke(ctx, "/containerd.v1.Content/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *contentClient) List(ctx context.Context, in *ListContentRequest, opts ...grpc.CallOption) (Content_ListClient, error) {
 	stream, err := grpc.NewClientStream(ctx, &_Content_serviceDesc.Streams[0], c.cc, "/containerd.v1.Content/List", opts...)
 	if err != nil {
@@ -532,6 +579,8 @@ type ContentServer interface {
 	// This call can be used for getting the size of content and checking for
 	// existence.
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	// Update updates the labels of committed content.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// List streams the entire set of content as Info objects and closes the
 	// stream.
 	//
@@ -596,6 +645,24 @@ func _Content_Info_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Content_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/containerd.v1.Content/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Content_List_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListContentRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -726,6 +793,10 @@ var _Content_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Info",
 			Handler:    _Content_Info_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _Content_Update_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _Content_Delete_Handler,
@@ -794,6 +865,23 @@ func (m *Info) MarshalTo(dAtA []byte) (int, error) {
 		return 0, err
 	}
 	i += n1
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x22
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovContent(uint64(len(k))) + 1 + len(v) + sovContent(uint64(len(v)))
+			i = encodeVarintContent(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintContent(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintContent(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
 	return i, nil
 }
 
@@ -847,6 +935,68 @@ func (m *InfoResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *UpdateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintContent(dAtA, i, uint64(m.Info.Size()))
+	n3, err := m.Info.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	if m.UpdateMask != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintContent(dAtA, i, uint64(m.UpdateMask.Size()))
+		n4, err := m.UpdateMask.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	return i, nil
+}
+
+func (m *UpdateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintContent(dAtA, i, uint64(m.Info.Size()))
+	n5, err := m.Info.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	return i, nil
+}
+
 func (m *ListContentRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1024,19 +1174,19 @@ func (m *Status) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintContent(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt)))
-	n3, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i:])
+	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n3
+	i += n6
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintContent(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
-	n4, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
+	n7, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n4
+	i += n7
 	if len(m.Ref) > 0 {
 		dAtA[i] = 0x1a
 		i++
@@ -1166,19 +1316,19 @@ func (m *WriteResponse) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x12
 	i++
 	i = encodeVarintContent(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.StartedAt)))
-	n5, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i:])
+	n8, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.StartedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n8
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintContent(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
-	n6, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
+	n9, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n9
 	if m.Offset != 0 {
 		dAtA[i] = 0x20
 		i++
@@ -1261,6 +1411,14 @@ func (m *Info) Size() (n int) {
 	}
 	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.CommittedAt)
 	n += 1 + l + sovContent(uint64(l))
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovContent(uint64(len(k))) + 1 + len(v) + sovContent(uint64(len(v)))
+			n += mapEntrySize + 1 + sovContent(uint64(mapEntrySize))
+		}
+	}
 	return n
 }
 
@@ -1282,6 +1440,26 @@ func (m *InfoResponse) Size() (n int) {
 	return n
 }
 
+func (m *UpdateRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Info.Size()
+	n += 1 + l + sovContent(uint64(l))
+	if m.UpdateMask != nil {
+		l = m.UpdateMask.Size()
+		n += 1 + l + sovContent(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Info.Size()
+	n += 1 + l + sovContent(uint64(l))
+	return n
+}
+
 func (m *ListContentRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -1462,10 +1640,21 @@ func (this *Info) String() string {
 	if this == nil {
 		return "nil"
 	}
+	keysForLabels := make([]string, 0, len(this.Labels))
+	for k, _ := range this.Labels {
+		keysForLabels = append(keysForLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+	mapStringForLabels := "map[string]string{"
+	for _, k := range keysForLabels {
+		mapStringForLabels += fmt.Sprintf("%v: %v,", k, this.Labels[k])
+	}
+	mapStringForLabels += "}"
 	s := strings.Join([]string{`&Info{`,
 		`Digest:` + fmt.Sprintf("%v", this.Digest) + `,`,
 		`Size_:` + fmt.Sprintf("%v", this.Size_) + `,`,
 		`CommittedAt:` + strings.Replace(strings.Replace(this.CommittedAt.String(), "Timestamp", "google_protobuf1.Timestamp", 1), `&`, ``, 1) + `,`,
+		`Labels:` + mapStringForLabels + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1490,6 +1679,27 @@ func (this *InfoResponse) String() string {
 	}, "")
 	return s
 }
+func (this *UpdateRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateRequest{`,
+		`Info:` + strings.Replace(strings.Replace(this.Info.String(), "Info", "Info", 1), `&`, ``, 1) + `,`,
+		`UpdateMask:` + strings.Replace(fmt.Sprintf("%v", this.UpdateMask), "FieldMask", "google_protobuf3.FieldMask", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateResponse{`,
+		`Info:` + strings.Replace(strings.Replace(this.Info.String(), "Info", "Info", 1), `&`, ``, 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *ListContentRequest) String() string {
 	if this == nil {
 		return "nil"
@@ -1732,6 +1942,122 @@ func (m *Info) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthContent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthContent
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowContent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowContent
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthContent
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.Labels[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.Labels[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipContent(dAtA[iNdEx:])
@@ -1912,6 +2238,199 @@ func (m *InfoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *UpdateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowContent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthContent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateMask", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthContent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UpdateMask == nil {
+				m.UpdateMask = &google_protobuf3.FieldMask{}
+			}
+			if err := m.UpdateMask.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipContent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthContent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowContent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowContent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthContent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Info.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipContent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthContent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ListContentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3286,59 +3805,67 @@ func init() {
 }
 
 var fileDescriptorContent = []byte{
-	// 860 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0xcf, 0xc4, 0x8e, 0x69, 0x5f, 0xd2, 0x25, 0x4c, 0xb2, 0x55, 0xe4, 0xee, 0x3a, 0x59, 0x73,
-	0x20, 0x5a, 0x69, 0x9d, 0x25, 0x1c, 0x90, 0x10, 0x08, 0x25, 0x69, 0x41, 0x41, 0x2c, 0x2b, 0xb9,
-	0x91, 0x2a, 0x4e, 0xc8, 0x49, 0x26, 0xc6, 0x52, 0xe3, 0x31, 0xf6, 0xa4, 0x2a, 0x9c, 0xb8, 0x20,
-	0xa1, 0x9e, 0xf8, 0x02, 0xbd, 0x00, 0x77, 0xee, 0x7c, 0x01, 0x7a, 0xe4, 0x88, 0x38, 0x14, 0x9a,
-	0x03, 0x1f, 0x80, 0x4f, 0x80, 0x3c, 0x33, 0x76, 0x9c, 0x3f, 0x45, 0xf4, 0x0f, 0xa7, 0xcc, 0xcc,
-	0xfb, 0xbd, 0x9f, 0xdf, 0x9f, 0x5f, 0xdf, 0x2b, 0xf4, 0x5c, 0x8f, 0x7d, 0x3e, 0x1b, 0x5a, 0x23,
-	0x3a, 0x6d, 0x8d, 0xa8, 0xcf, 0x1c, 0xcf, 0x27, 0xe1, 0x38, 0x7b, 0x74, 0x02, 0xaf, 0x15, 0x91,
-	0xf0, 0xc4, 0x1b, 0x91, 0x88, 0xbf, 0x13, 0x9f, 0x25, 0xbf, 0x56, 0x10, 0x52, 0x46, 0xf1, 0xce,
-	0x02, 0x6e, 0x9d, 0xbc, 0xa9, 0x57, 0x5d, 0xea, 0x52, 0x6e, 0x69, 0xc5, 0x27, 0x01, 0xd2, 0xeb,
-	0x2e, 0xa5, 0xee, 0x31, 0x69, 0xf1, 0xdb, 0x70, 0x36, 0x69, 0x31, 0x6f, 0x4a, 0x22, 0xe6, 0x4c,
-	0x03, 0x09, 0xd8, 0x5b, 0x05, 0x90, 0x69, 0xc0, 0xbe, 0x14, 0x46, 0xf3, 0x27, 0x04, 0x6a, 0xdf,
-	0x9f, 0x50, 0xfc, 0x11, 0x68, 0x63, 0xcf, 0x25, 0x11, 0xab, 0xa1, 0x06, 0x6a, 0x6e, 0x77, 0xdb,
-	0x17, 0x97, 0xf5, 0xdc, 0xef, 0x97, 0xf5, 0xa7, 0x99, 0x44, 0x68, 0x40, 0xfc, 0x34, 0xa4, 0xa8,
-	0xe5, 0xd2, 0x67, 0xc2, 0xc5, 0xda, 0xe7, 0x3f, 0xb6, 0x64, 0xc0, 0x18, 0xd4, 0xc8, 0xfb, 0x8a,
-	0xd4, 0xf2, 0x0d, 0xd4, 0x54, 0x6c, 0x7e, 0xc6, 0x1f, 0x42, 0x69, 0x44, 0xa7, 0x53, 0x8f, 0x31,
-	0x32, 0xfe, 0xcc, 0x61, 0x35, 0xa5, 0x81, 0x9a, 0xc5, 0xb6, 0x6e, 0x89, 0xe0, 0xac, 0x24, 0x38,
-	0x6b, 0x90, 0x44, 0xdf, 0xdd, 0x8a, 0x23, 0xf8, 0xee, 0x8f, 0x3a, 0xb2, 0x8b, 0xa9, 0x67, 0x87,
-	0x99, 0x9f, 0x42, 0x31, 0x0e, 0xd8, 0x26, 0x5f, 0xcc, 0xe2, 0x6f, 0xdd, 0x63, 0xdc, 0xe6, 0x7b,
-	0x50, 0x12, 0xd4, 0x51, 0x40, 0xfd, 0x88, 0xe0, 0x67, 0xa0, 0x7a, 0xfe, 0x84, 0x72, 0xe6, 0x62,
-	0xbb, 0x62, 0x2d, 0xb5, 0xc3, 0x8a, 0xa1, 0x5d, 0x35, 0xfe, 0x9c, 0xcd, 0x61, 0x66, 0x15, 0xf0,
-	0xc7, 0x5e, 0xc4, 0x7a, 0xa2, 0x87, 0x32, 0x40, 0x73, 0x1f, 0x2a, 0x4b, 0xaf, 0x6b, 0xdc, 0xca,
-	0x7f, 0xe1, 0x1e, 0x42, 0x75, 0x9f, 0x1c, 0x13, 0x46, 0x96, 0xd9, 0xef, 0x35, 0xfd, 0x6f, 0x10,
-	0x14, 0x6d, 0xe2, 0x8c, 0xff, 0x07, 0x6e, 0xbc, 0x0b, 0x1a, 0x9d, 0x4c, 0x22, 0xc2, 0xa4, 0x28,
-	0xe4, 0x2d, 0x95, 0x8a, 0xb2, 0x90, 0x8a, 0xf9, 0x0e, 0x94, 0x44, 0x18, 0xb2, 0x54, 0x0b, 0x5f,
-	0xb4, 0xea, 0x3b, 0x76, 0x98, 0xc3, 0x19, 0x4b, 0x36, 0x3f, 0x9b, 0x6f, 0xc0, 0xce, 0x21, 0x73,
-	0xd8, 0x2c, 0x4a, 0x92, 0xd8, 0x05, 0x2d, 0x24, 0x2e, 0x39, 0x0d, 0x44, 0x12, 0xb6, 0xbc, 0x99,
-	0xdf, 0xe7, 0x41, 0x13, 0x48, 0xdc, 0x03, 0x88, 0x98, 0x13, 0x4a, 0x61, 0xa2, 0x1b, 0x08, 0x73,
-	0x5b, 0xfa, 0x75, 0x58, 0x4c, 0x32, 0x0b, 0xc6, 0x8e, 0x24, 0xc9, 0xdf, 0x84, 0x44, 0xfa, 0x75,
-	0x18, 0x2e, 0x83, 0x12, 0x92, 0x09, 0x2f, 0xc6, 0xb6, 0x1d, 0x1f, 0x33, 0xb9, 0xab, 0x4b, 0xb9,
-	0x57, 0xa1, 0xc0, 0x28, 0x73, 0x8e, 0x6b, 0x05, 0xfe, 0x2c, 0x2e, 0xf8, 0x13, 0xd8, 0x22, 0xa7,
-	0x01, 0x19, 0x31, 0x32, 0xae, 0x69, 0xb7, 0xee, 0x59, 0xca, 0x61, 0xf6, 0xe1, 0x41, 0x52, 0x4d,
-	0xd9, 0x8b, 0xb7, 0x61, 0x2b, 0xe2, 0x2f, 0x24, 0x92, 0xd2, 0x7d, 0xb8, 0x22, 0x5d, 0xe1, 0x20,
-	0xc5, 0x9b, 0x82, 0xcd, 0xbf, 0x10, 0x94, 0x8e, 0x42, 0x8f, 0x91, 0xa4, 0x31, 0x6d, 0xd0, 0x9c,
-	0x11, 0xf3, 0xa8, 0xcf, 0x2b, 0xfe, 0xa0, 0xad, 0xaf, 0xf0, 0x70, 0x70, 0x87, 0x23, 0x6c, 0x89,
-	0x4c, 0xea, 0x93, 0x5f, 0xd4, 0x27, 0xad, 0x83, 0x72, 0x5d, 0x1d, 0xd4, 0xbb, 0xd7, 0x21, 0xd3,
-	0x85, 0xc2, 0x46, 0x05, 0x6a, 0x19, 0x05, 0xfe, 0x92, 0x87, 0x1d, 0x99, 0xa8, 0xac, 0xd9, 0x6d,
-	0x32, 0x5d, 0xd6, 0x64, 0xfe, 0x3e, 0x34, 0xa9, 0xdc, 0x4e, 0x93, 0x37, 0x53, 0xe0, 0x62, 0x66,
-	0x68, 0x77, 0x9e, 0x47, 0x0d, 0x28, 0x75, 0x86, 0x34, 0x4c, 0x67, 0x9d, 0xec, 0x3e, 0x4a, 0xbb,
-	0xff, 0x34, 0x9e, 0x58, 0x99, 0xea, 0xe1, 0xc7, 0xa0, 0x1e, 0x0e, 0x3a, 0x83, 0x72, 0x4e, 0xaf,
-	0x9c, 0x9d, 0x37, 0x5e, 0xcd, 0x98, 0x62, 0x55, 0xe2, 0x3a, 0x14, 0x8e, 0xec, 0xfe, 0xe0, 0xa0,
-	0x8c, 0xf4, 0xea, 0xd9, 0x79, 0xa3, 0x9c, 0xb1, 0xf3, 0x23, 0x7e, 0x02, 0x5a, 0xef, 0xe5, 0x8b,
-	0x17, 0xfd, 0x41, 0x39, 0xaf, 0x3f, 0x3c, 0x3b, 0x6f, 0xbc, 0x96, 0x41, 0xf4, 0xf8, 0x0e, 0xd2,
-	0x2b, 0xdf, 0xfe, 0x60, 0xe4, 0x7e, 0xfe, 0xd1, 0xc8, 0x7e, 0xb7, 0xfd, 0xb7, 0x02, 0xaf, 0xc8,
-	0xc1, 0x8c, 0xdf, 0x97, 0x0b, 0x55, 0xdf, 0x30, 0xd2, 0x65, 0x26, 0xfa, 0xde, 0x46, 0x9b, 0x94,
-	0xcb, 0x4b, 0x50, 0xe3, 0x85, 0x81, 0x9f, 0xac, 0x80, 0xd6, 0x77, 0x8b, 0x6e, 0xfe, 0x1b, 0x44,
-	0xd0, 0x3d, 0x47, 0xf8, 0x00, 0x34, 0xb1, 0x3b, 0xf0, 0xeb, 0x2b, 0xf8, 0x4d, 0x2b, 0x45, 0xdf,
-	0x5d, 0x53, 0xc8, 0x41, 0xfc, 0x0f, 0x03, 0xee, 0x80, 0x1a, 0x8f, 0xe5, 0xb5, 0xc4, 0x32, 0x2b,
-	0x63, 0x2d, 0xb1, 0xec, 0x1c, 0x17, 0x91, 0xc8, 0x99, 0xfb, 0x68, 0xe3, 0xd4, 0x48, 0x68, 0x1e,
-	0x5f, 0x63, 0x95, 0x15, 0xfa, 0x00, 0x0a, 0xa2, 0x5f, 0x7b, 0x9b, 0xfe, 0x92, 0x12, 0x92, 0x47,
-	0x9b, 0x8d, 0x82, 0xa3, 0x89, 0x9e, 0x23, 0xfc, 0x2e, 0x14, 0xb8, 0xc0, 0xd6, 0x78, 0xb2, 0xb2,
-	0xbb, 0xae, 0x1e, 0xdd, 0xda, 0xc5, 0x95, 0x91, 0xfb, 0xed, 0xca, 0xc8, 0x7d, 0x3d, 0x37, 0xd0,
-	0xc5, 0xdc, 0x40, 0xbf, 0xce, 0x0d, 0xf4, 0xe7, 0xdc, 0x40, 0x43, 0x8d, 0x23, 0xdf, 0xfa, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0xbc, 0x66, 0xb9, 0xa0, 0x05, 0x0a, 0x00, 0x00,
+	// 984 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x4f, 0x6f, 0x1b, 0x45,
+	0x14, 0xcf, 0xac, 0xd7, 0x4b, 0xf2, 0xec, 0x04, 0x33, 0x71, 0x23, 0x6b, 0x93, 0xda, 0xee, 0x72,
+	0xc0, 0xaa, 0xd4, 0x75, 0x31, 0x87, 0x42, 0x01, 0x55, 0x8e, 0x93, 0xa2, 0xa0, 0x86, 0x4a, 0x5b,
+	0xa3, 0x8a, 0x53, 0xb5, 0xb6, 0xc7, 0x66, 0x15, 0xdb, 0xb3, 0xec, 0x8e, 0xa3, 0x06, 0x2e, 0x5c,
+	0x90, 0x50, 0x4e, 0x7c, 0x81, 0x5c, 0x28, 0x5f, 0x02, 0xbe, 0x00, 0x39, 0x72, 0x44, 0x1c, 0x0a,
+	0xcd, 0x81, 0xcf, 0x81, 0xe6, 0xcf, 0xda, 0xeb, 0xf5, 0x1a, 0x91, 0x34, 0x9c, 0x3c, 0x33, 0xef,
+	0xf7, 0x7e, 0xfb, 0xde, 0x9b, 0xdf, 0x7b, 0x63, 0x68, 0x0d, 0x3c, 0xf6, 0xe5, 0xa4, 0x63, 0x77,
+	0xe9, 0xa8, 0xde, 0xa5, 0x63, 0xe6, 0x7a, 0x63, 0x12, 0xf4, 0xe2, 0x4b, 0xd7, 0xf7, 0xea, 0x21,
+	0x09, 0x8e, 0xbd, 0x2e, 0x09, 0xc5, 0x39, 0x19, 0xb3, 0xe8, 0xd7, 0xf6, 0x03, 0xca, 0x28, 0x5e,
+	0x9f, 0xc1, 0xed, 0xe3, 0x77, 0xcd, 0xe2, 0x80, 0x0e, 0xa8, 0xb0, 0xd4, 0xf9, 0x4a, 0x82, 0xcc,
+	0xca, 0x80, 0xd2, 0xc1, 0x90, 0xd4, 0xc5, 0xae, 0x33, 0xe9, 0xd7, 0x99, 0x37, 0x22, 0x21, 0x73,
+	0x47, 0xbe, 0x02, 0x6c, 0x27, 0x01, 0x64, 0xe4, 0xb3, 0x13, 0x65, 0xac, 0x26, 0x8d, 0x7d, 0x8f,
+	0x0c, 0x7b, 0xcf, 0x46, 0x6e, 0x78, 0x24, 0x11, 0xd6, 0x0b, 0x0d, 0xf4, 0x83, 0x71, 0x9f, 0xe2,
+	0x4f, 0xc1, 0xe8, 0x79, 0x03, 0x12, 0xb2, 0x12, 0xaa, 0xa2, 0xda, 0xda, 0x6e, 0xe3, 0xfc, 0x65,
+	0x65, 0xe5, 0x8f, 0x97, 0x95, 0xdb, 0xb1, 0x54, 0xa9, 0x4f, 0xc6, 0xd3, 0xa0, 0xc3, 0xfa, 0x80,
+	0xde, 0x91, 0x2e, 0xf6, 0x9e, 0xf8, 0x71, 0x14, 0x03, 0xc6, 0xa0, 0x87, 0xde, 0xd7, 0xa4, 0xa4,
+	0x55, 0x51, 0x2d, 0xe3, 0x88, 0x35, 0xfe, 0x04, 0xf2, 0x5d, 0x3a, 0x1a, 0x79, 0x8c, 0x91, 0xde,
+	0x33, 0x97, 0x95, 0x32, 0x55, 0x54, 0xcb, 0x35, 0x4c, 0x5b, 0x46, 0x68, 0x47, 0x11, 0xda, 0xed,
+	0x28, 0xbf, 0xdd, 0x55, 0x1e, 0xc1, 0x0f, 0x7f, 0x56, 0x90, 0x93, 0x9b, 0x7a, 0x36, 0x19, 0xbe,
+	0x07, 0xc6, 0xd0, 0xed, 0x90, 0x61, 0x58, 0xd2, 0xab, 0x99, 0x5a, 0xae, 0x51, 0xb1, 0xe7, 0xea,
+	0x68, 0xf3, 0x6c, 0xec, 0x47, 0x02, 0xb1, 0x3f, 0x66, 0xc1, 0x89, 0xa3, 0xe0, 0xe6, 0x07, 0x90,
+	0x8b, 0x1d, 0xe3, 0x02, 0x64, 0x8e, 0xc8, 0x89, 0xcc, 0xd6, 0xe1, 0x4b, 0x5c, 0x84, 0xec, 0xb1,
+	0x3b, 0x9c, 0xc8, 0xb8, 0xd7, 0x1c, 0xb9, 0xb9, 0xaf, 0xbd, 0x8f, 0xac, 0x2f, 0x20, 0xc7, 0x69,
+	0x1d, 0xf2, 0xd5, 0x84, 0xe7, 0x77, 0x8d, 0xb5, 0xb2, 0x3e, 0x86, 0xbc, 0xa4, 0x0e, 0x7d, 0x3a,
+	0x0e, 0x09, 0xbe, 0x03, 0xba, 0x37, 0xee, 0x53, 0xc1, 0x9c, 0x6b, 0x6c, 0xa6, 0x24, 0xb7, 0xab,
+	0xf3, 0xcf, 0x39, 0x02, 0x66, 0x7d, 0x03, 0xeb, 0x9f, 0xfb, 0x3d, 0x97, 0x91, 0x28, 0xb6, 0xcb,
+	0xf9, 0xe3, 0x0f, 0x21, 0x37, 0x11, 0xfe, 0x42, 0x14, 0x25, 0x6d, 0xc9, 0xad, 0x3c, 0xe4, 0xba,
+	0x39, 0x74, 0xc3, 0x23, 0x07, 0x24, 0x9c, 0xaf, 0xad, 0x07, 0xb0, 0x11, 0x7d, 0xfc, 0x6a, 0xd1,
+	0x17, 0x01, 0x3f, 0xf2, 0x42, 0xd6, 0x92, 0x7d, 0xa1, 0x52, 0xb0, 0xf6, 0x60, 0x73, 0xee, 0x74,
+	0x81, 0x3b, 0xf3, 0x5f, 0xb8, 0x3b, 0x50, 0xdc, 0x23, 0x43, 0xc2, 0xc8, 0x3c, 0xfb, 0xb5, 0x5e,
+	0xde, 0x77, 0x08, 0x72, 0x0e, 0x71, 0x7b, 0xff, 0x03, 0x37, 0xde, 0x02, 0x83, 0xf6, 0xfb, 0x21,
+	0x61, 0xaa, 0x8d, 0xd4, 0x6e, 0xda, 0x5c, 0x99, 0x59, 0x73, 0x59, 0xf7, 0x21, 0x2f, 0xc3, 0x50,
+	0xa5, 0x9a, 0xf9, 0xa2, 0xa4, 0x6f, 0xcf, 0x65, 0xae, 0x60, 0xcc, 0x3b, 0x62, 0x6d, 0xbd, 0x03,
+	0xeb, 0x4f, 0x98, 0xcb, 0x26, 0x61, 0x94, 0xc4, 0x16, 0x18, 0x01, 0x19, 0x90, 0xe7, 0xbe, 0xea,
+	0x0d, 0xb5, 0xb3, 0x7e, 0xd4, 0xc0, 0x90, 0x48, 0xdc, 0x02, 0x08, 0x99, 0x1b, 0xa8, 0x56, 0x46,
+	0x97, 0x68, 0xe5, 0x35, 0xe5, 0xd7, 0x64, 0x9c, 0x44, 0x6a, 0x49, 0x90, 0x68, 0x97, 0x21, 0x51,
+	0x7e, 0x4d, 0xc6, 0xbb, 0x38, 0x20, 0x7d, 0x51, 0x8c, 0x35, 0x87, 0x2f, 0x63, 0xb9, 0xeb, 0x73,
+	0xb9, 0x17, 0x21, 0xcb, 0x28, 0x73, 0x87, 0xa5, 0xac, 0x38, 0x96, 0x1b, 0xfc, 0x19, 0xac, 0x92,
+	0xe7, 0x3e, 0xe9, 0x32, 0xd2, 0x2b, 0x19, 0x57, 0xbe, 0xb3, 0x29, 0x87, 0x75, 0x00, 0x1b, 0x51,
+	0x35, 0xd5, 0x5d, 0xdc, 0x83, 0xd5, 0x50, 0x9c, 0x90, 0x50, 0x49, 0xf7, 0x46, 0x42, 0xba, 0xd2,
+	0x41, 0x89, 0x77, 0x0a, 0xb6, 0xfe, 0x46, 0x90, 0x7f, 0x1a, 0x78, 0xb3, 0xd6, 0x6e, 0x80, 0xe1,
+	0x76, 0x99, 0x47, 0xc7, 0xa2, 0xe2, 0x1b, 0x0d, 0x33, 0xc1, 0x23, 0xc0, 0x4d, 0x81, 0x70, 0x14,
+	0x32, 0xaa, 0x8f, 0x36, 0xab, 0xcf, 0xb4, 0x0e, 0x99, 0x65, 0x75, 0xd0, 0x5f, 0xbf, 0x0e, 0xb1,
+	0x5b, 0xc8, 0xa6, 0x2a, 0xd0, 0x88, 0x29, 0xf0, 0x57, 0x0d, 0xd6, 0x55, 0xa2, 0xaa, 0x66, 0x57,
+	0xc9, 0x74, 0x5e, 0x93, 0xda, 0x75, 0x68, 0x32, 0x73, 0x35, 0x4d, 0x5e, 0x4e, 0x81, 0xb3, 0x99,
+	0x61, 0xbc, 0xf6, 0x3c, 0xaa, 0x42, 0xbe, 0xd9, 0xa1, 0xc1, 0x74, 0xd6, 0xa9, 0xdb, 0x47, 0xd3,
+	0xdb, 0xbf, 0xcd, 0x27, 0x56, 0xac, 0x7a, 0xf8, 0x26, 0xe8, 0x4f, 0xda, 0xcd, 0x76, 0x61, 0xc5,
+	0xdc, 0x3c, 0x3d, 0xab, 0xbe, 0x19, 0x33, 0x71, 0x55, 0xe2, 0x0a, 0x64, 0x9f, 0x3a, 0x07, 0xed,
+	0xfd, 0x02, 0x32, 0x8b, 0xa7, 0x67, 0xd5, 0x42, 0xcc, 0x2e, 0x96, 0xf8, 0x16, 0x18, 0xad, 0xc7,
+	0x87, 0x87, 0x07, 0xed, 0x82, 0x66, 0xde, 0x38, 0x3d, 0xab, 0xbe, 0x15, 0x43, 0xb4, 0xc4, 0xab,
+	0x6d, 0x6e, 0x7e, 0xff, 0xa2, 0xbc, 0xf2, 0xcb, 0x4f, 0xe5, 0xf8, 0x77, 0x1b, 0x3f, 0xeb, 0xf0,
+	0x86, 0x1a, 0xcc, 0xf8, 0x81, 0xfa, 0x0b, 0x62, 0xa6, 0x8c, 0x74, 0x95, 0x89, 0xb9, 0x9d, 0x6a,
+	0x53, 0x72, 0xd9, 0x07, 0x43, 0xbe, 0x43, 0x78, 0x27, 0x01, 0x9b, 0x7b, 0x1b, 0xcd, 0x9b, 0x4b,
+	0xac, 0x8a, 0xe6, 0x31, 0xe8, 0xfc, 0xdd, 0xc1, 0xb7, 0x12, 0xb0, 0xc5, 0x27, 0xca, 0xb4, 0xfe,
+	0x0d, 0x22, 0xe9, 0xee, 0x22, 0x1e, 0x97, 0x7c, 0x82, 0xf0, 0xdb, 0x09, 0x7c, 0xda, 0xcb, 0x64,
+	0x6e, 0x2d, 0x08, 0x6d, 0x9f, 0xff, 0x97, 0xc3, 0x4d, 0xd0, 0xf9, 0x74, 0x5f, 0xa8, 0x4f, 0xec,
+	0xe5, 0x31, 0xb7, 0x53, 0x6d, 0xf1, 0x48, 0xd4, 0xe8, 0xde, 0x49, 0x1d, 0x3e, 0xcb, 0x2a, 0x94,
+	0x98, 0x65, 0x0f, 0x21, 0x2b, 0xaf, 0x7d, 0x3b, 0xad, 0x21, 0x23, 0x92, 0x9d, 0x74, 0xa3, 0xe4,
+	0xa8, 0xa1, 0xbb, 0x08, 0x7f, 0x04, 0x59, 0xa1, 0xd3, 0x05, 0x9e, 0xb8, 0x7a, 0x97, 0xd5, 0x63,
+	0xb7, 0x74, 0xfe, 0xaa, 0xbc, 0xf2, 0xfb, 0xab, 0xf2, 0xca, 0xb7, 0x17, 0x65, 0x74, 0x7e, 0x51,
+	0x46, 0xbf, 0x5d, 0x94, 0xd1, 0x5f, 0x17, 0x65, 0xd4, 0x31, 0x04, 0xf2, 0xbd, 0x7f, 0x06, 0x00,
+	0x90, 0x2e, 0x30, 0xe1, 0xa0, 0x0b, 0x00, 0x00,
 }
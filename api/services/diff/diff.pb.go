@@ -25,6 +25,8 @@ import _ "github.com/gogo/protobuf/types"
 import containerd_v1_types "github.com/containerd/containerd/api/types/mount"
 import containerd_v1_types1 "github.com/containerd/containerd/api/types/descriptor"
 
+import github_com_opencontainers_go_digest "github.com/opencontainers/go-digest"
+
 import (
 	context "golang.org/x/net/context"
 	grpc "google.golang.org/grpc"
@@ -50,6 +52,22 @@ type ApplyRequest struct {
 	// Diff is the descriptor of the diff to be extracted
 	Diff   *containerd_v1_types1.Descriptor `protobuf:"bytes,1,opt,name=diff" json:"diff,omitempty"`
 	Mounts []*containerd_v1_types.Mount     `protobuf:"bytes,2,rep,name=mounts" json:"mounts,omitempty"`
+	// Snapshotter is the snapshotter of Name, the default snapshotter of
+	// the daemon if empty.
+	Snapshotter string `protobuf:"bytes,3,opt,name=snapshotter,proto3" json:"snapshotter,omitempty"`
+	// Name is the snapshot to unpack the diff to. If set, Mounts is ignored:
+	// the daemon prepares a snapshot on top of Parent, applies the diff and
+	// commits it as Name within the call, so that no client can access the
+	// snapshot before it is committed. The snapshotter records the applied
+	// diff, allowing the committed snapshot to be shared with other
+	// namespaces. If Name exists, or a snapshot with the same layers is
+	// shared as Name, nothing is applied and AlreadyExists is returned.
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// Parent is the committed snapshot Name is unpacked on top of.
+	Parent string `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	// DiffID is the expected digest of the uncompressed diff. If set, Name
+	// is only committed when the applied diff has this digest.
+	DiffID github_com_opencontainers_go_digest.Digest `protobuf:"bytes,6,opt,name=diff_id,json=diffId,proto3,customtype=github.com/opencontainers/go-digest.Digest" json:"diff_id"`
 }
 
 func (m *ApplyRequest) Reset()                    { *m = ApplyRequest{} }
@@ -254,6 +272,30 @@ func (m *ApplyRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Snapshotter) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDiff(dAtA, i, uint64(len(m.Snapshotter)))
+		i += copy(dAtA[i:], m.Snapshotter)
+	}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDiff(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Parent) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDiff(dAtA, i, uint64(len(m.Parent)))
+		i += copy(dAtA[i:], m.Parent)
+	}
+	if len(m.DiffID) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDiff(dAtA, i, uint64(len(m.DiffID)))
+		i += copy(dAtA[i:], m.DiffID)
+	}
 	return i, nil
 }
 
@@ -407,6 +449,22 @@ func (m *ApplyRequest) Size() (n int) {
 			n += 1 + l + sovDiff(uint64(l))
 		}
 	}
+	l = len(m.Snapshotter)
+	if l > 0 {
+		n += 1 + l + sovDiff(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDiff(uint64(l))
+	}
+	l = len(m.Parent)
+	if l > 0 {
+		n += 1 + l + sovDiff(uint64(l))
+	}
+	l = len(m.DiffID)
+	if l > 0 {
+		n += 1 + l + sovDiff(uint64(l))
+	}
 	return n
 }
 
@@ -476,6 +534,10 @@ func (this *ApplyRequest) String() string {
 	s := strings.Join([]string{`&ApplyRequest{`,
 		`Diff:` + strings.Replace(fmt.Sprintf("%v", this.Diff), "Descriptor", "containerd_v1_types1.Descriptor", 1) + `,`,
 		`Mounts:` + strings.Replace(fmt.Sprintf("%v", this.Mounts), "Mount", "containerd_v1_types.Mount", 1) + `,`,
+		`Snapshotter:` + fmt.Sprintf("%v", this.Snapshotter) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Parent:` + fmt.Sprintf("%v", this.Parent) + `,`,
+		`DiffID:` + fmt.Sprintf("%v", this.DiffID) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -614,6 +676,122 @@ func (m *ApplyRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshotter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDiff
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDiff
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Snapshotter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDiff
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDiff
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDiff
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDiff
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Parent = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiffID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDiff
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDiff
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DiffID = github_com_opencontainers_go_digest.Digest(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDiff(dAtA[iNdEx:])
@@ -1081,31 +1259,37 @@ func init() {
 }
 
 var fileDescriptorDiff = []byte{
-	// 407 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0x41, 0xcf, 0x93, 0x40,
-	0x10, 0xfd, 0x56, 0xda, 0xcf, 0x74, 0xdb, 0x26, 0x66, 0xe3, 0x81, 0x50, 0xa5, 0x0d, 0xa7, 0x9e,
-	0x40, 0xe9, 0xc9, 0x44, 0x63, 0xac, 0x8d, 0x07, 0x13, 0x2f, 0xc4, 0xbb, 0xa1, 0x30, 0xd0, 0x4d,
-	0x80, 0x5d, 0xd9, 0xa5, 0x86, 0x9b, 0x77, 0xff, 0x84, 0x3f, 0xa7, 0x47, 0x8f, 0x1e, 0x2d, 0xbf,
-	0xc4, 0xb0, 0x6c, 0x15, 0x9b, 0x26, 0xb6, 0x97, 0xcd, 0xb0, 0xef, 0xcd, 0x9b, 0x37, 0x8f, 0xc5,
-	0xaf, 0x52, 0x2a, 0x77, 0xd5, 0xd6, 0x8d, 0x58, 0xee, 0x45, 0xac, 0x90, 0x21, 0x2d, 0xa0, 0x8c,
-	0xfb, 0x65, 0xc8, 0xa9, 0x27, 0xa0, 0xdc, 0xd3, 0x08, 0x84, 0x17, 0xd3, 0x24, 0x51, 0x87, 0xcb,
-	0x4b, 0x26, 0x19, 0x99, 0xfe, 0x25, 0xba, 0xfb, 0xe7, 0xd6, 0xe3, 0x94, 0xa5, 0x4c, 0x21, 0x5e,
-	0x5b, 0x75, 0x24, 0x6b, 0x96, 0x32, 0x96, 0x66, 0xe0, 0xa9, 0xaf, 0x6d, 0x95, 0x78, 0x90, 0x73,
-	0x59, 0x6b, 0x70, 0x7e, 0x0e, 0x4a, 0x9a, 0x83, 0x90, 0x61, 0xce, 0x35, 0xe1, 0xe5, 0x55, 0x0e,
-	0x65, 0xcd, 0x41, 0x78, 0x39, 0xab, 0x0a, 0xd9, 0x9d, 0xba, 0xfb, 0xdd, 0x0d, 0xdd, 0x31, 0x88,
-	0xa8, 0xa4, 0x5c, 0xb2, 0xb2, 0x57, 0x76, 0x3a, 0xce, 0x17, 0x3c, 0x79, 0xc3, 0x79, 0x56, 0x07,
-	0xf0, 0xb9, 0x02, 0x21, 0xc9, 0x0a, 0x0f, 0xda, 0x18, 0x4c, 0xb4, 0x40, 0xcb, 0xb1, 0x3f, 0x77,
-	0xff, 0xc9, 0xc1, 0x55, 0x7a, 0xee, 0xe6, 0x8f, 0x48, 0xa0, 0xc8, 0xc4, 0xc7, 0xf7, 0xca, 0x9b,
-	0x30, 0x1f, 0x2c, 0x8c, 0xe5, 0xd8, 0xb7, 0x2e, 0xb6, 0x7d, 0x68, 0x29, 0x81, 0x66, 0x3a, 0xef,
-	0xf1, 0x54, 0x0f, 0x16, 0x9c, 0x15, 0x02, 0xc8, 0x0b, 0xfc, 0x30, 0xe4, 0x3c, 0xa3, 0x10, 0x5f,
-	0x3b, 0xfc, 0xc4, 0x77, 0xbe, 0x23, 0x3c, 0xde, 0xd0, 0x24, 0x39, 0x2d, 0xe1, 0xe2, 0x41, 0x06,
-	0x89, 0x34, 0xd1, 0x7f, 0xdd, 0x28, 0x1e, 0x79, 0x86, 0x87, 0x25, 0x4d, 0x77, 0xf2, 0x0a, 0xfb,
-	0x1d, 0x91, 0x3c, 0xc5, 0x38, 0x87, 0x98, 0x86, 0x9f, 0x5a, 0xcc, 0x34, 0x16, 0x68, 0x39, 0x0a,
-	0x46, 0xea, 0xe6, 0x63, 0xcd, 0x81, 0x3c, 0xc2, 0x46, 0x09, 0x89, 0x39, 0x54, 0xf7, 0x6d, 0xe9,
-	0xbc, 0xc5, 0x93, 0xce, 0xa1, 0xde, 0xf6, 0x94, 0xb3, 0x71, 0x43, 0xce, 0xfe, 0x37, 0x84, 0x07,
-	0xad, 0x0a, 0x59, 0xe3, 0xa1, 0x0a, 0x8f, 0xcc, 0xce, 0x1a, 0xfb, 0xff, 0xd2, 0x7a, 0x72, 0x19,
-	0xd4, 0x0e, 0x5e, 0x6b, 0xad, 0xf3, 0x6d, 0x7b, 0x41, 0x5a, 0xb3, 0x8b, 0x58, 0x27, 0xb0, 0x36,
-	0x0f, 0x47, 0xfb, 0xee, 0xe7, 0xd1, 0xbe, 0xfb, 0xda, 0xd8, 0xe8, 0xd0, 0xd8, 0xe8, 0x47, 0x63,
-	0xa3, 0x5f, 0x8d, 0x8d, 0xb6, 0xf7, 0xea, 0x6d, 0xad, 0x7e, 0x07, 0x00, 0x00, 0xff, 0xff, 0x61,
-	0x65, 0x17, 0x47, 0x85, 0x03, 0x00, 0x00,
+	// 498 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0x41, 0x8b, 0xd3, 0x40,
+	0x14, 0xc7, 0x3b, 0xdb, 0x34, 0xcb, 0x4e, 0x77, 0x41, 0x06, 0x91, 0x90, 0x6a, 0x52, 0x7a, 0x2a,
+	0x82, 0x89, 0x76, 0x4f, 0xc2, 0x8a, 0x58, 0x8b, 0xb0, 0x82, 0x97, 0xa0, 0xe7, 0x25, 0x6d, 0x5e,
+	0xd2, 0x81, 0x26, 0x33, 0x66, 0xa6, 0x0b, 0xbd, 0x79, 0xf7, 0x2b, 0x78, 0xf0, 0xe3, 0xf4, 0xe8,
+	0x51, 0x3c, 0x14, 0x37, 0x9f, 0x44, 0x66, 0x32, 0x5d, 0x63, 0x29, 0xd8, 0x5e, 0x26, 0x6f, 0xe6,
+	0xff, 0x7f, 0x7f, 0xde, 0xfc, 0x98, 0xe0, 0x57, 0x19, 0x95, 0xf3, 0xe5, 0x34, 0x98, 0xb1, 0x3c,
+	0x9c, 0xb1, 0x42, 0xc6, 0xb4, 0x80, 0x32, 0x69, 0x96, 0x31, 0xa7, 0xa1, 0x80, 0xf2, 0x96, 0xce,
+	0x40, 0x84, 0x09, 0x4d, 0x53, 0xbd, 0x04, 0xbc, 0x64, 0x92, 0x91, 0x8b, 0xbf, 0xc6, 0xe0, 0xf6,
+	0x85, 0xfb, 0x30, 0x63, 0x19, 0xd3, 0x4a, 0xa8, 0xaa, 0xda, 0xe4, 0xf6, 0x32, 0xc6, 0xb2, 0x05,
+	0x84, 0x7a, 0x37, 0x5d, 0xa6, 0x21, 0xe4, 0x5c, 0xae, 0x8c, 0xe8, 0xef, 0x8a, 0x92, 0xe6, 0x20,
+	0x64, 0x9c, 0x73, 0x63, 0xb8, 0x3a, 0x68, 0x42, 0xb9, 0xe2, 0x20, 0xc2, 0x9c, 0x2d, 0x0b, 0x59,
+	0xaf, 0xa6, 0xfb, 0xdd, 0x11, 0xdd, 0x09, 0x88, 0x59, 0x49, 0xb9, 0x64, 0x65, 0xa3, 0xac, 0x73,
+	0x06, 0xdf, 0x4e, 0xf0, 0xf9, 0x1b, 0xce, 0x17, 0xab, 0x08, 0x3e, 0x2f, 0x41, 0x48, 0x72, 0x89,
+	0x2d, 0xc5, 0xc1, 0x41, 0x7d, 0x34, 0xec, 0x8e, 0xfc, 0xe0, 0x1f, 0x10, 0x81, 0x0e, 0x0c, 0x26,
+	0xf7, 0x29, 0x91, 0x36, 0x93, 0x11, 0xb6, 0xf5, 0x70, 0xc2, 0x39, 0xe9, 0xb7, 0x87, 0xdd, 0x91,
+	0xbb, 0xb7, 0xed, 0x83, 0xb2, 0x44, 0xc6, 0x49, 0xfa, 0xb8, 0x2b, 0x8a, 0x98, 0x8b, 0x39, 0x93,
+	0x12, 0x4a, 0xa7, 0xdd, 0x47, 0xc3, 0xb3, 0xa8, 0x79, 0x44, 0x08, 0xb6, 0x8a, 0x38, 0x07, 0xc7,
+	0xd2, 0x92, 0xae, 0xc9, 0x23, 0x6c, 0xf3, 0xb8, 0x84, 0x42, 0x3a, 0x1d, 0x7d, 0x6a, 0x76, 0xe4,
+	0x13, 0x3e, 0x55, 0x93, 0xdc, 0xd0, 0xc4, 0xb1, 0x95, 0x30, 0xbe, 0x5a, 0x6f, 0xfc, 0xd6, 0xaf,
+	0x8d, 0xff, 0xb4, 0x01, 0x8a, 0x71, 0x28, 0xee, 0x07, 0x13, 0x61, 0xc6, 0x9e, 0x25, 0x34, 0x03,
+	0x21, 0x83, 0x89, 0xfe, 0x54, 0x1b, 0xdf, 0x9e, 0xd0, 0x34, 0xbd, 0x9e, 0x44, 0xb6, 0x0a, 0xbb,
+	0x4e, 0x06, 0xef, 0xf1, 0x85, 0xa1, 0x23, 0x38, 0x2b, 0x04, 0x90, 0x97, 0xf8, 0x34, 0xe6, 0x7c,
+	0x41, 0x21, 0x39, 0x94, 0xd0, 0xd6, 0x3f, 0xf8, 0x8e, 0x70, 0x57, 0xc5, 0x6f, 0x49, 0x07, 0xd8,
+	0x5a, 0x40, 0x2a, 0x1d, 0xf4, 0x5f, 0x64, 0xda, 0x47, 0x9e, 0xe3, 0x4e, 0x49, 0xb3, 0xb9, 0x3c,
+	0x80, 0x71, 0x6d, 0x24, 0x4f, 0x30, 0xce, 0x21, 0xa1, 0xf1, 0x8d, 0xd2, 0x0c, 0xe1, 0x33, 0x7d,
+	0xf2, 0x71, 0xc5, 0x81, 0x3c, 0xc0, 0xed, 0x12, 0x52, 0x03, 0x52, 0x95, 0x83, 0xb7, 0xf8, 0xbc,
+	0x9e, 0xd0, 0xdc, 0x76, 0xfb, 0x18, 0xda, 0x47, 0x3c, 0x86, 0xd1, 0x57, 0x84, 0x2d, 0x95, 0x42,
+	0xc6, 0xb8, 0xa3, 0xe1, 0x91, 0xde, 0x4e, 0x63, 0xf3, 0xc1, 0xb9, 0x8f, 0xf7, 0x8b, 0x66, 0x82,
+	0xd7, 0x26, 0x6b, 0xf7, 0xb6, 0x0d, 0x90, 0x6e, 0x6f, 0xaf, 0x56, 0x07, 0x8c, 0x9d, 0xf5, 0x9d,
+	0xd7, 0xfa, 0x79, 0xe7, 0xb5, 0xbe, 0x54, 0x1e, 0x5a, 0x57, 0x1e, 0xfa, 0x51, 0x79, 0xe8, 0x77,
+	0xe5, 0xa1, 0xa9, 0xad, 0xff, 0x80, 0xcb, 0x3f, 0x03, 0x00, 0xa9, 0x3c, 0x19, 0x5a, 0x2b, 0x04,
+	0x00, 0x00,
 }
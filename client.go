@@ -19,19 +19,24 @@ import (
 	versionservice "github.com/containerd/containerd/api/services/version"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
+	"github.com/containerd/containerd/remotes/oci"
+	"github.com/containerd/containerd/remotes/policy"
 	contentservice "github.com/containerd/containerd/services/content"
 	"github.com/containerd/containerd/services/diff"
 	diffservice "github.com/containerd/containerd/services/diff"
 	imagesservice "github.com/containerd/containerd/services/images"
 	snapshotservice "github.com/containerd/containerd/services/snapshot"
+	"github.com/containerd/containerd/signature"
 	"github.com/containerd/containerd/snapshot"
 	pempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/opencontainers/image-spec/identity"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -106,11 +111,21 @@ func WithContainerLabels(labels map[string]string) NewContainerOpts {
 	}
 }
 
+// WithSnapshotter sets the snapshotter holding the root filesystem of the
+// container. It must be given before the options creating the root
+// filesystem, which use the snapshotter.
+func WithSnapshotter(name string) NewContainerOpts {
+	return func(ctx context.Context, client *Client, c *containers.Container) error {
+		c.Snapshotter = name
+		return nil
+	}
+}
+
 // WithExistingRootFS uses an existing root filesystem for the container
 func WithExistingRootFS(id string) NewContainerOpts {
 	return func(ctx context.Context, client *Client, c *containers.Container) error {
 		// check that the snapshot exists, if not, fail on creation
-		if _, err := client.SnapshotService().Mounts(ctx, id); err != nil {
+		if _, err := client.SnapshotService(WithSnapshotterName(c.Snapshotter)).Mounts(ctx, id); err != nil {
 			return err
 		}
 		c.RootFS = id
@@ -126,7 +141,7 @@ func WithNewRootFS(id string, i Image) NewContainerOpts {
 		if err != nil {
 			return err
 		}
-		if _, err := client.SnapshotService().Prepare(ctx, id, identity.ChainID(diffIDs).String()); err != nil {
+		if _, err := client.SnapshotService(WithSnapshotterName(c.Snapshotter)).Prepare(ctx, id, identity.ChainID(diffIDs).String()); err != nil {
 			return err
 		}
 		c.RootFS = id
@@ -142,7 +157,7 @@ func WithNewReadonlyRootFS(id string, i Image) NewContainerOpts {
 		if err != nil {
 			return err
 		}
-		if _, err := client.SnapshotService().View(ctx, id, identity.ChainID(diffIDs).String()); err != nil {
+		if _, err := client.SnapshotService(WithSnapshotterName(c.Snapshotter)).View(ctx, id, identity.ChainID(diffIDs).String()); err != nil {
 			return err
 		}
 		c.RootFS = id
@@ -201,7 +216,11 @@ type RemoteOpts func(*Client, *RemoteContext) error
 // remote content stores and image providers.
 type RemoteContext struct {
 	// Resolver is used to resolve names to objects, fetchers, and pushers.
-	// If no resolver is provided, defaults to Docker registry resolver.
+	// If no resolver is provided, references with the "oci:" scheme are
+	// resolved against an OCI image layout directory and all others default
+	// to the Docker registry resolver. The default Docker resolver records
+	// the repositories blobs are fetched from and pushed to as labels in the
+	// content store, so that later pushes can mount the blobs.
 	Resolver remotes.Resolver
 
 	// Unpack is done after an image is pulled to extract into a snapshotter.
@@ -209,6 +228,10 @@ type RemoteContext struct {
 	// afterwards. Unpacking is required to run an image.
 	Unpack bool
 
+	// Snapshotter is the name of the snapshotter used for unpacking, the
+	// default snapshotter of the daemon if empty.
+	Snapshotter string
+
 	// PushWrapper allows hooking into the push method. This can be used
 	// track content that is being sent to the remote.
 	PushWrapper func(remotes.Pusher) remotes.Pusher
@@ -217,14 +240,38 @@ type RemoteContext struct {
 	// These handlers always get called before any operation specific
 	// handlers.
 	BaseHandlers []images.Handler
+
+	// MaxConcurrency limits the number of resources fetched or pushed
+	// concurrently. If zero, all siblings are transferred at once.
+	MaxConcurrency int
+
+	// BandwidthLimiter limits the rate at which content is fetched or
+	// pushed. A limiter may be shared between operations to limit their
+	// combined rate.
+	BandwidthLimiter *remotes.BandwidthLimiter
+
+	// SignaturePolicy verifies the signatures of the resolved manifest on
+	// pull. If verification fails, the image is not registered.
+	SignaturePolicy *signature.Policy
 }
 
 func defaultRemoteContext() *RemoteContext {
-	return &RemoteContext{
-		Resolver: docker.NewResolver(docker.ResolverOptions{
-			Client: http.DefaultClient,
-		}),
+	return &RemoteContext{}
+}
+
+// resolver returns the configured resolver or the default resolver for the
+// scheme of ref.
+func (rc *RemoteContext) resolver(client *Client, ref string) remotes.Resolver {
+	if rc.Resolver != nil {
+		return rc.Resolver
+	}
+	if oci.IsReference(ref) {
+		return oci.NewResolver()
 	}
+	return docker.NewResolver(docker.ResolverOptions{
+		Client:  http.DefaultClient,
+		Sources: docker.NewContentSourceTracker(client.ContentStore()),
+	})
 }
 
 // WithPullUnpack is used to unpack an image after pull. This
@@ -235,6 +282,14 @@ func WithPullUnpack(client *Client, c *RemoteContext) error {
 	return nil
 }
 
+// WithPullSnapshotter specifies the snapshotter to unpack the image into.
+func WithPullSnapshotter(snapshotterName string) RemoteOpts {
+	return func(client *Client, c *RemoteContext) error {
+		c.Snapshotter = snapshotterName
+		return nil
+	}
+}
+
 // WithResolver specifies the resolver to use.
 func WithResolver(resolver remotes.Resolver) RemoteOpts {
 	return func(client *Client, c *RemoteContext) error {
@@ -251,6 +306,33 @@ func WithImageHandler(h images.Handler) RemoteOpts {
 	}
 }
 
+// WithMaxConcurrency limits the number of resources fetched or pushed
+// concurrently.
+func WithMaxConcurrency(n int) RemoteOpts {
+	return func(client *Client, c *RemoteContext) error {
+		c.MaxConcurrency = n
+		return nil
+	}
+}
+
+// WithBandwidthLimiter limits the rate of content transfers to the rate of
+// the limiter. Share the limiter between operations to limit their combined
+// rate.
+func WithBandwidthLimiter(l *remotes.BandwidthLimiter) RemoteOpts {
+	return func(client *Client, c *RemoteContext) error {
+		c.BandwidthLimiter = l
+		return nil
+	}
+}
+
+// WithSignaturePolicy verifies pulled images against the policy.
+func WithSignaturePolicy(p *signature.Policy) RemoteOpts {
+	return func(client *Client, c *RemoteContext) error {
+		c.SignaturePolicy = p
+		return nil
+	}
+}
+
 // WithPushWrapper is used to wrap a pusher to hook into
 // the push content as it is sent to a remote.
 func WithPushWrapper(w func(remotes.Pusher) remotes.Pusher) RemoteOpts {
@@ -260,6 +342,29 @@ func WithPushWrapper(w func(remotes.Pusher) remotes.Pusher) RemoteOpts {
 	}
 }
 
+// admit checks ref against the policy set in the labels of the namespace
+// before any content is fetched. The images service checks the daemon
+// policy when the pulled image is registered.
+func (c *Client) admit(ctx context.Context, ref string) error {
+	namespace, ok := namespaces.Namespace(ctx)
+	if !ok {
+		return nil
+	}
+	resp, err := c.NamespaceService().Get(ctx, &namespacesapi.GetNamespaceRequest{
+		Name: namespace,
+	})
+	if err != nil {
+		if grpc.Code(err) == codes.NotFound {
+			return nil
+		}
+		return err
+	}
+	if err := policy.RulesFromLabels(resp.Namespace.Labels).Check(ref); err != nil {
+		return errors.Wrapf(err, "namespace %v", namespace)
+	}
+	return nil
+}
+
 func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpts) (Image, error) {
 	pullCtx := defaultRemoteContext()
 	for _, o := range opts {
@@ -267,22 +372,33 @@ func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpts) (Imag
 			return nil, err
 		}
 	}
+	if err := c.admit(ctx, ref); err != nil {
+		return nil, err
+	}
 	store := c.ContentStore()
+	resolver := pullCtx.resolver(c, ref)
 
-	name, desc, err := pullCtx.Resolver.Resolve(ctx, ref)
+	name, desc, err := resolver.Resolve(ctx, ref)
 	if err != nil {
 		return nil, err
 	}
-	fetcher, err := pullCtx.Resolver.Fetcher(ctx, name)
+	fetcher, err := resolver.Fetcher(ctx, name)
 	if err != nil {
 		return nil, err
 	}
+	if pullCtx.BandwidthLimiter != nil {
+		fetcher = remotes.LimitFetcher(fetcher, pullCtx.BandwidthLimiter)
+	}
 
 	handlers := append(pullCtx.BaseHandlers,
 		remotes.FetchHandler(store, fetcher),
 		images.ChildrenHandler(store),
 	)
-	if err := images.Dispatch(ctx, images.Handlers(handlers...), desc); err != nil {
+	var handler images.Handler = images.Handlers(handlers...)
+	if pullCtx.SignaturePolicy != nil {
+		handler = pullCtx.SignaturePolicy.Handler(name, desc, handler)
+	}
+	if err := images.DispatchLimit(ctx, handler, pullCtx.MaxConcurrency, desc); err != nil {
 		return nil, err
 	}
 	is := c.ImageService()
@@ -298,7 +414,7 @@ func (c *Client) Pull(ctx context.Context, ref string, opts ...RemoteOpts) (Imag
 		i:      i,
 	}
 	if pullCtx.Unpack {
-		if err := img.Unpack(ctx); err != nil {
+		if err := img.Unpack(ctx, WithUnpackSnapshotter(pullCtx.Snapshotter)); err != nil {
 			return nil, err
 		}
 	}
@@ -313,11 +429,14 @@ func (c *Client) Push(ctx context.Context, ref string, desc ocispec.Descriptor,
 		}
 	}
 
-	pusher, err := pushCtx.Resolver.Pusher(ctx, ref)
+	pusher, err := pushCtx.resolver(c, ref).Pusher(ctx, ref)
 	if err != nil {
 		return err
 	}
 
+	if pushCtx.BandwidthLimiter != nil {
+		pusher = remotes.LimitPusher(pusher, pushCtx.BandwidthLimiter)
+	}
 	if pushCtx.PushWrapper != nil {
 		pusher = pushCtx.PushWrapper(pusher)
 	}
@@ -347,7 +466,7 @@ func (c *Client) Push(ctx context.Context, ref string, desc ocispec.Descriptor,
 		pushHandler,
 	)
 
-	if err := images.Dispatch(ctx, images.Handlers(handlers...), desc); err != nil {
+	if err := images.DispatchLimit(ctx, images.Handlers(handlers...), pushCtx.MaxConcurrency, desc); err != nil {
 		return err
 	}
 
@@ -406,8 +525,33 @@ func (c *Client) ContentStore() content.Store {
 	return contentservice.NewStoreFromClient(contentapi.NewContentClient(c.conn))
 }
 
-func (c *Client) SnapshotService() snapshot.Snapshotter {
-	return snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(c.conn))
+// SnapshotServiceOpts allows the caller to set options on the snapshot
+// service
+type SnapshotServiceOpts func(*SnapshotServiceConfig)
+
+// SnapshotServiceConfig selects the snapshotter of the snapshot service.
+type SnapshotServiceConfig struct {
+	// Snapshotter is the name of the snapshotter loaded by the daemon, the
+	// default snapshotter of the daemon if empty.
+	Snapshotter string
+}
+
+// WithSnapshotterName selects the snapshotter loaded by the daemon as name,
+// the default snapshotter if name is empty.
+func WithSnapshotterName(name string) SnapshotServiceOpts {
+	return func(c *SnapshotServiceConfig) {
+		c.Snapshotter = name
+	}
+}
+
+// SnapshotService returns the default snapshotter of the daemon, or the
+// snapshotter selected by the options.
+func (c *Client) SnapshotService(opts ...SnapshotServiceOpts) snapshot.Snapshotter {
+	var config SnapshotServiceConfig
+	for _, o := range opts {
+		o(&config)
+	}
+	return snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(c.conn), config.Snapshotter)
 }
 
 func (c *Client) TaskService() execution.TasksClient {
@@ -3,5 +3,7 @@ package main
 import (
 	_ "github.com/containerd/containerd/linux"
 	_ "github.com/containerd/containerd/metrics/cgroups"
+	_ "github.com/containerd/containerd/snapshot/devmapper"
 	_ "github.com/containerd/containerd/snapshot/overlay"
+	_ "github.com/containerd/containerd/snapshot/tmpfs"
 )
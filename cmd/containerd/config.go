@@ -47,11 +47,20 @@ type config struct {
 	Debug debug `toml:"debug"`
 	// Metrics and monitoring settings
 	Metrics metricsConfig `toml:"metrics"`
-	// Snapshotter specifies which snapshot driver to use
+	// Snapshotter specifies which snapshot driver to use by default
 	Snapshotter string `toml:"snapshotter"`
+	// Snapshotters lists additional snapshot drivers to load alongside the
+	// default, which clients may select by name
+	Snapshotters []string `toml:"snapshotters"`
 	// Differ specifies which differ to use. Differ is tightly coupled with the snapshotter
 	// so not all combinations may work.
 	Differ string `toml:"differ"`
+	// Content specifies the proxy plugin serving the content store. The
+	// local content store is used if empty.
+	Content string `toml:"content"`
+	// ProxyPlugins declares snapshotters, content stores and differs served
+	// by external processes over the gRPC API, keyed by name
+	ProxyPlugins map[string]proxyPlugin `toml:"proxy_plugins"`
 	// Plugins provides plugin specific configuration for the initialization of a plugin
 	Plugins map[string]toml.Primitive `toml:"plugins"`
 	// Enable containerd as a subreaper
@@ -79,6 +88,14 @@ func (c *config) WriteTo(w io.Writer) (int64, error) {
 	return io.Copy(w, buf)
 }
 
+type proxyPlugin struct {
+	// Type is the type of the plugin, either "snapshot", "content" or "diff"
+	Type string `toml:"type"`
+	// Address is the path of the unix socket the plugin serves the
+	// corresponding gRPC API on
+	Address string `toml:"address"`
+}
+
 type grpcConfig struct {
 	Address string `toml:"address"`
 	Uid     int    `toml:"uid"`
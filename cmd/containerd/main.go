@@ -28,7 +28,11 @@ import (
 	versionapi "github.com/containerd/containerd/api/services/version"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/plugin"
+	contentservice "github.com/containerd/containerd/services/content"
+	diffservice "github.com/containerd/containerd/services/diff"
+	snapshotservice "github.com/containerd/containerd/services/snapshot"
 	"github.com/containerd/containerd/snapshot"
 	"github.com/containerd/containerd/sys"
 	"github.com/containerd/containerd/version"
@@ -85,9 +89,14 @@ func main() {
 			Name:  "root",
 			Usage: "containerd root directory",
 		},
+		cli.StringFlag{
+			Name:  "restore",
+			Usage: "restore the metadata database from a backup before starting",
+		},
 	}
 	app.Commands = []cli.Command{
 		configCommand,
+		migrateCommand,
 	}
 	app.Before = before
 	app.Action = func(context *cli.Context) error {
@@ -121,22 +130,32 @@ func main() {
 		if err != nil {
 			return err
 		}
+		if path := context.GlobalString("restore"); path != "" {
+			if err := restoreMetaDB(path); err != nil {
+				return err
+			}
+		}
 		meta, err := resolveMetaDB(context)
 		if err != nil {
 			return err
 		}
 		defer meta.Close()
-		snapshotter, err := loadSnapshotter(store)
+		snapshotters, err := loadSnapshotters(store)
 		if err != nil {
 			return err
 		}
+		if context.GlobalString("restore") != "" {
+			if err := verifyMetaDB(meta, store, snapshotters); err != nil {
+				return errors.Wrap(err, "failed to verify metadata database")
+			}
+		}
 
-		differ, err := loadDiffer(snapshotter, store)
+		differ, err := loadDiffer(snapshotters[conf.Snapshotter], store)
 		if err != nil {
 			return err
 		}
 
-		services, err := loadServices(runtimes, store, snapshotter, meta, differ)
+		services, err := loadServices(runtimes, store, snapshotters, meta, differ)
 		if err != nil {
 			return err
 		}
@@ -253,6 +272,16 @@ func serveDebugAPI() error {
 }
 
 func resolveContentStore() (content.Store, error) {
+	if conf.Content != "" {
+		conn, err := proxyConn(conf.Content, proxyContent)
+		if err != nil {
+			return nil, err
+		}
+		if conn == nil {
+			return nil, fmt.Errorf("content proxy plugin not configured: %v", conf.Content)
+		}
+		return contentservice.NewStoreFromClient(contentapi.NewContentClient(conn)), nil
+	}
 	cp := filepath.Join(conf.Root, "content")
 	return content.NewStore(cp)
 }
@@ -265,6 +294,15 @@ func resolveMetaDB(ctx *cli.Context) (*bolt.DB, error) {
 		return nil, err
 	}
 
+	result, err := metadata.Migrate(db, false)
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "failed to migrate %s", path)
+	}
+	for _, m := range result.Applied {
+		log.G(global).WithField("migration", m).Info("migrated metadata database")
+	}
+
 	return db, nil
 }
 
@@ -320,12 +358,38 @@ func loadMonitor() (plugin.TaskMonitor, error) {
 	return plugin.NewMultiTaskMonitor(monitors...), nil
 }
 
-func loadSnapshotter(store content.Store) (snapshot.Snapshotter, error) {
+// loadSnapshotters loads the default snapshotter and any additional
+// snapshotters of the configuration, keyed by name.
+func loadSnapshotters(store content.Store) (map[string]snapshot.Snapshotter, error) {
+	snapshotters := make(map[string]snapshot.Snapshotter)
+	for _, name := range append([]string{conf.Snapshotter}, conf.Snapshotters...) {
+		if _, ok := snapshotters[name]; ok {
+			continue
+		}
+		sn, err := loadSnapshotter(store, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshotters[name] = sn
+	}
+	return snapshotters, nil
+}
+
+func loadSnapshotter(store content.Store, snapshotter string) (snapshot.Snapshotter, error) {
+	conn, err := proxyConn(snapshotter, proxySnapshot)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		// the snapshots are checked by the plugin itself
+		return snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(conn), ""), nil
+	}
+
 	for name, sr := range plugin.Registrations() {
 		if sr.Type != plugin.SnapshotPlugin {
 			continue
 		}
-		moduleName := fmt.Sprintf("snapshot-%s", conf.Snapshotter)
+		moduleName := fmt.Sprintf("snapshot-%s", snapshotter)
 		if name != moduleName {
 			continue
 		}
@@ -347,13 +411,40 @@ func loadSnapshotter(store content.Store) (snapshot.Snapshotter, error) {
 		if err != nil {
 			return nil, err
 		}
+		if checker, ok := sn.(snapshot.Checker); ok {
+			checkSnapshots(ic.Context, checker)
+		}
 
 		return sn.(snapshot.Snapshotter), nil
 	}
-	return nil, fmt.Errorf("snapshotter not loaded: %v", conf.Snapshotter)
+	return nil, fmt.Errorf("snapshotter not loaded: %v", snapshotter)
+}
+
+// checkSnapshots repairs snapshot storage left behind by operations
+// interrupted by a crash and reports metadata without storage.
+func checkSnapshots(ctx gocontext.Context, checker snapshot.Checker) {
+	result, err := checker.Check(ctx, true)
+	if err != nil {
+		log.G(ctx).WithError(err).Warn("failed to check snapshots")
+		return
+	}
+	for _, path := range result.Repaired {
+		log.G(ctx).WithField("path", path).Info("repaired orphaned snapshot")
+	}
+	for _, ref := range result.Dangling {
+		log.G(ctx).WithField("snapshot", ref).Warn("snapshot storage is missing")
+	}
 }
 
 func loadDiffer(snapshotter snapshot.Snapshotter, store content.Store) (plugin.Differ, error) {
+	conn, err := proxyConn(conf.Differ, proxyDiff)
+	if err != nil {
+		return nil, err
+	}
+	if conn != nil {
+		return diffservice.NewDiffServiceFromClient(diffapi.NewDiffClient(conn)), nil
+	}
+
 	for name, sr := range plugin.Registrations() {
 		if sr.Type != plugin.DiffPlugin {
 			continue
@@ -396,7 +487,7 @@ func newGRPCServer() *grpc.Server {
 }
 
 func loadServices(runtimes map[string]plugin.Runtime,
-	store content.Store, sn snapshot.Snapshotter,
+	store content.Store, snapshotters map[string]snapshot.Snapshotter,
 	meta *bolt.DB, differ plugin.Differ) ([]plugin.Service, error) {
 	var o []plugin.Service
 	for name, sr := range plugin.Registrations() {
@@ -405,14 +496,16 @@ func loadServices(runtimes map[string]plugin.Runtime,
 		}
 		log.G(global).Infof("loading grpc service plugin %q...", name)
 		ic := &plugin.InitContext{
-			Root:        conf.Root,
-			State:       conf.State,
-			Context:     log.WithModule(global, fmt.Sprintf("service-%s", name)),
-			Runtimes:    runtimes,
-			Content:     store,
-			Meta:        meta,
-			Snapshotter: sn,
-			Differ:      differ,
+			Root:            conf.Root,
+			State:           conf.State,
+			Context:         log.WithModule(global, fmt.Sprintf("service-%s", name)),
+			Runtimes:        runtimes,
+			Content:         store,
+			Meta:            meta,
+			Snapshotter:     snapshotters[conf.Snapshotter],
+			Snapshotters:    snapshotters,
+			SnapshotterName: conf.Snapshotter,
+			Differ:          differ,
 		}
 		if sr.Config != nil {
 			if err := conf.decodePlugin(name, sr.Config); err != nil {
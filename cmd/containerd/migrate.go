@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/metadata"
+	"github.com/containerd/containerd/metadata/schema"
+	"github.com/containerd/containerd/snapshot/storage"
+	"github.com/urfave/cli"
+)
+
+var migrateCommand = cli.Command{
+	Name:  "migrate",
+	Usage: "migrate the metadata databases to the current schema",
+	Description: `Migrate the metadata database and the snapshot metadata databases under
+   the root. Databases are also migrated when containerd starts, containerd
+   must not be running while migrating.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "run the migrations without changing the databases",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		dryRun := context.Bool("dry-run")
+
+		path := filepath.Join(conf.Root, "meta.db")
+		if _, err := os.Stat(path); err == nil {
+			db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+			if err != nil {
+				return fmt.Errorf("failed to open %s, is containerd running? %v", path, err)
+			}
+			result, err := metadata.Migrate(db, dryRun)
+			db.Close()
+			if err != nil {
+				return fmt.Errorf("failed to migrate %s: %v", path, err)
+			}
+			printMigration(path, result, dryRun)
+		}
+
+		paths, err := filepath.Glob(filepath.Join(conf.Root, "snapshot", "*", "metadata.db"))
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			result, err := storage.Migrate(path, dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to migrate %s: %v", path, err)
+			}
+			printMigration(path, result, dryRun)
+		}
+		return nil
+	},
+}
+
+func printMigration(path string, result schema.Result, dryRun bool) {
+	if result.From == result.To {
+		fmt.Printf("%s: up to date at version %d\n", path, result.To)
+		return
+	}
+	verb := "migrated"
+	if dryRun {
+		verb = "would migrate"
+	}
+	fmt.Printf("%s: %s from version %d to %d\n", path, verb, result.From, result.To)
+	for _, m := range result.Applied {
+		fmt.Printf("  %s\n", m)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/namespaces"
+	gocontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Types of proxy plugins, as set in the configuration.
+const (
+	proxySnapshot = "snapshot"
+	proxyContent  = "content"
+	proxyDiff     = "diff"
+)
+
+// proxyHealthInterval is the interval at which proxy plugins are checked.
+const proxyHealthInterval = 10 * time.Second
+
+// proxyConn returns a connection to the proxy plugin name if it is declared
+// in the configuration, or nil otherwise. The plugin must be of type typ.
+//
+// The connection is established in the background, containerd starts even
+// if the plugin is not serving yet. The health of the plugin is checked for
+// as long as containerd runs, requests fail with Unavailable until the first
+// check succeeds and whenever the plugin stops serving.
+func proxyConn(name, typ string) (*grpc.ClientConn, error) {
+	p, ok := conf.ProxyPlugins[name]
+	if !ok {
+		return nil, nil
+	}
+	if p.Type != typ {
+		return nil, fmt.Errorf("proxy plugin %q is of type %q, not %q", name, p.Type, typ)
+	}
+	if p.Address == "" {
+		return nil, fmt.Errorf("proxy plugin %q has no address", name)
+	}
+
+	log.G(global).Infof("loading %s proxy plugin %q...", typ, name)
+	health := &proxyHealth{name: name}
+	conn, err := grpc.Dial(fmt.Sprintf("unix://%s", p.Address),
+		grpc.WithInsecure(),
+		grpc.WithDialer(proxyDialer),
+		grpc.WithBackoffMaxDelay(proxyHealthInterval),
+		grpc.WithUnaryInterceptor(health.unaryInterceptor),
+		grpc.WithStreamInterceptor(health.streamInterceptor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial proxy plugin %q: %v", name, err)
+	}
+
+	go health.check(log.WithModule(global, fmt.Sprintf("proxy-%s", name)), conn)
+
+	return conn, nil
+}
+
+func proxyDialer(address string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("unix", strings.TrimPrefix(address, "unix://"), timeout)
+}
+
+// proxyNamespace forwards the namespace of the request served by containerd
+// to the proxy plugin.
+func proxyNamespace(ctx gocontext.Context) gocontext.Context {
+	if namespace, ok := namespaces.Namespace(ctx); ok {
+		return namespaces.WithNamespace(ctx, namespace)
+	}
+	return ctx
+}
+
+// proxyHealthCheck is the method of the gRPC health service, which is
+// called regardless of the health of the plugin.
+const proxyHealthCheck = "/grpc.health.v1.Health/Check"
+
+// proxyHealth holds the health of a proxy plugin, as last reported by its
+// health service.
+type proxyHealth struct {
+	name string
+
+	mu      sync.RWMutex
+	serving bool
+}
+
+func (h *proxyHealth) isServing() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.serving
+}
+
+// setServing records the health of the plugin and reports whether it
+// changed.
+func (h *proxyHealth) setServing(serving bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	changed := h.serving != serving
+	h.serving = serving
+	return changed
+}
+
+// available returns an Unavailable error if the plugin is not serving.
+func (h *proxyHealth) available(method string) error {
+	if method == proxyHealthCheck || h.isServing() {
+		return nil
+	}
+	return grpc.Errorf(codes.Unavailable, "proxy plugin %q not serving", h.name)
+}
+
+func (h *proxyHealth) unaryInterceptor(ctx gocontext.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := h.available(method); err != nil {
+		return err
+	}
+	return invoker(proxyNamespace(ctx), method, req, reply, cc, opts...)
+}
+
+func (h *proxyHealth) streamInterceptor(ctx gocontext.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if err := h.available(method); err != nil {
+		return nil, err
+	}
+	return streamer(proxyNamespace(ctx), desc, cc, method, opts...)
+}
+
+// check queries the gRPC health service of the proxy plugin at every
+// proxyHealthInterval, records the result and logs when the plugin stops or
+// starts serving. Plugins without a health service are considered serving as
+// long as they can be reached.
+func (h *proxyHealth) check(ctx gocontext.Context, conn *grpc.ClientConn) {
+	var (
+		client = grpc_health_v1.NewHealthClient(conn)
+		ticker = time.NewTicker(proxyHealthInterval)
+		first  = true
+	)
+	defer ticker.Stop()
+	for {
+		cctx, cancel := gocontext.WithTimeout(ctx, proxyHealthInterval)
+		resp, err := client.Check(cctx, &grpc_health_v1.HealthCheckRequest{})
+		cancel()
+
+		var ok bool
+		switch {
+		case err == nil:
+			ok = resp.Status == grpc_health_v1.HealthCheckResponse_SERVING
+		case grpc.Code(err) == codes.Unimplemented:
+			ok = true
+		}
+		if h.setServing(ok) || first {
+			if ok {
+				log.G(ctx).Info("proxy plugin serving")
+			} else {
+				log.G(ctx).WithError(err).Warn("proxy plugin not serving")
+			}
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
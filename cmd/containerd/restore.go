@@ -0,0 +1,178 @@
+package main
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/metadata"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshot"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// restoreMetaDB replaces the metadata database in the root with the backup
+// at path, as written by the admin service. The backup is checked before
+// the database is replaced and the previous database is kept next to it,
+// suffixed with the time of the restore.
+func restoreMetaDB(path string) error {
+	dbPath := filepath.Join(conf.Root, "meta.db")
+	if err := os.MkdirAll(conf.Root, 0711); err != nil {
+		return err
+	}
+
+	// copy into the root first, so that the final rename is atomic
+	tmp, err := ioutil.TempFile(conf.Root, "meta.db.restore-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := copyFile(tmp, path); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to copy %s", path)
+	}
+	// the copy must be on disk before it replaces the database
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := checkBackup(tmp.Name()); err != nil {
+		return errors.Wrapf(err, "invalid backup %s", path)
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		old := fmt.Sprintf("%s.%s", dbPath, time.Now().UTC().Format("20060102T150405Z"))
+		if err := os.Rename(dbPath, old); err != nil {
+			return err
+		}
+		log.G(global).WithField("path", old).Info("moved previous metadata database")
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dbPath); err != nil {
+		return err
+	}
+	log.G(global).WithField("backup", path).Info("restored metadata database")
+	return nil
+}
+
+func copyFile(dst io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(dst, f)
+	return err
+}
+
+// checkBackup opens the database at path and checks that it can be migrated
+// to the current schema, without changing it.
+func checkBackup(path string) error {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if _, err := metadata.Migrate(db, true); err != nil {
+		return err
+	}
+	return db.View(func(tx *bolt.Tx) error {
+		_, err := metadata.NewNamespaceStore(tx).List(global)
+		return err
+	})
+}
+
+// verifyMetaDB logs the content and snapshots referenced by the images and
+// containers of db which are missing from store and snapshotters, such as
+// after restoring a backup older or newer than the rest of the root.
+func verifyMetaDB(db *bolt.DB, store content.Store, snapshotters map[string]snapshot.Snapshotter) error {
+	var (
+		imgs = map[string][]images.Image{}
+		ctrs = map[string][]containers.Container{}
+	)
+	if err := db.View(func(tx *bolt.Tx) error {
+		nss, err := metadata.NewNamespaceStore(tx).List(global)
+		if err != nil {
+			return err
+		}
+		for _, ns := range nss {
+			ctx := namespaces.WithNamespace(global, ns)
+			if imgs[ns], err = metadata.NewImageStore(tx).List(ctx); err != nil {
+				return err
+			}
+			if ctrs[ns], err = metadata.NewContainerStore(tx).List(ctx, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var missing int
+	for ns, imgs := range imgs {
+		ctx := namespaces.WithNamespace(global, ns)
+		for _, img := range imgs {
+			logger := log.G(ctx).WithField("namespace", ns).WithField("image", img.Name)
+			if err := images.Walk(ctx, images.Handlers(
+				images.HandlerFunc(func(ctx gocontext.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+					if _, err := store.Info(ctx, desc.Digest); err != nil {
+						if !content.IsNotFound(err) {
+							return nil, err
+						}
+						logger.WithField("digest", desc.Digest).Warn("missing content")
+						missing++
+						return nil, images.StopHandler
+					}
+					return nil, nil
+				}),
+				images.ChildrenHandler(store),
+			), img.Target); err != nil {
+				logger.WithError(err).Warn("failed to verify image")
+			}
+		}
+	}
+	for ns, ctrs := range ctrs {
+		ctx := namespaces.WithNamespace(global, ns)
+		for _, c := range ctrs {
+			if c.RootFS == "" {
+				continue
+			}
+			logger := log.G(ctx).WithField("namespace", ns).WithField("container", c.ID).WithField("snapshot", c.RootFS)
+			name := c.Snapshotter
+			if name == "" {
+				name = conf.Snapshotter
+			}
+			sn, ok := snapshotters[name]
+			if !ok {
+				logger.WithField("snapshotter", name).Warn("snapshotter not loaded")
+				missing++
+				continue
+			}
+			if _, err := sn.Stat(ctx, c.RootFS); err != nil {
+				logger.WithError(err).Warn("missing snapshot")
+				missing++
+			}
+		}
+	}
+	if missing > 0 {
+		log.G(global).Warnf("%d resources referenced by the metadata database are missing", missing)
+	} else {
+		log.G(global).Info("verified metadata database")
+	}
+	return nil
+}
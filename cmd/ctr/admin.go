@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	adminapi "github.com/containerd/containerd/api/services/admin"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var adminCommand = cli.Command{
+	Name:  "admin",
+	Usage: "daemon maintenance",
+	Subcommands: cli.Commands{
+		adminBackupCommand,
+	},
+}
+
+var adminBackupCommand = cli.Command{
+	Name:      "backup",
+	Usage:     "backup the metadata database",
+	ArgsUsage: "[flags] <file>",
+	Description: `Write a consistent copy of the metadata database of the running daemon
+to file, or to stdout if file is "-".
+
+The backup can be restored by starting containerd with --restore <file>.`,
+	Action: func(clicontext *cli.Context) error {
+		path := clicontext.Args().First()
+		if path == "" {
+			return errors.New("please specify a file")
+		}
+
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		admin, err := getAdminService(clicontext)
+		if err != nil {
+			return err
+		}
+		stream, err := admin.Backup(ctx, &adminapi.BackupRequest{})
+		if err != nil {
+			return err
+		}
+
+		if path == "-" {
+			return copyBackup(os.Stdout, stream)
+		}
+
+		// write next to the destination and rename once complete, to never
+		// leave a partial backup behind
+		f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp-")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		if err := copyBackup(f, stream); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		return os.Rename(f.Name(), path)
+	},
+}
+
+func copyBackup(w io.Writer, stream adminapi.Admin_BackupClient) error {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if _, err := w.Write(resp.Data); err != nil {
+			return errors.Wrap(err, "failed to write backup")
+		}
+	}
+}
@@ -50,8 +50,14 @@ containerd CLI
 			Value:  "default",
 			EnvVar: "CONTAINERD_NAMESPACE",
 		},
+		cli.StringFlag{
+			Name:   "snapshotter",
+			Usage:  "snapshotter to use with commands, the daemon's default if empty",
+			EnvVar: "CONTAINERD_SNAPSHOTTER",
+		},
 	}
 	app.Commands = append([]cli.Command{
+		adminCommand,
 		attachCommand,
 		checkpointCommand,
 		runCommand,
@@ -54,12 +54,13 @@ var namespacesCreateCommand = cli.Command{
 }
 
 func namespaceWithLabelArgs(clicontext *cli.Context) (string, map[string]string) {
-	var (
-		namespace    = clicontext.Args().First()
-		labelStrings = clicontext.Args().Tail()
-		labels       = make(map[string]string, len(labelStrings))
-	)
+	return clicontext.Args().First(), labelArgs(clicontext.Args().Tail())
+}
 
+// labelArgs parses labels given as "key=value", or "key" for the value
+// "true".
+func labelArgs(labelStrings []string) map[string]string {
+	labels := make(map[string]string, len(labelStrings))
 	for _, label := range labelStrings {
 		parts := strings.SplitN(label, "=", 2)
 		key := parts[0]
@@ -71,7 +72,7 @@ func namespaceWithLabelArgs(clicontext *cli.Context) (string, map[string]string)
 		labels[key] = value
 	}
 
-	return namespace, labels
+	return labels
 }
 
 var namespacesSetLabelsCommand = cli.Command{
@@ -67,7 +67,7 @@ func newContainer(ctx gocontext.Context, client *containerd.Client, context *cli
 			return nil, err
 		}
 	}
-	image, err := client.GetImage(ctx, ref)
+	image, err := getImage(ctx, client, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -99,12 +99,16 @@ func newContainer(ctx gocontext.Context, client *containerd.Client, context *cli
 		return client.NewContainer(ctx, id,
 			containerd.WithSpec(spec),
 			containerd.WithImage(image),
+			containerd.WithSnapshotter(context.GlobalString("snapshotter")),
 			rootfs,
 		)
 	}
-	return client.NewContainer(ctx, id, containerd.WithCheckpoint(v1.Descriptor{
-		Digest: checkpointIndex,
-	}, id))
+	return client.NewContainer(ctx, id,
+		containerd.WithSnapshotter(context.GlobalString("snapshotter")),
+		containerd.WithCheckpoint(v1.Descriptor{
+			Digest: checkpointIndex,
+		}, id),
+	)
 }
 
 func newTask(ctx gocontext.Context, container containerd.Container, checkpoint digest.Digest, tty bool) (containerd.Task, error) {
@@ -221,7 +221,7 @@ func newContainer(ctx gocontext.Context, client *containerd.Client, context *cli
 		args = context.Args()[2:]
 		tty  = context.Bool("tty")
 	)
-	image, err := client.GetImage(ctx, ref)
+	image, err := getImage(ctx, client, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -252,6 +252,7 @@ func newContainer(ctx gocontext.Context, client *containerd.Client, context *cli
 	return client.NewContainer(ctx, id,
 		containerd.WithSpec(spec),
 		containerd.WithImage(image),
+		containerd.WithSnapshotter(context.GlobalString("snapshotter")),
 		rootfs,
 	)
 }
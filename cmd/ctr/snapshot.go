@@ -3,8 +3,15 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
+	"text/tabwriter"
 
+	snapshotapi "github.com/containerd/containerd/api/services/snapshot"
+	"github.com/containerd/containerd/fs"
+	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/rootfs"
+	snapshotservice "github.com/containerd/containerd/services/snapshot"
+	"github.com/containerd/containerd/snapshot"
 	"github.com/urfave/cli"
 )
 
@@ -12,6 +19,12 @@ var snapshotCommand = cli.Command{
 	Name:      "snapshot",
 	Usage:     "snapshot a container into an archive",
 	ArgsUsage: "",
+	Subcommands: []cli.Command{
+		snapshotCheckCommand,
+		snapshotDiffCommand,
+		snapshotExportCommand,
+		snapshotImportCommand,
+	},
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "id",
@@ -50,3 +63,202 @@ var snapshotCommand = cli.Command{
 		return nil
 	},
 }
+
+var snapshotCheckCommand = cli.Command{
+	Name:        "check",
+	Usage:       "check snapshot metadata against the stored snapshots",
+	Description: "List the snapshots of the namespace whose storage is missing. Orphaned storage is repaired by containerd when the snapshotter is loaded.",
+	Action: func(clicontext *cli.Context) error {
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		snapshotter, err := getSnapshotter(clicontext)
+		if err != nil {
+			return err
+		}
+		checker, ok := snapshotter.(snapshot.Checker)
+		if !ok {
+			return errors.New("snapshotter does not support checks")
+		}
+
+		result, err := checker.Check(ctx, false)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		fmt.Fprintln(w, "STATUS\tSNAPSHOT")
+		for _, ref := range result.Dangling {
+			fmt.Fprintf(w, "dangling\t%s\n", ref)
+		}
+		return w.Flush()
+	},
+}
+
+var snapshotDiffCommand = cli.Command{
+	Name:        "diff",
+	Usage:       "list the filesystem changes of a snapshot",
+	ArgsUsage:   "<name> [parent]",
+	Description: "List the files added, modified or deleted in the committed snapshot relative to parent, or to its own parent.",
+	Action: func(clicontext *cli.Context) error {
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		var (
+			name   = clicontext.Args().First()
+			parent = clicontext.Args().Get(1)
+		)
+		if name == "" {
+			return errors.New("snapshot name must be provided")
+		}
+
+		conn, err := getGRPCConnection(clicontext)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+		fmt.Fprintln(w, "KIND\tMODE\tSIZE\tPATH")
+		if err := snapshotservice.Changes(ctx, snapshotapi.NewSnapshotClient(conn), clicontext.GlobalString("snapshotter"), name, parent, func(c rootfs.Change) error {
+			mode, size := "-", "-"
+			if c.Kind != fs.ChangeKindDelete {
+				mode, size = c.Mode.String(), fmt.Sprint(c.Size)
+			}
+			_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Kind, mode, size, c.Path)
+			return err
+		}); err != nil {
+			return err
+		}
+		return w.Flush()
+	},
+}
+
+var snapshotExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export a snapshot as a tar archive",
+	ArgsUsage: "[flags] <name>",
+	Description: `Export writes the committed snapshot name as an archive holding its labels,
+its parent chain and a layer tar of its changes to the parent, or of its
+complete filesystem with --full.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "full",
+			Usage: "export the complete filesystem rather than the changes to the parent",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "file to write the archive to, defaults to stdout",
+		},
+	},
+	Action: func(clicontext *cli.Context) error {
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		name := clicontext.Args().First()
+		if name == "" {
+			return errors.New("snapshot name must be provided")
+		}
+
+		snapshotter, err := getSnapshotter(clicontext)
+		if err != nil {
+			return err
+		}
+
+		w := os.Stdout
+		if output := clicontext.String("output"); output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		return rootfs.Export(ctx, snapshotter, name, clicontext.Bool("full"), w)
+	},
+}
+
+var snapshotImportCommand = cli.Command{
+	Name:      "import",
+	Usage:     "import a snapshot from a tar archive",
+	ArgsUsage: "[flags] <key> [<file>]",
+	Description: `Import creates the active snapshot key from an archive written by export,
+read from stdin if no file is given. The archive is applied on top of the
+parent snapshot, by default the parent it was exported with, and its labels
+are set on the snapshot. With --commit, the snapshot is committed as key
+instead.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "parent",
+			Usage: "parent snapshot to import the archive on top of, instead of the exported parent",
+		},
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "label to set on the snapshot, as key=value",
+		},
+		cli.BoolFlag{
+			Name:  "commit",
+			Usage: "commit the imported snapshot",
+		},
+	},
+	Action: func(clicontext *cli.Context) error {
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		key := clicontext.Args().First()
+		if key == "" {
+			return errors.New("snapshot key must be provided")
+		}
+
+		snapshotter, err := getSnapshotter(clicontext)
+		if err != nil {
+			return err
+		}
+		cs, err := getContentStore(clicontext)
+		if err != nil {
+			return err
+		}
+		differ, err := getDiffService(clicontext)
+		if err != nil {
+			return err
+		}
+
+		r := os.Stdin
+		if input := clicontext.Args().Get(1); input != "" {
+			f, err := os.Open(input)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		var (
+			labels = snapshot.WithLabels(labelArgs(clicontext.StringSlice("label")))
+			active = key
+		)
+		if clicontext.Bool("commit") {
+			active = fmt.Sprintf("import %s", key)
+		}
+
+		desc, err := rootfs.Import(ctx, snapshotter, cs, differ, active, clicontext.String("parent"), r, labels)
+		if err != nil {
+			return err
+		}
+		if clicontext.Bool("commit") {
+			info, err := snapshotter.Stat(ctx, active)
+			if err == nil {
+				err = snapshotter.Commit(ctx, key, active, snapshot.WithLabels(info.Labels))
+			}
+			if err != nil {
+				if rerr := snapshotter.Remove(ctx, active); rerr != nil {
+					log.G(ctx).WithError(rerr).WithField("key", active).Warn("failed to remove imported snapshot")
+				}
+				return err
+			}
+		}
+
+		fmt.Printf("%s %s\n", desc.MediaType, desc.Digest)
+		return nil
+	},
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/containerd/containerd"
+	adminapi "github.com/containerd/containerd/api/services/admin"
 	containersapi "github.com/containerd/containerd/api/services/containers"
 	contentapi "github.com/containerd/containerd/api/services/content"
 	diffapi "github.com/containerd/containerd/api/services/diff"
@@ -25,7 +26,9 @@ import (
 	"github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/reference"
 	contentservice "github.com/containerd/containerd/services/content"
 	"github.com/containerd/containerd/services/diff"
 	imagesservice "github.com/containerd/containerd/services/images"
@@ -104,7 +107,7 @@ func getSnapshotter(context *cli.Context) (snapshot.Snapshotter, error) {
 	if err != nil {
 		return nil, err
 	}
-	return snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(conn)), nil
+	return snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(conn), context.GlobalString("snapshotter")), nil
 }
 
 func getImageStore(clicontext *cli.Context) (images.Store, error) {
@@ -115,6 +118,21 @@ func getImageStore(clicontext *cli.Context) (images.Store, error) {
 	return imagesservice.NewStoreFromClient(imagesapi.NewImagesClient(conn)), nil
 }
 
+// getImage returns the image ref, accepting familiar references such as
+// "ubuntu". Images registered under names which are not references are
+// returned by their name.
+func getImage(ctx gocontext.Context, client *containerd.Client, ref string) (containerd.Image, error) {
+	normalized, err := reference.Normalize(ref)
+	if err != nil || normalized == ref {
+		return client.GetImage(ctx, ref)
+	}
+	image, err := client.GetImage(ctx, normalized)
+	if metadata.IsNotFound(err) {
+		return client.GetImage(ctx, ref)
+	}
+	return image, err
+}
+
 func getDiffService(context *cli.Context) (diff.DiffService, error) {
 	conn, err := getGRPCConnection(context)
 	if err != nil {
@@ -131,6 +149,14 @@ func getVersionService(context *cli.Context) (versionservice.VersionClient, erro
 	return versionservice.NewVersionClient(conn), nil
 }
 
+func getAdminService(context *cli.Context) (adminapi.AdminClient, error) {
+	conn, err := getGRPCConnection(context)
+	if err != nil {
+		return nil, err
+	}
+	return adminapi.NewAdminClient(conn), nil
+}
+
 func getTempDir(id string) (string, error) {
 	err := os.MkdirAll(filepath.Join(os.TempDir(), "ctr"), 0700)
 	if err != nil {
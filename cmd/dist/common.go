@@ -18,12 +18,17 @@ import (
 	imagesapi "github.com/containerd/containerd/api/services/images"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/reference"
 	"github.com/containerd/containerd/remotes"
 	"github.com/containerd/containerd/remotes/docker"
+	"github.com/containerd/containerd/remotes/oci"
 	"github.com/containerd/containerd/rootfs"
 	contentservice "github.com/containerd/containerd/services/content"
 	imagesservice "github.com/containerd/containerd/services/images"
+	units "github.com/docker/go-units"
+	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
@@ -49,6 +54,41 @@ var registryFlags = []cli.Flag{
 	},
 }
 
+var transferFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "max-concurrency",
+		Usage: "Maximum number of resources to transfer concurrently",
+	},
+	cli.StringFlag{
+		Name:  "limit-bandwidth",
+		Usage: "Maximum combined transfer rate, in bytes per second (eg. 10MB)",
+	},
+	cli.StringSliceFlag{
+		Name:  "foreign-host",
+		Usage: "Host to fetch foreign layers from (eg. *.microsoft.com)",
+	},
+}
+
+// getTransferOpts returns the remote options configured by the transfer
+// flags.
+func getTransferOpts(clicontext *cli.Context) ([]containerd.RemoteOpts, error) {
+	var opts []containerd.RemoteOpts
+	if n := clicontext.Int("max-concurrency"); n > 0 {
+		opts = append(opts, containerd.WithMaxConcurrency(n))
+	}
+	if limit := clicontext.String("limit-bandwidth"); limit != "" {
+		rate, err := units.FromHumanSize(limit)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid bandwidth limit")
+		}
+		if rate <= 0 {
+			return nil, errors.Errorf("invalid bandwidth limit: %v", limit)
+		}
+		opts = append(opts, containerd.WithBandwidthLimiter(remotes.NewBandwidthLimiter(rate)))
+	}
+	return opts, nil
+}
+
 func getClient(context *cli.Context) (*containerd.Client, error) {
 	address := context.GlobalString("address")
 	//timeout := context.GlobalDuration("connect-timeout")
@@ -109,8 +149,57 @@ func connectGRPC(context *cli.Context) (*grpc.ClientConn, error) {
 	)
 }
 
+// normalizeRef qualifies a familiar reference, such as "ubuntu" or
+// "myorg/app", with the default registry, repository namespace and tag.
+// References to OCI image layout directories are returned unchanged.
+func normalizeRef(ref string) (string, error) {
+	if oci.IsReference(ref) {
+		return ref, nil
+	}
+	normalized, err := reference.Normalize(ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid reference %q", ref)
+	}
+	return normalized, nil
+}
+
+// getImage returns the image name from the store, accepting familiar
+// references. Images registered under names which are not references are
+// returned by their name.
+func getImage(ctx context.Context, is images.Store, name string) (images.Image, error) {
+	normalized, err := normalizeRef(name)
+	if err != nil || normalized == name {
+		return is.Get(ctx, name)
+	}
+	img, err := is.Get(ctx, normalized)
+	if metadata.IsNotFound(err) {
+		return is.Get(ctx, name)
+	}
+	return img, err
+}
+
 // getResolver prepares the resolver from the environment and options.
 func getResolver(ctx context.Context, clicontext *cli.Context) (remotes.Resolver, error) {
+	options, err := getResolverOptions(ctx, clicontext)
+	if err != nil {
+		return nil, err
+	}
+	return docker.NewResolver(options), nil
+}
+
+// getResolverFor prepares the resolver for ref. References to OCI image layout
+// directories, such as "oci:/path/to/layout:tag", are resolved against the
+// directory, all others against a registry.
+func getResolverFor(ctx context.Context, clicontext *cli.Context, ref string) (remotes.Resolver, error) {
+	if oci.IsReference(ref) {
+		return oci.NewResolver(), nil
+	}
+	return getResolver(ctx, clicontext)
+}
+
+// getResolverOptions prepares the docker resolver options from the
+// environment and options.
+func getResolverOptions(ctx context.Context, clicontext *cli.Context) (docker.ResolverOptions, error) {
 	username := clicontext.String("user")
 	var secret string
 	if i := strings.IndexByte(username, ':'); i > 0 {
@@ -118,7 +207,23 @@ func getResolver(ctx context.Context, clicontext *cli.Context) (remotes.Resolver
 		username = username[0:i]
 	}
 	options := docker.ResolverOptions{
-		PlainHTTP: clicontext.Bool("plain-http"),
+		PlainHTTP:         clicontext.Bool("plain-http"),
+		ForeignHosts:      clicontext.StringSlice("foreign-host"),
+		PushForeignLayers: clicontext.Bool("push-foreign-layers"),
+	}
+
+	// the sources of blobs are recorded in the content store, so that a push
+	// can mount the blobs fetched or pushed by earlier invocations.
+	cs, err := resolveContentStore(clicontext)
+	if err != nil {
+		return docker.ResolverOptions{}, err
+	}
+	options.Sources = docker.NewContentSourceTracker(cs)
+	if repos := clicontext.StringSlice("mount-from"); len(repos) > 0 {
+		options.Sources = mountFromSources{
+			SourceTracker: options.Sources,
+			repos:         repos,
+		}
 	}
 	if username != "" {
 		if secret == "" {
@@ -127,7 +232,7 @@ func getResolver(ctx context.Context, clicontext *cli.Context) (remotes.Resolver
 			var err error
 			secret, err = passwordPrompt()
 			if err != nil {
-				return nil, err
+				return docker.ResolverOptions{}, err
 			}
 
 			fmt.Print("\n")
@@ -161,7 +266,22 @@ func getResolver(ctx context.Context, clicontext *cli.Context) (remotes.Resolver
 		Transport: tr,
 	}
 
-	return docker.NewResolver(options), nil
+	return options, nil
+}
+
+// mountFromSources offers the same repositories as mount sources for every
+// blob pushed, after those recorded for the blob.
+type mountFromSources struct {
+	docker.SourceTracker
+	repos []string
+}
+
+func (m mountFromSources) Sources(ctx context.Context, dgst digest.Digest, host string) ([]string, error) {
+	repos, err := m.SourceTracker.Sources(ctx, dgst, host)
+	if err != nil {
+		return nil, err
+	}
+	return append(repos, m.repos...), nil
 }
 
 func passwordPrompt() (string, error) {
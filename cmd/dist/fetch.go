@@ -15,6 +15,7 @@ import (
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/progress"
 	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/signature"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/urfave/cli"
@@ -31,7 +32,8 @@ an image's rootfs and convert the configuration to a runtime format supported
 by containerd.
 
 This command uses the same syntax, of remote and object, as 'dist
-fetch-object'. We may want to make this nicer, but agnostism is preferred for
+fetch-object'. The remote may also be an OCI image layout directory, in the
+form "oci:<path>:<tag>". We may want to make this nicer, but agnostism is preferred for
 the moment.
 
 Right now, the responsibility of the daemon and the cli aren't quite clear. Do
@@ -39,7 +41,7 @@ not use this implementation as a guide. The end goal should be having metadata,
 content and snapshots ready for a direct use via the 'ctr run'.
 
 Most of this is experimental and there are few leaps to make this work.`,
-	Flags: registryFlags,
+	Flags: append(registryFlags, transferFlags...),
 	Action: func(clicontext *cli.Context) error {
 		var (
 			ref = clicontext.Args().First()
@@ -53,16 +55,34 @@ Most of this is experimental and there are few leaps to make this work.`,
 }
 
 func fetch(ctx context.Context, ref string, clicontext *cli.Context) (containerd.Image, error) {
+	ref, err := normalizeRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := getClient(clicontext)
 	if err != nil {
 		return nil, err
 	}
 
-	resolver, err := getResolver(ctx, clicontext)
+	resolver, err := getResolverFor(ctx, clicontext, ref)
 	if err != nil {
 		return nil, err
 	}
 
+	opts, err := getTransferOpts(clicontext)
+	if err != nil {
+		return nil, err
+	}
+
+	if p := clicontext.String("signature-policy"); p != "" {
+		policy, err := signature.LoadPolicy(p, resolver)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, containerd.WithSignaturePolicy(policy))
+	}
+
 	ongoing := newJobs(ref)
 
 	pctx, stopProgress := context.WithCancel(ctx)
@@ -80,7 +100,8 @@ func fetch(ctx context.Context, ref string, clicontext *cli.Context) (containerd
 
 	log.G(pctx).WithField("image", ref).Debug("fetching")
 
-	img, err := client.Pull(pctx, ref, containerd.WithResolver(resolver), containerd.WithImageHandler(h))
+	opts = append(opts, containerd.WithResolver(resolver), containerd.WithImageHandler(h))
+	img, err := client.Pull(pctx, ref, opts...)
 	stopProgress()
 	if err != nil {
 		return nil, err
@@ -25,7 +25,12 @@ var fetchObjectCommand = cli.Command{
 		ctx, cancel := appContext(context)
 		defer cancel()
 
-		resolver, err := getResolver(ctx, context)
+		ref, err := normalizeRef(ref)
+		if err != nil {
+			return err
+		}
+
+		resolver, err := getResolverFor(ctx, context, ref)
 		if err != nil {
 			return err
 		}
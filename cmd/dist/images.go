@@ -8,6 +8,7 @@ import (
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/progress"
+	"github.com/containerd/containerd/reference"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
@@ -55,7 +56,7 @@ var imagesListCommand = cli.Command{
 				log.G(ctx).WithError(err).Errorf("failed calculating size for image %s", image.Name)
 			}
 
-			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t\n", image.Name, image.Target.MediaType, image.Target.Digest, progress.Bytes(size))
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t\n", reference.FamiliarString(image.Name), image.Target.MediaType, image.Target.Digest, progress.Bytes(size))
 		}
 		tw.Flush()
 
@@ -83,7 +84,11 @@ var imageRemoveCommand = cli.Command{
 		}
 
 		for _, target := range clicontext.Args() {
-			if err := imageStore.Delete(ctx, target); err != nil {
+			name := target
+			if img, err := getImage(ctx, imageStore, target); err == nil {
+				name = img.Name
+			}
+			if err := imageStore.Delete(ctx, name); err != nil {
 				if !metadata.IsNotFound(err) {
 					if exitErr == nil {
 						exitErr = errors.Wrapf(err, "unable to delete %v", target)
@@ -65,6 +65,11 @@ distribution tool
 			Value:  "default",
 			EnvVar: "CONTAINERD_NAMESPACE",
 		},
+		cli.StringFlag{
+			Name:   "snapshotter",
+			Usage:  "snapshotter to use with commands, the daemon's default if empty",
+			EnvVar: "CONTAINERD_SNAPSHOTTER",
+		},
 	}
 	app.Commands = []cli.Command{
 		imageCommand,
@@ -76,6 +81,8 @@ distribution tool
 		rootfsCommand,
 		pushCommand,
 		pushObjectCommand,
+		remoteCommand,
+		serveCommand,
 	}
 	app.Before = func(context *cli.Context) error {
 		if context.GlobalBool("debug") {
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/log"
 	"github.com/urfave/cli"
 )
@@ -19,8 +20,34 @@ command. As part of this process, we do the following:
 1. Fetch all resources into containerd.
 2. Prepare the snapshot filesystem with the pulled resources.
 3. Register metadata for the image.
+
+Familiar references are qualified as Docker does, so "ubuntu" pulls
+"docker.io/library/ubuntu:latest".
+
+If a signature policy is given, the signatures of the resolved manifest are
+verified before any content is fetched and the image is only registered if
+it was signed by a trusted key. The policy is a JSON file selecting the
+keyring and signature store by registry or repository namespace:
+
+	{
+		"default": {"keyring": ["/etc/containerd/keys"], "signatures": "registry"},
+		"scopes": {
+			"docker.io/library": {},
+			"registry.example.com": {
+				"keyring": ["/etc/containerd/keys/release.pem"],
+				"signatures": "/var/lib/containerd/signatures"
+			}
+		}
+	}
+
+Signatures are read from "<dir>/<algorithm>/<hex>" for a directory, or from
+the layers of the "<algorithm>-<hex>.sig" tag of the image repository for
+"registry". Scopes with no keyring accept images without verification.
 `,
-	Flags: registryFlags,
+	Flags: append(append(registryFlags, transferFlags...), cli.StringFlag{
+		Name:  "signature-policy",
+		Usage: "Verify image signatures against the policy file",
+	}),
 	Action: func(clicontext *cli.Context) error {
 		var (
 			ref = clicontext.Args().First()
@@ -38,7 +65,7 @@ command. As part of this process, we do the following:
 
 		// TODO: Show unpack status
 		fmt.Printf("unpacking %s...", img.Target().Digest)
-		err = img.Unpack(ctx)
+		err = img.Unpack(ctx, containerd.WithUnpackSnapshotter(clicontext.GlobalString("snapshotter")))
 		fmt.Println("done")
 		return err
 	},
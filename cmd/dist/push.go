@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 	"text/tabwriter"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/progress"
 	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/containerd/containerd/remotes/oci"
 	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
@@ -26,6 +29,11 @@ var pushCommand = cli.Command{
 	ArgsUsage: "[flags] <remote> [<local>]",
 	Description: `Pushes an image reference from containerd.
 
+	The remote may be a registry reference or an OCI image layout
+	directory, in the form "oci:<path>:<tag>". Familiar references are
+	qualified as Docker does, so "myorg/app" pushes to
+	"docker.io/myorg/app:latest".
+
 	All resources associated with the manifest reference will be pushed.
 	The ref is used to resolve to a locally existing image manifest.
 	The image manifest must exist before push. Creating a new image
@@ -33,13 +41,22 @@ var pushCommand = cli.Command{
 	creating the associated configuration, and creating the manifest
 	which references those resources.
 `,
-	Flags: append(registryFlags, cli.StringFlag{
+	Flags: append(append(registryFlags, transferFlags...), cli.StringFlag{
 		Name:  "manifest",
 		Usage: "Digest of manifest",
 	}, cli.StringFlag{
 		Name:  "manifest-type",
 		Usage: "Media type of manifest digest",
 		Value: ocispec.MediaTypeImageManifest,
+	}, cli.StringSliceFlag{
+		Name:  "mount-from",
+		Usage: "Repository on the target registry to mount existing blobs from",
+	}, cli.Int64Flag{
+		Name:  "chunk-size",
+		Usage: "Upload layers in chunks of at most this many bytes",
+	}, cli.BoolFlag{
+		Name:  "push-foreign-layers",
+		Usage: "Push foreign, non-distributable layers into the registry",
 	}),
 	Action: func(clicontext *cli.Context) error {
 		var (
@@ -66,18 +83,40 @@ var pushCommand = cli.Command{
 			if local == "" {
 				local = ref
 			}
-			img, err := client.ImageService().Get(ctx, local)
+			img, err := getImage(ctx, client.ImageService(), local)
 			if err != nil {
 				return errors.Wrap(err, "unable to resolve image to manifest")
 			}
 			desc = img.Target
 		}
 
-		resolver, err := getResolver(ctx, clicontext)
+		ref, err = normalizeRef(ref)
 		if err != nil {
 			return err
 		}
-		ongoing := newPushJobs()
+
+		var (
+			resolver remotes.Resolver
+			tracker  = docker.NewFileTracker(filepath.Join(clicontext.GlobalString("root"), "dist", "uploads"))
+		)
+		if oci.IsReference(ref) {
+			resolver = oci.NewResolver()
+		} else {
+			options, err := getResolverOptions(ctx, clicontext)
+			if err != nil {
+				return err
+			}
+			options.ChunkSize = clicontext.Int64("chunk-size")
+			options.Tracker = tracker
+			resolver = docker.NewResolver(options)
+		}
+
+		opts, err := getTransferOpts(clicontext)
+		if err != nil {
+			return err
+		}
+
+		ongoing := newPushJobs(tracker)
 
 		eg, ctx := errgroup.WithContext(ctx)
 
@@ -89,11 +128,12 @@ var pushCommand = cli.Command{
 				return nil, nil
 			})
 
-			return client.Push(ctx, ref, desc,
+			opts = append(opts,
 				containerd.WithResolver(resolver),
 				containerd.WithImageHandler(jobHandler),
 				containerd.WithPushWrapper(ongoing.wrapPusher),
 			)
+			return client.Push(ctx, ref, desc, opts...)
 		})
 
 		errs := make(chan error)
@@ -136,52 +176,43 @@ var pushCommand = cli.Command{
 	},
 }
 
-type pushTracker struct {
-	closed  bool
-	started time.Time
-	updated time.Time
-	written int64
-	total   int64
-}
-
-func (pt *pushTracker) Write(p []byte) (int, error) {
-	pt.written += int64(len(p))
-	pt.updated = time.Now()
-	return len(p), nil
-}
-
-func (pt *pushTracker) Close() error {
-	pt.closed = true
-	pt.updated = time.Now()
-	return nil
-}
-
 type pushWrapper struct {
 	jobs   *pushjobs
 	pusher remotes.Pusher
 }
 
 func (pw pushWrapper) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
-	tr := pw.jobs.track(remotes.MakeRefKey(ctx, desc), desc.Size)
-	defer tr.Close()
-	return pw.pusher.Push(ctx, desc, io.TeeReader(r, tr))
+	ref := remotes.MakeRefKey(ctx, desc)
+	pw.jobs.start(ref, desc.Size)
+	defer pw.jobs.done(ref)
+	return pw.pusher.Push(ctx, desc, r)
 }
 
-type pushStatus struct {
-	name    string
-	started bool
-	written int64
-	total   int64
+type pushState int
+
+const (
+	pushWaiting pushState = iota
+	pushStarted
+	pushDone
+)
+
+type pushJob struct {
+	state pushState
+	total int64
 }
 
 type pushjobs struct {
-	jobs    map[string]*pushTracker
+	jobs    map[string]*pushJob
 	ordered []string
+	tracker docker.StatusTracker
 	mu      sync.Mutex
 }
 
-func newPushJobs() *pushjobs {
-	return &pushjobs{jobs: make(map[string]*pushTracker)}
+func newPushJobs(tracker docker.StatusTracker) *pushjobs {
+	return &pushjobs{
+		jobs:    make(map[string]*pushJob),
+		tracker: tracker,
+	}
 }
 
 func (j *pushjobs) wrapPusher(p remotes.Pusher) remotes.Pusher {
@@ -199,23 +230,29 @@ func (j *pushjobs) add(ref string) {
 		return
 	}
 	j.ordered = append(j.ordered, ref)
-	j.jobs[ref] = nil
+	j.jobs[ref] = &pushJob{}
 }
 
-func (j *pushjobs) track(ref string, size int64) io.WriteCloser {
+func (j *pushjobs) start(ref string, size int64) {
 	j.mu.Lock()
 	defer j.mu.Unlock()
 
 	if _, ok := j.jobs[ref]; !ok {
 		j.ordered = append(j.ordered, ref)
 	}
+	j.jobs[ref] = &pushJob{
+		state: pushStarted,
+		total: size,
+	}
+}
 
-	pt := &pushTracker{
-		started: time.Now(),
-		total:   size,
+func (j *pushjobs) done(ref string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if job, ok := j.jobs[ref]; ok {
+		job.state = pushDone
 	}
-	j.jobs[ref] = pt
-	return pt
 }
 
 func (j *pushjobs) status() []statusInfo {
@@ -224,24 +261,29 @@ func (j *pushjobs) status() []statusInfo {
 
 	status := make([]statusInfo, 0, len(j.jobs))
 	for _, name := range j.ordered {
-		tracker := j.jobs[name]
 		si := statusInfo{
 			Ref: name,
 		}
-		if tracker != nil {
-			si.Offset = tracker.written
-			si.Total = tracker.total
-			si.StartedAt = tracker.started
-			si.UpdatedAt = tracker.updated
-			if tracker.closed {
-				si.Status = "done"
-			} else if tracker.written >= tracker.total {
-				si.Status = "committing"
-			} else {
-				si.Status = "uploading"
-			}
-		} else {
+		job := j.jobs[name]
+		if job.state == pushWaiting {
 			si.Status = "waiting"
+			status = append(status, si)
+			continue
+		}
+
+		si.Total = job.total
+		// manifests and existing blobs have no upload status
+		if ts, err := j.tracker.GetStatus(name); err == nil {
+			si.Offset = ts.Offset
+			si.StartedAt = ts.StartedAt
+			si.UpdatedAt = ts.UpdatedAt
+		}
+		if job.state == pushDone {
+			si.Status = "done"
+		} else if si.Offset >= si.Total {
+			si.Status = "committing"
+		} else {
+			si.Status = "uploading"
 		}
 		status = append(status, si)
 	}
@@ -29,7 +29,7 @@ var pushObjectCommand = cli.Command{
 		ctx, cancel := appContext(clicontext)
 		defer cancel()
 
-		resolver, err := getResolver(ctx, clicontext)
+		resolver, err := getResolverFor(ctx, clicontext, ref)
 		if err != nil {
 			return err
 		}
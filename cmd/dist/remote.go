@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/containerd/remotes"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var remoteCommand = cli.Command{
+	Name:  "remote",
+	Usage: "inspect remotes",
+	Subcommands: cli.Commands{
+		remoteTagsCommand,
+		remoteCatalogCommand,
+	},
+}
+
+var remoteTagsCommand = cli.Command{
+	Name:        "tags",
+	Usage:       "list the tags of a remote repository",
+	ArgsUsage:   "[flags] <remote>",
+	Description: `List the tags available for a repository on a remote.`,
+	Flags:       registryFlags,
+	Action: func(clicontext *cli.Context) error {
+		var (
+			ref = clicontext.Args().First()
+		)
+		if ref == "" {
+			return errors.New("please provide a remote repository")
+		}
+
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		lister, err := getLister(ctx, clicontext)
+		if err != nil {
+			return err
+		}
+
+		ref, err = normalizeRef(ref)
+		if err != nil {
+			return err
+		}
+
+		tags, err := lister.Tags(ctx, ref)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list tags of %v", ref)
+		}
+
+		for _, tag := range tags {
+			fmt.Println(tag)
+		}
+		return nil
+	},
+}
+
+var remoteCatalogCommand = cli.Command{
+	Name:        "catalog",
+	Usage:       "list the repositories of a remote",
+	ArgsUsage:   "[flags] <host>",
+	Description: `List the repositories available on a remote host.`,
+	Flags:       registryFlags,
+	Action: func(clicontext *cli.Context) error {
+		var (
+			host = clicontext.Args().First()
+		)
+		if host == "" {
+			return errors.New("please provide a remote host")
+		}
+
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		lister, err := getLister(ctx, clicontext)
+		if err != nil {
+			return err
+		}
+
+		repos, err := lister.Catalog(ctx, host)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list repositories of %v", host)
+		}
+
+		for _, repo := range repos {
+			fmt.Println(repo)
+		}
+		return nil
+	},
+}
+
+// getLister prepares the resolver from the environment and options and
+// returns it as a remotes.Lister.
+func getLister(ctx context.Context, clicontext *cli.Context) (remotes.Lister, error) {
+	resolver, err := getResolver(ctx, clicontext)
+	if err != nil {
+		return nil, err
+	}
+
+	lister, ok := resolver.(remotes.Lister)
+	if !ok {
+		return nil, errors.New("resolver does not support listing")
+	}
+	return lister, nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/containerd/containerd"
 	snapshotapi "github.com/containerd/containerd/api/services/snapshot"
 	"github.com/containerd/containerd/log"
 	snapshotservice "github.com/containerd/containerd/services/snapshot"
@@ -54,7 +55,7 @@ var rootfsUnpackCommand = cli.Command{
 		for _, image := range images {
 			if image.Target().Digest == dgst {
 				fmt.Printf("unpacking %s (%s)...", dgst, image.Target().MediaType)
-				if err := image.Unpack(ctx); err != nil {
+				if err := image.Unpack(ctx, containerd.WithUnpackSnapshotter(clicontext.GlobalString("snapshotter"))); err != nil {
 					fmt.Println()
 					return err
 				}
@@ -100,7 +101,7 @@ var rootfsPrepareCommand = cli.Command{
 			return err
 		}
 
-		snapshotter := snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(conn))
+		snapshotter := snapshotservice.NewSnapshotterFromClient(snapshotapi.NewSnapshotClient(conn), clicontext.GlobalString("snapshotter"))
 
 		mounts, err := snapshotter.Prepare(ctx, target, dgst.String())
 		if err != nil {
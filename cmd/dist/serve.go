@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/remotes/docker/registry"
+	"github.com/urfave/cli"
+)
+
+var serveCommand = cli.Command{
+	Name:      "serve",
+	Usage:     "serve images as a read-only registry",
+	ArgsUsage: "[flags]",
+	Description: `Serve the images and content of containerd over the Docker Registry
+HTTP API v2.
+
+The registry is read-only and serves the images of the selected namespace
+directly from the content store. Other hosts may use it as a mirror by
+pulling from this host, for example "<host>:5000/library/redis:latest" for
+the image "docker.io/library/redis:latest".`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "listen",
+			Usage: "address to listen on",
+			Value: ":5000",
+		},
+		cli.StringFlag{
+			Name:  "tls-cert",
+			Usage: "path to the TLS certificate to serve with",
+		},
+		cli.StringFlag{
+			Name:  "tls-key",
+			Usage: "path to the TLS key to serve with",
+		},
+	},
+	Action: func(clicontext *cli.Context) error {
+		var (
+			listen    = clicontext.String("listen")
+			cert      = clicontext.String("tls-cert")
+			key       = clicontext.String("tls-key")
+			namespace = clicontext.GlobalString("namespace")
+		)
+		ctx, cancel := appContext(clicontext)
+		defer cancel()
+
+		cs, err := resolveContentStore(clicontext)
+		if err != nil {
+			return err
+		}
+
+		is, err := resolveImageStore(clicontext)
+		if err != nil {
+			return err
+		}
+
+		server := &http.Server{
+			Addr:    listen,
+			Handler: registry.NewHandler(namespace, cs, is),
+		}
+		go func() {
+			<-ctx.Done()
+			server.Close()
+		}()
+
+		log.G(ctx).WithField("address", listen).Info("serving registry")
+		if cert != "" || key != "" {
+			err = server.ListenAndServeTLS(cert, key)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	},
+}
@@ -70,7 +70,7 @@ func (c *container) Delete(ctx context.Context) (err error) {
 	// TODO: should the client be the one removing resources attached
 	// to the container at the moment before we have GC?
 	if c.c.RootFS != "" {
-		err = c.client.SnapshotService().Remove(ctx, c.c.RootFS)
+		err = c.client.SnapshotService(WithSnapshotterName(c.c.Snapshotter)).Remove(ctx, c.c.RootFS)
 	}
 	if _, cerr := c.client.ContainerService().Delete(ctx, &containers.DeleteContainerRequest{
 		ID: c.c.ID,
@@ -126,7 +126,7 @@ func (c *container) NewTask(ctx context.Context, ioCreate IOCreation, opts ...Ne
 	}
 	if c.c.RootFS != "" {
 		// get the rootfs from the snapshotter and add it to the request
-		mounts, err := c.client.SnapshotService().Mounts(ctx, c.c.RootFS)
+		mounts, err := c.client.SnapshotService(WithSnapshotterName(c.c.Snapshotter)).Mounts(ctx, c.c.RootFS)
 		if err != nil {
 			return nil, err
 		}
@@ -45,7 +45,7 @@ func WithCheckpoint(desc v1.Descriptor, rootfsID string) NewContainerOpts {
 				if err != nil {
 					return err
 				}
-				if _, err := client.SnapshotService().Prepare(ctx, rootfsID, identity.ChainID(diffIDs).String()); err != nil {
+				if _, err := client.SnapshotService(WithSnapshotterName(c.Snapshotter)).Prepare(ctx, rootfsID, identity.ChainID(diffIDs).String()); err != nil {
 					if !snapshot.IsExist(err) {
 						return err
 					}
@@ -69,7 +69,7 @@ func WithCheckpoint(desc v1.Descriptor, rootfsID string) NewContainerOpts {
 		}
 		if rw != nil {
 			// apply the rw snapshot to the new rw layer
-			mounts, err := client.SnapshotService().Mounts(ctx, rootfsID)
+			mounts, err := client.SnapshotService(WithSnapshotterName(c.Snapshotter)).Mounts(ctx, rootfsID)
 			if err != nil {
 				return err
 			}
@@ -10,14 +10,15 @@ import (
 //
 // The resources specified in this object are used to create tasks from the container.
 type Container struct {
-	ID        string
-	Labels    map[string]string
-	Image     string
-	Runtime   string
-	Spec      []byte
-	RootFS    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          string
+	Labels      map[string]string
+	Image       string
+	Runtime     string
+	Spec        []byte
+	RootFS      string
+	Snapshotter string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 type Store interface {
@@ -48,6 +48,7 @@ type Info struct {
 	Digest      digest.Digest
 	Size        int64
 	CommittedAt time.Time
+	Labels      map[string]string
 }
 
 type Status struct {
@@ -69,6 +70,14 @@ type Manager interface {
 	// If the content is not present, ErrNotFound will be returned.
 	Info(ctx context.Context, dgst digest.Digest) (Info, error)
 
+	// Update updates the labels of committed content. If fieldpaths are
+	// provided, only the labels named as "labels.<key>" are updated, otherwise
+	// the labels are replaced by those of info. A label with an empty value
+	// is removed.
+	//
+	// If the content is not present, ErrNotFound will be returned.
+	Update(ctx context.Context, info Info, fieldpaths ...string) (Info, error)
+
 	// Walk will call fn for each item in the content store.
 	Walk(ctx context.Context, fn WalkFunc) error
 
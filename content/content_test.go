@@ -145,6 +145,61 @@ func TestWalkBlobs(t *testing.T) {
 	}
 }
 
+func TestUpdateLabels(t *testing.T) {
+	ctx, _, cs, cleanup := contentStoreEnv(t)
+	defer cleanup()
+
+	p := []byte("labeled")
+	dgst := checkWrite(t, ctx, cs, digest.FromBytes(p), p)
+
+	checkLabels := func(expected map[string]string) {
+		info, err := cs.Info(ctx, dgst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(info.Labels, expected) {
+			t.Fatalf("unexpected labels: %v != %v", info.Labels, expected)
+		}
+	}
+
+	if _, err := cs.Update(ctx, Info{
+		Digest: dgst,
+		Labels: map[string]string{"a": "1", "b": "2"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	checkLabels(map[string]string{"a": "1", "b": "2"})
+
+	// only the named labels are changed, an empty value removes the label
+	info, err := cs.Update(ctx, Info{
+		Digest: dgst,
+		Labels: map[string]string{"b": "", "c": "3", "d": "4"},
+	}, "labels.b", "labels.c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"a": "1", "c": "3"}
+	if !reflect.DeepEqual(info.Labels, expected) {
+		t.Fatalf("unexpected labels: %v != %v", info.Labels, expected)
+	}
+	checkLabels(expected)
+
+	if _, err := cs.Update(ctx, Info{Digest: dgst}, "size"); err == nil {
+		t.Fatal("expected update of size to fail")
+	}
+
+	if _, err := cs.Update(ctx, Info{Digest: digest.FromString("missing")}); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	// the labels go away with the blob
+	if err := cs.Delete(ctx, dgst); err != nil {
+		t.Fatal(err)
+	}
+	checkWrite(t, ctx, cs, dgst, p)
+	checkLabels(nil)
+}
+
 // BenchmarkIngests checks the insertion time over varying blob sizes.
 //
 // Note that at the time of writing there is roughly a 4ms insertion overhead
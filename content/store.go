@@ -2,6 +2,7 @@ package content
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,6 +10,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/containerd/containerd/log"
@@ -23,6 +26,15 @@ import (
 // including resumable ingest.
 type store struct {
 	root string
+
+	// labelLocks serializes the updates of the labels of each blob
+	labelLocks   map[digest.Digest]*labelLock
+	labelLocksMu sync.Mutex
+}
+
+type labelLock struct {
+	sync.Mutex
+	refs int
 }
 
 func NewStore(root string) (Store, error) {
@@ -31,7 +43,8 @@ func NewStore(root string) (Store, error) {
 	}
 
 	return &store{
-		root: root,
+		root:       root,
+		labelLocks: map[digest.Digest]*labelLock{},
 	}, nil
 }
 
@@ -46,15 +59,153 @@ func (s *store) Info(ctx context.Context, dgst digest.Digest) (Info, error) {
 		return Info{}, err
 	}
 
-	return s.info(dgst, fi), nil
+	return s.info(dgst, fi)
 }
 
-func (s *store) info(dgst digest.Digest, fi os.FileInfo) Info {
+func (s *store) info(dgst digest.Digest, fi os.FileInfo) (Info, error) {
+	labels, err := s.labels(dgst)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return blobInfo(dgst, fi, labels), nil
+}
+
+func blobInfo(dgst digest.Digest, fi os.FileInfo, labels map[string]string) Info {
 	return Info{
 		Digest:      dgst,
 		Size:        fi.Size(),
 		CommittedAt: fi.ModTime(),
+		Labels:      labels,
+	}
+}
+
+// Update updates the labels of a blob. The labels are kept in a file of their
+// own, outside of the blobs directory, as the blobs are never modified.
+// Updates of the labels of a blob are serialized.
+func (s *store) Update(ctx context.Context, info Info, fieldpaths ...string) (Info, error) {
+	unlock := s.lockLabels(info.Digest)
+	defer unlock()
+
+	fi, err := os.Stat(s.blobPath(info.Digest))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = ErrNotFound
+		}
+
+		return Info{}, err
+	}
+
+	labels := map[string]string{}
+	if len(fieldpaths) > 0 {
+		current, err := s.labels(info.Digest)
+		if err != nil {
+			return Info{}, err
+		}
+		for k, v := range current {
+			labels[k] = v
+		}
+
+		for _, path := range fieldpaths {
+			if !strings.HasPrefix(path, "labels.") {
+				return Info{}, errors.Errorf("cannot update %q field", path)
+			}
+			key := strings.TrimPrefix(path, "labels.")
+			labels[key] = info.Labels[key]
+		}
+	} else {
+		for k, v := range info.Labels {
+			labels[k] = v
+		}
+	}
+	for k, v := range labels {
+		if v == "" {
+			delete(labels, k)
+		}
+	}
+
+	if err := s.writeLabels(info.Digest, labels); err != nil {
+		return Info{}, err
+	}
+
+	return blobInfo(info.Digest, fi, labels), nil
+}
+
+// lockLabels locks the labels of dgst, the returned function unlocks them.
+func (s *store) lockLabels(dgst digest.Digest) func() {
+	s.labelLocksMu.Lock()
+	l, ok := s.labelLocks[dgst]
+	if !ok {
+		l = &labelLock{}
+		s.labelLocks[dgst] = l
+	}
+	l.refs++
+	s.labelLocksMu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		s.labelLocksMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.labelLocks, dgst)
+		}
+		s.labelLocksMu.Unlock()
+	}
+}
+
+func (s *store) labels(dgst digest.Digest) (map[string]string, error) {
+	p, err := ioutil.ReadFile(s.labelsPath(dgst))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
+
+	var labels map[string]string
+	if err := json.Unmarshal(p, &labels); err != nil {
+		return nil, errors.Wrapf(err, "failed to read labels of %v", dgst)
+	}
+	return labels, nil
+}
+
+// writeLabels replaces the labels of the blob atomically, an empty set of
+// labels removes the file. The new file is synced before it replaces the
+// previous one, so that a crash leaves either of them in place.
+func (s *store) writeLabels(dgst digest.Digest, labels map[string]string) error {
+	path := s.labelsPath(dgst)
+	if len(labels) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	p, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".labels-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(p); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
 // Open returns an io.ReadCloser for the blob.
@@ -87,6 +238,10 @@ func (cs *store) Delete(ctx context.Context, dgst digest.Digest) error {
 		return ErrNotFound
 	}
 
+	if err := os.Remove(cs.labelsPath(dgst)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -94,7 +249,12 @@ func (cs *store) Delete(ctx context.Context, dgst digest.Digest) error {
 
 func (cs *store) Walk(ctx context.Context, fn WalkFunc) error {
 	root := filepath.Join(cs.root, "blobs")
-	var alg digest.Algorithm
+	var (
+		alg digest.Algorithm
+		// labeled are the hex digests of the algorithm with labels, so
+		// that only their label files are read
+		labeled map[string]struct{}
+	)
 	return filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -118,6 +278,15 @@ func (cs *store) Walk(ctx context.Context, fn WalkFunc) error {
 				return filepath.SkipDir
 			}
 
+			entries, err := ioutil.ReadDir(filepath.Join(cs.root, "labels", alg.String()))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			labeled = map[string]struct{}{}
+			for _, entry := range entries {
+				labeled[entry.Name()] = struct{}{}
+			}
+
 			// descending into a hash directory
 			return nil
 		}
@@ -130,7 +299,15 @@ func (cs *store) Walk(ctx context.Context, fn WalkFunc) error {
 			// store or extra paths not expected previously.
 		}
 
-		return fn(cs.info(dgst, fi))
+		if _, ok := labeled[dgst.Hex()]; !ok {
+			return fn(blobInfo(dgst, fi, nil))
+		}
+		info, err := cs.info(dgst, fi)
+		if err != nil {
+			return err
+		}
+
+		return fn(info)
 	})
 }
 
@@ -335,6 +512,10 @@ func (cs *store) blobPath(dgst digest.Digest) string {
 	return filepath.Join(cs.root, "blobs", dgst.Algorithm().String(), dgst.Hex())
 }
 
+func (cs *store) labelsPath(dgst digest.Digest) string {
+	return filepath.Join(cs.root, "labels", dgst.Algorithm().String(), dgst.Hex())
+}
+
 func (s *store) ingestRoot(ref string) string {
 	dgst := digest.FromString(ref)
 	return filepath.Join(s.root, "ingest", dgst.Hex())
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 
+	diffapi "github.com/containerd/containerd/api/services/diff"
 	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/rootfs"
+	diffservice "github.com/containerd/containerd/services/diff"
 	"github.com/opencontainers/image-spec/specs-go/v1"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
@@ -16,7 +18,27 @@ type Image interface {
 	Name() string
 	Target() ocispec.Descriptor
 
-	Unpack(context.Context) error
+	// Unpack extracts the layers of the image into the default snapshotter
+	// of the daemon, or the snapshotter selected by the options.
+	Unpack(context.Context, ...UnpackOpts) error
+}
+
+// UnpackOpts allows the caller to set options on unpacking an image
+type UnpackOpts func(*UnpackConfig)
+
+// UnpackConfig holds the options of unpacking an image.
+type UnpackConfig struct {
+	// Snapshotter is the name of the snapshotter to unpack into, the
+	// default snapshotter of the daemon if empty.
+	Snapshotter string
+}
+
+// WithUnpackSnapshotter unpacks the image into the snapshotter loaded by the
+// daemon as name, the default snapshotter if name is empty.
+func WithUnpackSnapshotter(name string) UnpackOpts {
+	return func(c *UnpackConfig) {
+		c.Snapshotter = name
+	}
 }
 
 var _ = (Image)(&image{})
@@ -35,12 +57,20 @@ func (i *image) Target() ocispec.Descriptor {
 	return i.i.Target
 }
 
-func (i *image) Unpack(ctx context.Context) error {
+func (i *image) Unpack(ctx context.Context, opts ...UnpackOpts) error {
+	var config UnpackConfig
+	for _, o := range opts {
+		o(&config)
+	}
 	layers, err := i.getLayers(ctx)
 	if err != nil {
 		return err
 	}
-	if _, err := rootfs.ApplyLayers(ctx, layers, i.client.SnapshotService(), i.client.DiffService()); err != nil {
+	var (
+		sn = i.client.SnapshotService(WithSnapshotterName(config.Snapshotter))
+		a  = diffservice.NewSnapshotDiffServiceFromClient(diffapi.NewDiffClient(i.client.conn), config.Snapshotter)
+	)
+	if _, err := rootfs.ApplyLayers(ctx, layers, sn, a); err != nil {
 		return err
 	}
 	return nil
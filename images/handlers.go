@@ -93,6 +93,21 @@ func Walk(ctx context.Context, handler Handler, descs ...ocispec.Descriptor) err
 //
 // If any handler returns an error, the dispatch session will be canceled.
 func Dispatch(ctx context.Context, handler Handler, descs ...ocispec.Descriptor) error {
+	return dispatch(ctx, handler, nil, descs...)
+}
+
+// DispatchLimit behaves like Dispatch but runs at most limit handlers
+// concurrently across the whole dispatch session. If limit is zero or
+// negative, the number of concurrent handlers is not limited.
+func DispatchLimit(ctx context.Context, handler Handler, limit int, descs ...ocispec.Descriptor) error {
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+	return dispatch(ctx, handler, sem, descs...)
+}
+
+func dispatch(ctx context.Context, handler Handler, sem chan struct{}, descs ...ocispec.Descriptor) error {
 	eg, ctx := errgroup.WithContext(ctx)
 	for _, desc := range descs {
 		desc := desc
@@ -100,7 +115,19 @@ func Dispatch(ctx context.Context, handler Handler, descs ...ocispec.Descriptor)
 		eg.Go(func() error {
 			desc := desc
 
+			// The slot is only held while the handler runs, children
+			// acquire their own.
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
 			children, err := handler.Handle(ctx, desc)
+			if sem != nil {
+				<-sem
+			}
 			if err != nil {
 				if errors.Cause(err) == SkipDesc {
 					return nil // don't traverse the children.
@@ -109,7 +136,7 @@ func Dispatch(ctx context.Context, handler Handler, descs ...ocispec.Descriptor)
 			}
 
 			if len(children) > 0 {
-				return Dispatch(ctx, handler, children...)
+				return dispatch(ctx, handler, sem, children...)
 			}
 
 			return nil
@@ -131,7 +158,10 @@ func ChildrenHandler(provider content.Provider) HandlerFunc {
 		switch desc.MediaType {
 		case MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
 		case MediaTypeDockerSchema2Layer, MediaTypeDockerSchema2LayerGzip,
-			MediaTypeDockerSchema2Config:
+			MediaTypeDockerSchema2LayerForeign, MediaTypeDockerSchema2LayerForeignGzip,
+			MediaTypeDockerSchema2Config, ocispec.MediaTypeImageLayer, ocispec.MediaTypeImageLayerGzip,
+			ocispec.MediaTypeImageLayerNonDistributable, ocispec.MediaTypeImageLayerNonDistributableGzip,
+			ocispec.MediaTypeImageConfig:
 			return nil, nil
 		default:
 			return nil, fmt.Errorf("%v not yet supported", desc.MediaType)
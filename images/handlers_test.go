@@ -0,0 +1,66 @@
+package images
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDispatchLimit(t *testing.T) {
+	const limit = 2
+
+	var (
+		mu      sync.Mutex
+		active  int
+		maxSeen int
+		handled int
+	)
+
+	layers := make([]ocispec.Descriptor, 8)
+	for i := range layers {
+		layers[i] = ocispec.Descriptor{
+			MediaType: MediaTypeDockerSchema2LayerGzip,
+			Digest:    digest.FromBytes([]byte{byte(i)}),
+		}
+	}
+	root := ocispec.Descriptor{
+		MediaType: MediaTypeDockerSchema2Manifest,
+		Digest:    digest.FromString("manifest"),
+	}
+
+	handler := HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		mu.Lock()
+		active++
+		handled++
+		if active > maxSeen {
+			maxSeen = active
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		active--
+		mu.Unlock()
+
+		if desc.Digest == root.Digest {
+			return layers, nil
+		}
+		return nil, nil
+	})
+
+	if err := DispatchLimit(context.Background(), handler, limit, root); err != nil {
+		t.Fatal(err)
+	}
+
+	if handled != len(layers)+1 {
+		t.Fatalf("unexpected number of handled descriptors: %d", handled)
+	}
+	if maxSeen > limit {
+		t.Fatalf("%d handlers ran concurrently, expected at most %d", maxSeen, limit)
+	}
+}
@@ -16,6 +16,8 @@ import (
 // version: Currently, this is "v1". Additions can be made to v1 in a backwards
 // compatible way. If the layout changes, a new version must be made, along
 // with a migration.
+// The schema version of the layout is stored in the top-level "schema"
+// bucket, see migrations.go.
 //
 // namespace: the namespace to which this object belongs.
 //
@@ -33,16 +35,17 @@ var (
 	bucketKeyObjectImages     = []byte("images")     // stores image objects
 	bucketKeyObjectContainers = []byte("containers") // stores container objects
 
-	bucketKeyDigest    = []byte("digest")
-	bucketKeyMediaType = []byte("mediatype")
-	bucketKeySize      = []byte("size")
-	bucketKeyLabels    = []byte("labels")
-	bucketKeyImage     = []byte("image")
-	bucketKeyRuntime   = []byte("runtime")
-	bucketKeySpec      = []byte("spec")
-	bucketKeyRootFS    = []byte("rootfs")
-	bucketKeyCreatedAt = []byte("createdat")
-	bucketKeyUpdatedAt = []byte("updatedat")
+	bucketKeyDigest      = []byte("digest")
+	bucketKeyMediaType   = []byte("mediatype")
+	bucketKeySize        = []byte("size")
+	bucketKeyLabels      = []byte("labels")
+	bucketKeyImage       = []byte("image")
+	bucketKeyRuntime     = []byte("runtime")
+	bucketKeySpec        = []byte("spec")
+	bucketKeyRootFS      = []byte("rootfs")
+	bucketKeySnapshotter = []byte("snapshotter")
+	bucketKeyCreatedAt   = []byte("createdat")
+	bucketKeyUpdatedAt   = []byte("updatedat")
 )
 
 func getBucket(tx *bolt.Tx, keys ...[]byte) *bolt.Bucket {
@@ -152,6 +152,8 @@ func readContainer(container *containers.Container, bkt *bolt.Bucket) error {
 			copy(container.Spec, v)
 		case string(bucketKeyRootFS):
 			container.RootFS = string(v)
+		case string(bucketKeySnapshotter):
+			container.Snapshotter = string(v)
 		case string(bucketKeyCreatedAt):
 			if err := container.CreatedAt.UnmarshalBinary(v); err != nil {
 				return err
@@ -192,6 +194,7 @@ func writeContainer(container *containers.Container, bkt *bolt.Bucket) error {
 		{bucketKeyRuntime, []byte(container.Runtime)},
 		{bucketKeySpec, container.Spec},
 		{bucketKeyRootFS, []byte(container.RootFS)},
+		{bucketKeySnapshotter, []byte(container.Snapshotter)},
 		{bucketKeyCreatedAt, createdAt},
 		{bucketKeyUpdatedAt, updatedAt},
 	} {
@@ -0,0 +1,21 @@
+package metadata
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/metadata/schema"
+)
+
+// dbSchema is the schema of the layout described in buckets.go. The version
+// must be increased, with a migration, whenever the encoding of stored
+// objects changes.
+var dbSchema = schema.Schema{
+	Bucket:  bucketKeyVersion,
+	Version: 1,
+}
+
+// Migrate migrates the metadata database to the current schema. It must be
+// called before the database is used. With dryRun, the database is left
+// unchanged.
+func Migrate(db *bolt.DB, dryRun bool) (schema.Result, error) {
+	return dbSchema.Update(db, dryRun)
+}
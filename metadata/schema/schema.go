@@ -0,0 +1,154 @@
+// Package schema records the version of the layout of a bolt database and
+// migrates databases created with an older layout.
+//
+// The version is stored in the top-level "schema" bucket, under the name of
+// the top-level bucket of the layout, such as "v1", so that it does not take
+// a key within the layout. Databases created before versioning was
+// introduced have no version and are considered at version 1.
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+)
+
+// ErrNewerVersion is returned when a database was written with a newer
+// layout than supported, by a newer release.
+var ErrNewerVersion = errors.New("database schema is newer than supported")
+
+var bucketKeySchema = []byte("schema")
+
+// Migration upgrades the layout of a database to Version.
+type Migration struct {
+	// Version is the schema version after the migration.
+	Version int
+	// Description tells what the migration changes.
+	Description string
+	// Migrate changes the layout within the transaction.
+	Migrate func(tx *bolt.Tx) error
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%d: %s", m.Version, m.Description)
+}
+
+// Schema describes the current layout of a database and how to migrate to
+// it from older layouts.
+type Schema struct {
+	// Bucket is the top-level bucket of the layout.
+	Bucket []byte
+	// Version is the current version of the layout.
+	Version int
+	// Migrations are ordered by version, up to Version.
+	Migrations []Migration
+}
+
+// Result reports the migration of a database.
+type Result struct {
+	// From is the version of the database before the migration, or 0 for
+	// a new database.
+	From int
+	// To is the version of the database after the migration.
+	To int
+	// Applied are the migrations run, in order.
+	Applied []Migration
+}
+
+// Migrate runs the pending migrations of the database within tx, which must
+// be writable, and records the current version. A new database is created
+// at the current version. If the database has a newer version, an error
+// wrapping ErrNewerVersion is returned.
+func (s Schema) Migrate(tx *bolt.Tx) (Result, error) {
+	if tx.Bucket(s.Bucket) == nil {
+		if _, err := tx.CreateBucket(s.Bucket); err != nil {
+			return Result{}, err
+		}
+		return Result{To: s.Version}, putVersion(tx, s.Bucket, s.Version)
+	}
+
+	version, err := getVersion(tx, s.Bucket)
+	if err != nil {
+		return Result{}, err
+	}
+	if version > s.Version {
+		return Result{}, errors.Wrapf(ErrNewerVersion, "version %d, supported up to %d", version, s.Version)
+	}
+
+	result := Result{
+		From: version,
+		To:   s.Version,
+	}
+	last := 0
+	for _, m := range s.Migrations {
+		if m.Version <= last || m.Version > s.Version {
+			return Result{}, errors.Errorf("migration %q out of order", m)
+		}
+		last = m.Version
+		if m.Version <= version {
+			continue
+		}
+		if err := m.Migrate(tx); err != nil {
+			return Result{}, errors.Wrapf(err, "migration %q failed", m)
+		}
+		result.Applied = append(result.Applied, m)
+	}
+
+	if version == s.Version {
+		return result, nil
+	}
+	// migrations may have replaced the bucket
+	if tx.Bucket(s.Bucket) == nil {
+		if _, err := tx.CreateBucket(s.Bucket); err != nil {
+			return Result{}, err
+		}
+	}
+	return result, putVersion(tx, s.Bucket, s.Version)
+}
+
+// Update migrates db in a single transaction, see Migrate. With dryRun, the
+// migrations are run but the transaction is rolled back, leaving the
+// database unchanged.
+func (s Schema) Update(db *bolt.DB, dryRun bool) (Result, error) {
+	tx, err := db.Begin(true)
+	if err != nil {
+		return Result{}, err
+	}
+	result, err := s.Migrate(tx)
+	if err != nil || dryRun {
+		if rerr := tx.Rollback(); rerr != nil && err == nil {
+			err = rerr
+		}
+		return result, err
+	}
+	return result, tx.Commit()
+}
+
+// getVersion returns the version of the layout in the bucket named layout.
+func getVersion(tx *bolt.Tx, layout []byte) (int, error) {
+	var v []byte
+	if bkt := tx.Bucket(bucketKeySchema); bkt != nil {
+		v = bkt.Get(layout)
+	}
+	if v == nil {
+		// written before the schema was versioned
+		return 1, nil
+	}
+	version, n := binary.Varint(v)
+	if n <= 0 {
+		return 0, errors.New("invalid schema version")
+	}
+	return int(version), nil
+}
+
+// putVersion records the version of the layout in the bucket named layout.
+func putVersion(tx *bolt.Tx, layout []byte, version int) error {
+	bkt, err := tx.CreateBucketIfNotExists(bucketKeySchema)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	return bkt.Put(layout, buf[:binary.PutVarint(buf, int64(version))])
+}
@@ -0,0 +1,261 @@
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/pkg/errors"
+)
+
+var bucketKeyTest = []byte("v1")
+
+func newDB(t *testing.T) (*bolt.DB, func()) {
+	dir, err := ioutil.TempDir("", "schema-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := bolt.Open(filepath.Join(dir, "test.db"), 0600, nil)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func dbVersion(t *testing.T, db *bolt.DB) int {
+	var version int
+	if err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		version, err = getVersion(tx, bucketKeyTest)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
+
+// unversioned creates the bucket as written before versioning.
+func unversioned(t *testing.T, db *bolt.DB) {
+	if err := db.Update(func(tx *bolt.Tx) error {
+		bkt, err := tx.CreateBucket(bucketKeyTest)
+		if err != nil {
+			return err
+		}
+		_, err = bkt.CreateBucket([]byte("default"))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateNew(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	var ran bool
+	s := Schema{
+		Bucket:  bucketKeyTest,
+		Version: 2,
+		Migrations: []Migration{
+			{Version: 2, Description: "test", Migrate: func(*bolt.Tx) error {
+				ran = true
+				return nil
+			}},
+		},
+	}
+	result, err := s.Update(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ran || len(result.Applied) != 0 {
+		t.Fatal("expected no migrations on a new database")
+	}
+	if result.From != 0 || result.To != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if v := dbVersion(t, db); v != 2 {
+		t.Fatalf("expected version 2, got %d", v)
+	}
+}
+
+func TestMigrateOrder(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+	unversioned(t, db)
+
+	var ran []int
+	migration := func(version int) Migration {
+		return Migration{
+			Version:     version,
+			Description: "test",
+			Migrate: func(tx *bolt.Tx) error {
+				ran = append(ran, version)
+				return nil
+			},
+		}
+	}
+	s := Schema{
+		Bucket:     bucketKeyTest,
+		Version:    4,
+		Migrations: []Migration{migration(2), migration(3), migration(4)},
+	}
+	result, err := s.Update(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ran, []int{2, 3, 4}) {
+		t.Fatalf("unexpected migrations %v", ran)
+	}
+	if result.From != 1 || result.To != 4 || len(result.Applied) != 3 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	ran = nil
+	if _, err := s.Update(db, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 0 {
+		t.Fatalf("expected no migrations on a current database, ran %v", ran)
+	}
+}
+
+func TestMigrateOutOfOrder(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+	unversioned(t, db)
+
+	noop := func(*bolt.Tx) error { return nil }
+	s := Schema{
+		Bucket:  bucketKeyTest,
+		Version: 3,
+		Migrations: []Migration{
+			{Version: 3, Migrate: noop},
+			{Version: 2, Migrate: noop},
+		},
+	}
+	if _, err := s.Update(db, false); err == nil {
+		t.Fatal("expected migrations out of order to fail")
+	}
+	if v := dbVersion(t, db); v != 1 {
+		t.Fatalf("expected version 1, got %d", v)
+	}
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+	unversioned(t, db)
+
+	s := Schema{
+		Bucket:  bucketKeyTest,
+		Version: 2,
+		Migrations: []Migration{
+			{Version: 2, Description: "test", Migrate: func(tx *bolt.Tx) error {
+				return tx.Bucket(bucketKeyTest).DeleteBucket([]byte("default"))
+			}},
+		},
+	}
+	result, err := s.Update(db, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Applied) != 1 {
+		t.Fatalf("expected migration to be reported, got %+v", result)
+	}
+	if v := dbVersion(t, db); v != 1 {
+		t.Fatalf("expected version 1 after dry run, got %d", v)
+	}
+	if err := db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketKeyTest).Bucket([]byte("default")) == nil {
+			return errors.New("bucket removed by dry run")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrateFailure(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+	unversioned(t, db)
+
+	s := Schema{
+		Bucket:  bucketKeyTest,
+		Version: 3,
+		Migrations: []Migration{
+			{Version: 2, Migrate: func(tx *bolt.Tx) error {
+				return tx.Bucket(bucketKeyTest).DeleteBucket([]byte("default"))
+			}},
+			{Version: 3, Migrate: func(tx *bolt.Tx) error {
+				return errors.New("failed")
+			}},
+		},
+	}
+	if _, err := s.Update(db, false); err == nil {
+		t.Fatal("expected migration to fail")
+	}
+	if v := dbVersion(t, db); v != 1 {
+		t.Fatalf("expected version 1 after failed migration, got %d", v)
+	}
+}
+
+func TestMigrateNewer(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	if _, err := (Schema{Bucket: bucketKeyTest, Version: 3}).Update(db, false); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Schema{Bucket: bucketKeyTest, Version: 2}.Update(db, false)
+	if errors.Cause(err) != ErrNewerVersion {
+		t.Fatalf("expected newer version error, got %v", err)
+	}
+}
+
+func TestMigrateVersionOutsideLayout(t *testing.T) {
+	db, cleanup := newDB(t)
+	defer cleanup()
+
+	// the layout may hold any key, such as a namespace named "version"
+	if err := db.Update(func(tx *bolt.Tx) error {
+		bkt, err := tx.CreateBucket(bucketKeyTest)
+		if err != nil {
+			return err
+		}
+		_, err = bkt.CreateBucket([]byte("version"))
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Schema{Bucket: bucketKeyTest, Version: 2}
+	result, err := s.Update(db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.From != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if v := dbVersion(t, db); v != 2 {
+		t.Fatalf("expected version 2, got %d", v)
+	}
+	if _, err := s.Update(db, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketKeyTest).Bucket([]byte("version")) == nil {
+			return errors.New("namespace bucket removed")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,14 +1,36 @@
 package mount
 
 import (
+	"fmt"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"syscall"
+	"unsafe"
 
+	"github.com/pkg/errors"
 	"golang.org/x/sys/unix"
 )
 
+var pagesize = unix.Getpagesize()
+
 func (m *Mount) Mount(target string) error {
-	flags, data := parseMountOptions(m.Options)
-	return unix.Mount(m.Source, target, m.Type, uintptr(flags), data)
+	var (
+		chdir   string
+		options = m.Options
+	)
+	// overlay mounts of long layer chains exceed the page size limit of the
+	// mount data, the lower directories are then passed as paths relative
+	// to their common parent directory.
+	if m.Type == "overlay" && optionsSize(options) >= pagesize-512 {
+		chdir, options = compactLowerdirOption(options)
+	}
+
+	flags, data := parseMountOptions(options)
+	if len(data) > pagesize {
+		return errors.Errorf("mount options too long: %d bytes", len(data))
+	}
+	return mountAt(chdir, m.Source, target, m.Type, uintptr(flags), data)
 }
 
 func Unmount(mount string, flags int) error {
@@ -68,3 +90,136 @@ func parseMountOptions(options []string) (int, string) {
 	}
 	return flag, strings.Join(data, ",")
 }
+
+// optionsSize returns the size of the options joined as mount data.
+func optionsSize(options []string) int {
+	size := 0
+	for _, o := range options {
+		size += len(o) + 1
+	}
+	return size
+}
+
+// compactLowerdirOption rewrites the lowerdir option of an overlay mount with
+// paths relative to the common parent directory of the lower directories. The
+// parent directory is returned, the mount must be performed from within it.
+// If the paths have no common parent, the options are returned unchanged.
+func compactLowerdirOption(options []string) (string, []string) {
+	idx := -1
+	for i, o := range options {
+		if strings.HasPrefix(o, "lowerdir=") {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return "", options
+	}
+	dirs := strings.Split(strings.TrimPrefix(options[idx], "lowerdir="), ":")
+	if len(dirs) < 2 {
+		return "", options
+	}
+
+	common := dirs[0]
+	for _, dir := range dirs[1:] {
+		for !strings.HasPrefix(dir, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	common = common[:strings.LastIndex(common, "/")+1]
+	if common == "" || common == "/" {
+		return "", options
+	}
+
+	for i, dir := range dirs {
+		dirs[i] = dir[len(common):]
+	}
+	compacted := make([]string, len(options))
+	copy(compacted, options)
+	compacted[idx] = fmt.Sprintf("lowerdir=%s", strings.Join(dirs, ":"))
+	return common, compacted
+}
+
+// mountAt performs the mount with the working directory set to chdir, if not
+// empty, so that relative paths in data are resolved against it. The mount
+// is then done in a forked child, which has its own working directory and
+// exits once the mount is done, the rest of the process is unaffected.
+func mountAt(chdir, source, target, fstype string, flags uintptr, data string) error {
+	if chdir == "" {
+		return unix.Mount(source, target, fstype, flags, data)
+	}
+
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+
+	dirfd, err := unix.Open(chdir, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", chdir)
+	}
+	defer unix.Close(dirfd)
+
+	// the arguments are allocated before forking, the child may only make
+	// raw system calls.
+	var p [4]*byte
+	for i, s := range []string{source, target, fstype, data} {
+		if p[i], err = unix.BytePtrFromString(s); err != nil {
+			return err
+		}
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	pid, errno := forkAndMountat(uintptr(dirfd),
+		uintptr(unsafe.Pointer(p[0])), uintptr(unsafe.Pointer(p[1])),
+		uintptr(unsafe.Pointer(p[2])), flags, uintptr(unsafe.Pointer(p[3])))
+	if errno != 0 {
+		return errors.Wrap(errno, "failed to fork mount process")
+	}
+
+	var ws unix.WaitStatus
+	_, err = unix.Wait4(int(pid), &ws, 0, nil)
+	for err == unix.EINTR {
+		_, err = unix.Wait4(int(pid), &ws, 0, nil)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for mount process %d", pid)
+	}
+	if !ws.Exited() {
+		return errors.Errorf("mount process %d terminated: %v", pid, ws)
+	}
+	if errno := syscall.Errno(ws.ExitStatus()); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// forkAndMountat forks a child which changes its working directory to dirfd,
+// performs the mount and exits with the errno of the mount. The child shares
+// the file descriptors of the process but never runs Go code, it is never
+// reused by the runtime. The OS thread must be locked.
+func forkAndMountat(dirfd, source, target, fstype, flags, data uintptr) (pid uintptr, errno syscall.Errno) {
+	// signals are blocked until the child is created
+	beforeFork()
+	pid, _, errno = unix.RawSyscall6(unix.SYS_CLONE, uintptr(unix.SIGCHLD)|unix.CLONE_FILES, 0, 0, 0, 0, 0)
+	if errno != 0 || pid != 0 {
+		afterFork()
+		return
+	}
+
+	// in the child
+	_, _, errno = unix.RawSyscall(unix.SYS_FCHDIR, dirfd, 0, 0)
+	if errno == 0 {
+		_, _, errno = unix.RawSyscall6(unix.SYS_MOUNT, source, target, fstype, flags, data, 0)
+	}
+	unix.RawSyscall(unix.SYS_EXIT, uintptr(errno), 0, 0)
+	panic("unreachable")
+}
+
+//go:linkname beforeFork syscall.runtime_BeforeFork
+func beforeFork()
+
+//go:linkname afterFork syscall.runtime_AfterFork
+func afterFork()
@@ -0,0 +1,2 @@
+// This file is intentionally empty.
+// It allows the bodyless go:linkname declarations in mount_linux.go.
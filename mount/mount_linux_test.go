@@ -0,0 +1,47 @@
+// +build linux
+
+package mount
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactLowerdirOption(t *testing.T) {
+	for _, tc := range []struct {
+		options  []string
+		chdir    string
+		expected []string
+	}{
+		{
+			options:  []string{"workdir=/r/snapshots/3/work", "upperdir=/r/snapshots/3/fs", "lowerdir=/r/snapshots/2/fs:/r/snapshots/1/fs"},
+			chdir:    "/r/snapshots/",
+			expected: []string{"workdir=/r/snapshots/3/work", "upperdir=/r/snapshots/3/fs", "lowerdir=2/fs:1/fs"},
+		},
+		{
+			options:  []string{"lowerdir=/r/snapshots/12/fs:/r/snapshots/1/fs"},
+			chdir:    "/r/snapshots/",
+			expected: []string{"lowerdir=12/fs:1/fs"},
+		},
+		{
+			options:  []string{"lowerdir=/a/fs:/b/fs"},
+			expected: []string{"lowerdir=/a/fs:/b/fs"},
+		},
+		{
+			options:  []string{"lowerdir=/r/snapshots/1/fs"},
+			expected: []string{"lowerdir=/r/snapshots/1/fs"},
+		},
+		{
+			options:  []string{"ro"},
+			expected: []string{"ro"},
+		},
+	} {
+		chdir, options := compactLowerdirOption(tc.options)
+		if chdir != tc.chdir {
+			t.Errorf("%v: expected chdir %q but received %q", tc.options, tc.chdir, chdir)
+		}
+		if !reflect.DeepEqual(options, tc.expected) {
+			t.Errorf("%v: expected options %v but received %v", tc.options, tc.expected, options)
+		}
+	}
+}
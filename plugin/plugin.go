@@ -30,16 +30,18 @@ type Registration struct {
 
 // TODO(@crosbymichael): how do we keep this struct from growing but support dependency injection for loaded plugins?
 type InitContext struct {
-	Root        string
-	State       string
-	Runtimes    map[string]Runtime
-	Content     content.Store
-	Meta        *bolt.DB
-	Snapshotter snapshot.Snapshotter
-	Differ      Differ
-	Config      interface{}
-	Context     context.Context
-	Monitor     TaskMonitor
+	Root            string
+	State           string
+	Runtimes        map[string]Runtime
+	Content         content.Store
+	Meta            *bolt.DB
+	Snapshotter     snapshot.Snapshotter
+	Snapshotters    map[string]snapshot.Snapshotter
+	SnapshotterName string
+	Differ          Differ
+	Config          interface{}
+	Context         context.Context
+	Monitor         TaskMonitor
 }
 
 type Service interface {
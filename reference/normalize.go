@@ -0,0 +1,113 @@
+package reference
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultDomain is the registry host applied to references without one.
+	DefaultDomain = "docker.io"
+
+	// DefaultTag is the tag applied to references without a tag or digest.
+	DefaultTag = "latest"
+
+	legacyDefaultDomain = "index.docker.io"
+	officialRepoPrefix  = "library/"
+)
+
+// ParseNormalized parses a reference in the familiar form used by Docker,
+// such as "ubuntu" or "myorg/app:1.0", into a fully qualified Spec.
+//
+// Following Docker, a reference without a registry host is qualified with
+// DefaultDomain, an official image of DefaultDomain is prefixed with
+// "library/" and a reference without a tag or digest receives DefaultTag. The
+// first path component of a reference is only taken as the host if it
+// contains a "." or ":", or is "localhost".
+func ParseNormalized(s string) (Spec, error) {
+	if s == "" {
+		return Spec{}, ErrInvalid
+	}
+
+	name, object := splitNameObject(s)
+	if name == "" {
+		return Spec{}, ErrInvalid
+	}
+
+	domain, remainder := splitDomain(name)
+	if domain == DefaultDomain && !strings.ContainsRune(remainder, '/') {
+		remainder = officialRepoPrefix + remainder
+	}
+
+	if object == "" {
+		object = ":" + DefaultTag
+	}
+
+	return Parse(domain + "/" + remainder + object)
+}
+
+// Normalize returns the fully qualified string form of a reference in the
+// familiar form. See ParseNormalized.
+func Normalize(s string) (string, error) {
+	spec, err := ParseNormalized(s)
+	if err != nil {
+		return "", err
+	}
+	return spec.String(), nil
+}
+
+// Familiar returns the shortest form of the reference accepted by
+// ParseNormalized, for display. DefaultDomain and the "library/" prefix of
+// official images are removed, while the object is kept as is.
+func (r Spec) Familiar() string {
+	locator := r.Locator
+	if strings.HasPrefix(locator, DefaultDomain+"/") {
+		locator = strings.TrimPrefix(locator, DefaultDomain+"/")
+		if strings.HasPrefix(locator, officialRepoPrefix) &&
+			!strings.ContainsRune(locator[len(officialRepoPrefix):], '/') {
+			locator = locator[len(officialRepoPrefix):]
+		}
+	}
+
+	return Spec{Locator: locator, Object: r.Object}.String()
+}
+
+// FamiliarString returns the familiar form of the reference s for display. If
+// s cannot be parsed, it is returned unchanged.
+func FamiliarString(s string) string {
+	spec, err := Parse(s)
+	if err != nil {
+		return s
+	}
+	return spec.Familiar()
+}
+
+// splitNameObject splits s into the name and the object, with the object
+// retaining its leading ":" or "@".
+func splitNameObject(s string) (name, object string) {
+	if i := strings.IndexRune(s, '@'); i >= 0 {
+		name, object = s[:i], s[i:]
+	} else {
+		name = s
+	}
+
+	// a ":" after the last "/" starts the tag, others separate a port.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, object = name[:i], name[i:]+object
+	}
+	return name, object
+}
+
+// splitDomain splits the name into the registry host and the repository
+// path, applying DefaultDomain if the name has no host.
+func splitDomain(name string) (domain, remainder string) {
+	i := strings.IndexRune(name, '/')
+	if i < 0 || (!strings.ContainsAny(name[:i], ".:") && name[:i] != "localhost") {
+		return DefaultDomain, name
+	}
+
+	domain, remainder = name[:i], name[i+1:]
+	if domain == legacyDefaultDomain {
+		domain = DefaultDomain
+	}
+	return domain, remainder
+}
@@ -0,0 +1,100 @@
+package reference
+
+import "testing"
+
+func TestParseNormalized(t *testing.T) {
+	for _, testcase := range []struct {
+		Input      string
+		Normalized string
+		Familiar   string
+		Err        error
+	}{
+		{
+			Input:      "ubuntu",
+			Normalized: "docker.io/library/ubuntu:latest",
+			Familiar:   "ubuntu:latest",
+		},
+		{
+			Input:      "ubuntu:16.04",
+			Normalized: "docker.io/library/ubuntu:16.04",
+			Familiar:   "ubuntu:16.04",
+		},
+		{
+			Input:      "myorg/app",
+			Normalized: "docker.io/myorg/app:latest",
+			Familiar:   "myorg/app:latest",
+		},
+		{
+			Input:      "myorg/team/app:1.0",
+			Normalized: "docker.io/myorg/team/app:1.0",
+			Familiar:   "myorg/team/app:1.0",
+		},
+		{
+			Input:      "library/ubuntu@sha256:abcdef",
+			Normalized: "docker.io/library/ubuntu@sha256:abcdef",
+			Familiar:   "ubuntu@sha256:abcdef",
+		},
+		{
+			Input:      "ubuntu:16.04@sha256:abcdef",
+			Normalized: "docker.io/library/ubuntu:16.04@sha256:abcdef",
+			Familiar:   "ubuntu:16.04@sha256:abcdef",
+		},
+		{
+			Input:      "index.docker.io/ubuntu",
+			Normalized: "docker.io/library/ubuntu:latest",
+			Familiar:   "ubuntu:latest",
+		},
+		{
+			Input:      "docker.io/library/ubuntu:latest",
+			Normalized: "docker.io/library/ubuntu:latest",
+			Familiar:   "ubuntu:latest",
+		},
+		{
+			Input:      "docker.io/library/sub/app",
+			Normalized: "docker.io/library/sub/app:latest",
+			Familiar:   "library/sub/app:latest",
+		},
+		{
+			Input:      "localhost/app",
+			Normalized: "localhost/app:latest",
+			Familiar:   "localhost/app:latest",
+		},
+		{
+			Input:      "localhost:5000/app:1.0",
+			Normalized: "localhost:5000/app:1.0",
+			Familiar:   "localhost:5000/app:1.0",
+		},
+		{
+			Input:      "registry.example.com/app",
+			Normalized: "registry.example.com/app:latest",
+			Familiar:   "registry.example.com/app:latest",
+		},
+		{
+			Input: "",
+			Err:   ErrInvalid,
+		},
+		{
+			Input: ":latest",
+			Err:   ErrInvalid,
+		},
+	} {
+		t.Run(testcase.Input, func(t *testing.T) {
+			normalized, err := Normalize(testcase.Input)
+			if err != testcase.Err {
+				t.Fatalf("unexpected error for %q: %v, expected %v", testcase.Input, err, testcase.Err)
+			}
+			if testcase.Err != nil {
+				return
+			}
+			if normalized != testcase.Normalized {
+				t.Fatalf("normalization failed: %v != %v", normalized, testcase.Normalized)
+			}
+			if familiar := FamiliarString(normalized); familiar != testcase.Familiar {
+				t.Fatalf("familiar form failed: %v != %v", familiar, testcase.Familiar)
+			}
+			if renormalized, err := Normalize(testcase.Familiar); err != nil || renormalized != normalized {
+				t.Fatalf("familiar form %v does not normalize to %v: %v, %v", testcase.Familiar, normalized, renormalized, err)
+			}
+		})
+	}
+}
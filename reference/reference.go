@@ -105,7 +105,7 @@ func (r Spec) Hostname() string {
 	i := strings.Index(r.Locator, "/")
 
 	if i < 0 {
-		i = len(r.Locator) + 1
+		i = len(r.Locator)
 	}
 	return r.Locator[:i]
 }
@@ -0,0 +1,128 @@
+package remotes
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// minBandwidthBurst is the smallest number of bytes which may be transferred
+// at once, regardless of the rate.
+const minBandwidthBurst = 32 << 10
+
+// BandwidthLimiter limits the combined rate of all fetch and push streams
+// sharing it.
+type BandwidthLimiter struct {
+	rate  float64 // bytes per second
+	burst int
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewBandwidthLimiter returns a limiter which allows bytesPerSecond bytes to
+// be transferred each second across all streams using it.
+func NewBandwidthLimiter(bytesPerSecond int64) *BandwidthLimiter {
+	burst := int(bytesPerSecond)
+	if burst < minBandwidthBurst {
+		burst = minBandwidthBurst
+	}
+	return &BandwidthLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until n bytes may be transferred. The bytes are accounted for
+// immediately, so concurrent callers queue up behind each other.
+func (l *BandwidthLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Reader returns a reader which reads from r at the rate of the limiter.
+func (l *BandwidthLimiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	return &limitedReader{
+		ctx:     ctx,
+		r:       r,
+		limiter: l,
+	}
+}
+
+type limitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *BandwidthLimiter
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if len(p) > lr.limiter.burst {
+		p = p[:lr.limiter.burst]
+	}
+	n, err := lr.r.Read(p)
+	if n > 0 {
+		if werr := lr.limiter.wait(lr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// LimitFetcher returns a fetcher which reads content fetched by f at the rate
+// of the limiter.
+func LimitFetcher(f Fetcher, l *BandwidthLimiter) Fetcher {
+	return FetcherFunc(func(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+		rc, err := f.Fetch(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		return limitedReadCloser{
+			Reader: l.Reader(ctx, rc),
+			Closer: rc,
+		}, nil
+	})
+}
+
+// LimitPusher returns a pusher which provides content to p at the rate of
+// the limiter.
+func LimitPusher(p Pusher, l *BandwidthLimiter) Pusher {
+	return PusherFunc(func(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+		return p.Push(ctx, desc, l.Reader(ctx, r))
+	})
+}
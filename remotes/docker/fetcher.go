@@ -4,18 +4,22 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/log"
+	digest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context/ctxhttp"
 )
 
 type dockerFetcher struct {
 	*dockerBase
+	foreignHosts []string
 }
 
 func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
@@ -26,6 +30,16 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 		},
 	))
 
+	if images.IsForeignLayer(desc.MediaType) {
+		rc, err := r.fetchForeign(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		if rc != nil {
+			return rc, nil
+		}
+	}
+
 	paths, err := getV2URLPaths(desc)
 	if err != nil {
 		return nil, err
@@ -53,12 +67,78 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 			return nil, errors.Errorf("unexpected status code %v: %v", u, resp.Status)
 		}
 
+		if strings.HasPrefix(path, "blobs/") && r.sources != nil {
+			return &sourceRecorder{
+				ReadCloser: resp.Body,
+				ctx:        ctx,
+				base:       r.dockerBase,
+				dgst:       desc.Digest,
+			}, nil
+		}
+
 		return resp.Body, nil
 	}
 
 	return nil, errors.New("not found")
 }
 
+// fetchForeign fetches a foreign layer from the URLs of its descriptor on
+// allowed hosts, in order. If no URL is allowed or none could be fetched, nil
+// is returned so that the layer is fetched from the registry instead.
+func (r dockerFetcher) fetchForeign(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	for _, us := range desc.URLs {
+		u, err := url.Parse(us)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			log.G(ctx).WithField("url", us).Debug("skipping invalid foreign layer url")
+			continue
+		}
+		if !hostAllowed(u.Hostname(), r.foreignHosts) {
+			log.G(ctx).WithField("url", us).Debug("foreign layer host not allowed")
+			continue
+		}
+
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Registry credentials are not sent to foreign hosts.
+		log.G(ctx).WithField("url", us).Debug("fetching foreign layer")
+		resp, err := ctxhttp.Do(ctx, r.client, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.G(ctx).WithError(err).WithField("url", us).Warn("failed to fetch foreign layer")
+			continue
+		}
+		if resp.StatusCode > 299 {
+			resp.Body.Close()
+			log.G(ctx).WithField("url", us).WithField("status", resp.Status).Warn("failed to fetch foreign layer")
+			continue
+		}
+
+		return resp.Body, nil
+	}
+
+	return nil, nil
+}
+
+// hostAllowed returns true if host matches one of the allowed hosts. A
+// leading "*." matches any subdomain.
+func hostAllowed(host string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.HasPrefix(a, "*.") {
+			if strings.HasSuffix(host, a[1:]) {
+				return true
+			}
+		} else if host == a {
+			return true
+		}
+	}
+	return false
+}
+
 // getV2URLPaths generates the candidate urls paths for the object based on the
 // set of hints and the provided object id. URLs are returned in the order of
 // most to least likely succeed.
@@ -76,3 +156,30 @@ func getV2URLPaths(desc ocispec.Descriptor) ([]string, error) {
 
 	return urls, nil
 }
+
+// sourceRecorder records the repository of the fetch as a source of the blob
+// when it is closed after having been read completely. By then, the blob has
+// been committed by the caller.
+type sourceRecorder struct {
+	io.ReadCloser
+	ctx  context.Context
+	base *dockerBase
+	dgst digest.Digest
+	eof  bool
+}
+
+func (s *sourceRecorder) Read(p []byte) (int, error) {
+	n, err := s.ReadCloser.Read(p)
+	if err == io.EOF {
+		s.eof = true
+	}
+	return n, err
+}
+
+func (s *sourceRecorder) Close() error {
+	err := s.ReadCloser.Close()
+	if s.eof {
+		s.base.addSource(s.ctx, s.dgst)
+	}
+	return err
+}
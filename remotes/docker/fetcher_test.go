@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/containerd/containerd/images"
+)
+
+func TestFetchForeignLayer(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		layer    = newContent(images.MediaTypeDockerSchema2LayerForeignGzip, []byte("foreign"))
+		foreign  = httptest.NewServer(logHandler{t, layer})
+		registry = httptest.NewServer(logHandler{t, http.NotFoundHandler()})
+		desc     = layer.Descriptor()
+	)
+	defer foreign.Close()
+	defer registry.Close()
+
+	u, err := url.Parse(foreign.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc.URLs = []string{"ftp://invalid/layer", foreign.URL + "/layer"}
+	ref := registry.URL[7:] + "/testname:latest" // strip "http://"
+
+	for _, tc := range []struct {
+		allowed []string
+		found   bool
+	}{
+		{nil, false},
+		{[]string{"example.com"}, false},
+		{[]string{u.Hostname()}, true},
+	} {
+		resolver := NewResolver(ResolverOptions{
+			PlainHTTP:    true,
+			ForeignHosts: tc.allowed,
+		})
+		f, err := resolver.Fetcher(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rc, err := f.Fetch(ctx, desc)
+		if !tc.found {
+			if err == nil {
+				rc.Close()
+				t.Fatalf("%v: expected fetch to fall back to the registry and fail", tc.allowed)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: %v", tc.allowed, err)
+		}
+		p, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p, layer.content) {
+			t.Fatal("fetched content mismatch")
+		}
+	}
+}
+
+func TestPushSkipsForeignLayer(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		layer = newContent(images.MediaTypeDockerSchema2LayerForeignGzip, []byte("foreign"))
+		reg   = newTestRegistry(t)
+		s     = httptest.NewServer(reg)
+		base  = s.URL[7:] // strip "http://"
+	)
+	defer s.Close()
+
+	for _, force := range []bool{false, true} {
+		resolver := NewResolver(ResolverOptions{
+			PlainHTTP:         true,
+			PushForeignLayers: force,
+		})
+		p, err := resolver.Pusher(ctx, base+"/target:latest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Push(ctx, layer.Descriptor(), bytes.NewReader(layer.content)); err != nil {
+			t.Fatal(err)
+		}
+		_, pushed := reg.blobs["target"][layer.Digest().String()]
+		if pushed != force {
+			t.Fatalf("unexpected push of foreign layer with force %v", force)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	"github.com/pkg/errors"
+)
+
+var _ remotes.Lister = &dockerResolver{}
+
+type tagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type catalogResponse struct {
+	Repositories []string `json:"repositories"`
+}
+
+func (r *dockerResolver) Tags(ctx context.Context, ref string) ([]string, error) {
+	refspec, err := reference.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := r.base(refspec)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if err := base.list(ctx, base.url("tags", "list"), func(dec *json.Decoder) error {
+		var tr tagsResponse
+		if err := dec.Decode(&tr); err != nil {
+			return errors.Wrap(err, "unable to decode tags response")
+		}
+		tags = append(tags, tr.Tags...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *dockerResolver) Catalog(ctx context.Context, host string) ([]string, error) {
+	refspec, err := reference.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	if refspec.Locator != refspec.Hostname() {
+		return nil, errors.Errorf("catalog requires a host, got %q", host)
+	}
+
+	base, err := r.base(refspec)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	if err := base.list(ctx, base.url("_catalog"), func(dec *json.Decoder) error {
+		var cr catalogResponse
+		if err := dec.Decode(&cr); err != nil {
+			return errors.Wrap(err, "unable to decode catalog response")
+		}
+		for _, repo := range cr.Repositories {
+			repos = append(repos, path.Join(refspec.Hostname(), repo))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+// list requests each page of a paginated listing, starting at u and following
+// the "next" links returned by the registry. The body of each page is passed
+// to fn for decoding.
+func (r *dockerBase) list(ctx context.Context, u string, fn func(*json.Decoder) error) error {
+	for u != "" {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		log.G(ctx).Debug("listing")
+		resp, err := r.doRequestWithRetries(ctx, req, nil)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return errors.Errorf("unexpected status code %v: %v", u, resp.Status)
+		}
+
+		err = fn(json.NewDecoder(resp.Body))
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		u, err = r.nextLink(resp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextLink returns the url of the next page from the Link header of the
+// response, or an empty string if this was the last page.
+func (r *dockerBase) nextLink(resp *http.Response) (string, error) {
+	for _, link := range resp.Header[http.CanonicalHeaderKey("Link")] {
+		for _, part := range strings.Split(link, ",") {
+			fields := strings.Split(part, ";")
+			target := strings.TrimSpace(fields[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			var next bool
+			for _, param := range fields[1:] {
+				param = strings.Replace(strings.TrimSpace(param), `"`, "", -1)
+				if param == "rel=next" {
+					next = true
+				}
+			}
+			if !next {
+				continue
+			}
+
+			u, err := url.Parse(target[1 : len(target)-1])
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid link header: %q", link)
+			}
+			return resp.Request.URL.ResolveReference(u).String(), nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListTags(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		pages = [][]string{{"1.0", "1.1"}, {"2.0"}}
+		mux   = http.NewServeMux()
+	)
+
+	mux.HandleFunc("/v2/testname/tags/list", func(rw http.ResponseWriter, r *http.Request) {
+		page := pages[0]
+		if r.URL.Query().Get("last") != "" {
+			page = pages[1]
+		} else {
+			rw.Header().Set("Link", `</v2/testname/tags/list?n=2&last=1.1>; rel="next"`)
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(tagsResponse{Name: "testname", Tags: page})
+	})
+
+	s := httptest.NewServer(logHandler{t, mux})
+	defer s.Close()
+	base := s.URL[7:] // strip "http://"
+
+	resolver := NewResolver(ResolverOptions{PlainHTTP: true})
+	tags, err := resolver.(*dockerResolver).Tags(ctx, base+"/testname:ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"1.0", "1.1", "2.0"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("unexpected tags: %v, expected %v", tags, expected)
+	}
+}
+
+func TestListCatalog(t *testing.T) {
+	var (
+		ctx = context.Background()
+		mux = http.NewServeMux()
+	)
+
+	mux.HandleFunc("/v2/_catalog", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(catalogResponse{Repositories: []string{"library/ubuntu", "myorg/app"}})
+	})
+
+	s := httptest.NewServer(logHandler{t, mux})
+	defer s.Close()
+	base := s.URL[7:] // strip "http://"
+
+	resolver := NewResolver(ResolverOptions{PlainHTTP: true})
+	repos, err := resolver.(*dockerResolver).Catalog(ctx, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{base + "/library/ubuntu", base + "/myorg/app"}; !reflect.DeepEqual(repos, expected) {
+		t.Fatalf("unexpected repositories: %v, expected %v", repos, expected)
+	}
+}
@@ -3,24 +3,41 @@ package docker
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
+	"time"
 
+	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/remotes"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
+// maxChunkAttempts is the number of times a chunk is sent before the upload
+// is abandoned.
+const maxChunkAttempts = 5
+
 type dockerPusher struct {
 	*dockerBase
-	tag string
+	tag       string
+	tracker   StatusTracker
+	chunkSize int64
+	foreign   bool
 }
 
 func (p dockerPusher) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	if images.IsForeignLayer(desc.MediaType) && !p.foreign {
+		log.G(ctx).Debug("skipping foreign layer")
+		return nil
+	}
+
 	var (
 		isManifest bool
 		existCheck string
@@ -49,6 +66,9 @@ func (p dockerPusher) Push(ctx context.Context, desc ocispec.Descriptor, r io.Re
 		log.G(ctx).WithError(err).Debugf("Unable to check existence, continuing with push")
 	} else {
 		if resp.StatusCode == http.StatusOK {
+			if !isManifest {
+				p.addSource(ctx, desc.Digest)
+			}
 			return nil
 		}
 		if resp.StatusCode != http.StatusNotFound {
@@ -57,8 +77,6 @@ func (p dockerPusher) Push(ctx context.Context, desc ocispec.Descriptor, r io.Re
 		}
 	}
 
-	// TODO: Lookup related objects for cross repository push
-
 	if isManifest {
 		// Read all to use bytes.Reader for using GetBody
 		b, err := ioutil.ReadAll(r)
@@ -92,53 +110,365 @@ func (p dockerPusher) Push(ctx context.Context, desc ocispec.Descriptor, r io.Re
 			return errors.Errorf("unexpected response: %s", resp.Status)
 		}
 	} else {
-		// TODO: Do monolithic upload if size is small
+		return p.pushBlob(ctx, desc, r)
+	}
 
-		// TODO: Turn multi-request blob uploader into ingester
+	return nil
+}
 
-		// Start upload request
-		req, err := http.NewRequest(http.MethodPost, p.url("blobs", "uploads")+"/", nil)
-		if err != nil {
-			return err
+// pushBlob uploads a blob which does not yet exist in the repository.
+func (p dockerPusher) pushBlob(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	// TODO: Turn multi-request blob uploader into ingester
+
+	var (
+		ref     = remotes.MakeRefKey(ctx, desc)
+		chunked = p.chunkSize > 0 && desc.Size > p.chunkSize
+		status  = Status{
+			Status: content.Status{
+				Ref:       ref,
+				Total:     desc.Size,
+				Expected:  desc.Digest,
+				StartedAt: time.Now(),
+			},
 		}
+		location string
+		err      error
+	)
 
-		resp, err := p.doRequestWithRetries(ctx, req, nil)
+	if chunked {
+		location, status.Offset = p.resumeUpload(ctx, ref)
+	}
+	if location == "" {
+		location, err = p.startUpload(ctx, desc)
 		if err != nil {
 			return err
 		}
-		if resp.StatusCode != http.StatusAccepted {
-			// TODO: log error
-			return errors.Errorf("unexpected response: %s", resp.Status)
+		if location == "" {
+			// blob was mounted from another repository
+			p.addSource(ctx, desc.Digest)
+			status.Offset = desc.Size
+			p.setStatus(status)
+			return nil
 		}
-
-		location := resp.Header.Get("Location")
-		// Support paths without host in location
-		if strings.HasPrefix(location, "/") {
-			u := p.base
-			u.Path = location
-			location = u.String()
+	} else if status.Offset > 0 {
+		log.G(ctx).WithField("offset", status.Offset).Debug("resuming upload")
+		if err := skipReader(r, status.Offset); err != nil {
+			return errors.Wrap(err, "failed to resume upload")
 		}
+	}
+
+	status.UploadLocation = location
+	p.setStatus(status)
 
-		// TODO: Support chunked upload
-		req, err = http.NewRequest(http.MethodPut, location, r)
+	if chunked {
+		location, err = p.pushChunks(ctx, status, r)
 		if err != nil {
 			return err
 		}
-		q := req.URL.Query()
-		q.Add("digest", desc.Digest.String())
-		req.URL.RawQuery = q.Encode()
+		r = nil
+	} else {
+		r = &progressReader{
+			Reader:  r,
+			tracker: p.tracker,
+			status:  status,
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPut, location, r)
+	if err != nil {
+		return err
+	}
+	q := req.URL.Query()
+	q.Add("digest", desc.Digest.String())
+	req.URL.RawQuery = q.Encode()
+	if !chunked {
 		req.ContentLength = desc.Size
+	}
+
+	resp, err := p.doRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		// TODO: log error
+		return errors.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	p.addSource(ctx, desc.Digest)
+
+	status.Offset = desc.Size
+	status.UploadLocation = ""
+	p.setStatus(status)
 
-		resp, err = p.doRequest(ctx, req)
+	return nil
+}
+
+// pushChunks sends the remainder of the blob to the upload session in chunks
+// of at most chunkSize bytes, starting from the status offset. The location
+// to commit the upload to is returned.
+func (p dockerPusher) pushChunks(ctx context.Context, status Status, r io.Reader) (string, error) {
+	var (
+		location = status.UploadLocation
+		buf      = make([]byte, p.chunkSize)
+		err      error
+	)
+
+	for status.Offset < status.Total {
+		n := status.Total - status.Offset
+		if n > p.chunkSize {
+			n = p.chunkSize
+		}
+		if _, err := io.ReadFull(r, buf[:n]); err != nil {
+			return "", errors.Wrap(err, "failed to read blob")
+		}
+
+		location, err = p.uploadChunk(ctx, location, status.Offset, buf[:n])
 		if err != nil {
-			return err
+			return "", err
 		}
-		if resp.StatusCode != http.StatusCreated {
-			// TODO: log error
-			return errors.Errorf("unexpected response: %s", resp.Status)
+
+		status.Offset += n
+		status.UploadLocation = location
+		p.setStatus(status)
+	}
+
+	return location, nil
+}
+
+// uploadChunk sends a chunk starting at offset to the upload session. If the
+// request fails, the upload status is queried and the part of the chunk not
+// acknowledged by the registry is sent again. The location of the upload
+// session for the next request is returned.
+func (p dockerPusher) uploadChunk(ctx context.Context, location string, offset int64, chunk []byte) (string, error) {
+	end := offset + int64(len(chunk))
+
+	for attempt := 1; ; attempt++ {
+		next, err := p.patchChunk(ctx, location, offset, chunk)
+		if err == nil {
+			return next, nil
+		}
+		if attempt >= maxChunkAttempts {
+			return "", err
 		}
 
+		log.G(ctx).WithError(err).WithField("offset", offset).Debug("chunk upload failed, querying upload status")
+
+		if err := waitRetry(ctx, p.retry.backoff(attempt)); err != nil {
+			return "", err
+		}
+
+		next, acked, serr := p.uploadStatus(ctx, location)
+		if serr != nil {
+			log.G(ctx).WithError(serr).Debug("failed to query upload status")
+			continue
+		}
+		if acked < offset || acked > end {
+			return "", errors.Wrapf(err, "upload status offset %d outside of chunk %d-%d", acked, offset, end)
+		}
+		if acked == end {
+			return next, nil
+		}
+
+		chunk = chunk[acked-offset:]
+		offset = acked
+		location = next
 	}
+}
 
-	return nil
+// patchChunk sends a single chunk starting at offset with a PATCH request.
+func (p dockerPusher) patchChunk(ctx context.Context, location string, offset int64, chunk []byte) (string, error) {
+	req, err := http.NewRequest(http.MethodPatch, location, nil)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(len(chunk))
+	req.Body = ioutil.NopCloser(bytes.NewReader(chunk))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(chunk)), nil
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(len(chunk))-1))
+
+	resp, err := p.doRequestWithRetries(ctx, req, nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return "", errors.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return p.uploadLocation(resp, location), nil
+}
+
+// uploadStatus queries the upload session at location and returns the
+// location for the next request and the number of bytes acknowledged by the
+// registry.
+func (p dockerPusher) uploadStatus(ctx context.Context, location string) (string, int64, error) {
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return "", 0, err
+	}
+
+	resp, err := p.doRequestWithRetries(ctx, req, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return "", 0, errors.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	// The range is inclusive of the last byte received, a missing range
+	// means nothing has been received yet.
+	var offset int64
+	if rng := resp.Header.Get("Range"); rng != "" {
+		var start, last int64
+		if _, err := fmt.Sscanf(rng, "%d-%d", &start, &last); err != nil {
+			return "", 0, errors.Wrapf(err, "invalid range header: %q", rng)
+		}
+		offset = last + 1
+	}
+
+	return p.uploadLocation(resp, location), offset, nil
+}
+
+// resumeUpload returns the location and offset of a previous upload session
+// for ref which is still active on the registry. If there is no such session,
+// an empty location is returned.
+func (p dockerPusher) resumeUpload(ctx context.Context, ref string) (string, int64) {
+	status, err := p.tracker.GetStatus(ref)
+	if err != nil || status.UploadLocation == "" {
+		return "", 0
+	}
+
+	// the status may have been saved by a push to another repository
+	u, err := url.Parse(status.UploadLocation)
+	if err != nil || u.Host != p.base.Host || !strings.HasPrefix(u.Path, p.base.Path+"/blobs/uploads/") {
+		return "", 0
+	}
+
+	location, offset, err := p.uploadStatus(ctx, status.UploadLocation)
+	if err != nil {
+		log.G(ctx).WithError(err).Debug("unable to resume upload, starting over")
+		return "", 0
+	}
+
+	return location, offset
+}
+
+func (p dockerPusher) setStatus(status Status) {
+	status.UpdatedAt = time.Now()
+	p.tracker.SetStatus(status.Ref, status)
+}
+
+// uploadLocation returns the upload session location from the response,
+// falling back to the current location if the registry did not return one.
+func (p dockerPusher) uploadLocation(resp *http.Response, current string) string {
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return current
+	}
+	// Support paths without host in location
+	if strings.HasPrefix(location, "/") {
+		u := p.base
+		u.Path = location
+		location = u.String()
+	}
+	return location
+}
+
+// startUpload starts a blob upload session and returns its location. When a
+// source repository on the same host is known for the blob, the registry is
+// asked to mount the blob from there instead. If the mount succeeds, no
+// session is started and an empty location is returned. A refused mount
+// falls back to the upload session started by the registry.
+func (p dockerPusher) startUpload(ctx context.Context, desc ocispec.Descriptor) (string, error) {
+	u := p.url("blobs", "uploads") + "/"
+
+	from := p.mountSource(ctx, desc)
+	if from != "" {
+		q := url.Values{}
+		q.Set("mount", desc.Digest.String())
+		q.Set("from", from)
+		u = u + "?" + q.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := p.doRequestWithRetries(ctx, req, nil)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		if from != "" {
+			log.G(ctx).WithField("from", from).Debug("blob mounted")
+			return "", nil
+		}
+	case http.StatusAccepted:
+		if from != "" {
+			log.G(ctx).WithField("from", from).Debug("blob mount refused, uploading")
+		}
+
+		return p.uploadLocation(resp, ""), nil
+	}
+
+	// TODO: log error
+	return "", errors.Errorf("unexpected response: %s", resp.Status)
+}
+
+// mountSource returns a repository on the same host which is known to hold
+// the blob, or an empty string if there is none.
+func (p dockerPusher) mountSource(ctx context.Context, desc ocispec.Descriptor) string {
+	if p.sources == nil {
+		return ""
+	}
+
+	repos, err := p.sources.Sources(ctx, desc.Digest, p.base.Host)
+	if err != nil {
+		log.G(ctx).WithError(err).Warn("failed to lookup blob sources")
+		return ""
+	}
+
+	for _, repo := range repos {
+		if repo != p.repository {
+			return repo
+		}
+	}
+
+	return ""
+}
+
+// progressReader updates the upload status as the blob is read.
+type progressReader struct {
+	io.Reader
+	tracker StatusTracker
+	status  Status
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.Reader.Read(b)
+	if n > 0 {
+		pr.status.Offset += int64(n)
+		pr.status.UpdatedAt = time.Now()
+		pr.tracker.SetStatus(pr.status.Ref, pr.status)
+	}
+	return n, err
+}
+
+// skipReader advances the reader by n bytes.
+func skipReader(r io.Reader, n int64) error {
+	if rs, ok := r.(io.Seeker); ok {
+		_, err := rs.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(ioutil.Discard, r, n)
+	return err
 }
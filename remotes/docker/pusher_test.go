@@ -0,0 +1,431 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// testRegistry is a minimal registry which holds blobs for a set of
+// repositories and supports monolithic and chunked uploads and cross
+// repository mounts.
+type testRegistry struct {
+	t       *testing.T
+	blobs   map[string]map[string][]byte
+	mounts  int
+	uploads int
+
+	// session holds the data received by chunked upload requests
+	session []byte
+	patches int
+	// dropPatch is the patch request after which the connection is
+	// dropped when half of the chunk has been received
+	dropPatch int
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	return &testRegistry{
+		t:     t,
+		blobs: make(map[string]map[string][]byte),
+	}
+}
+
+func (tr *testRegistry) put(repo, dgst string, p []byte) {
+	if tr.blobs[repo] == nil {
+		tr.blobs[repo] = make(map[string][]byte)
+	}
+	tr.blobs[repo][dgst] = p
+}
+
+func (tr *testRegistry) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	tr.t.Logf("%s %s", r.Method, r.URL.String())
+
+	switch {
+	case r.Method == http.MethodHead:
+		for name, blobs := range tr.blobs {
+			for d := range blobs {
+				if r.URL.Path == fmt.Sprintf("/v2/%s/blobs/%s", name, d) {
+					rw.WriteHeader(http.StatusOK)
+					return
+				}
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && !strings.Contains(r.URL.Path, "/uploads/"):
+		for name, blobs := range tr.blobs {
+			for d, p := range blobs {
+				if r.URL.Path == fmt.Sprintf("/v2/%s/blobs/%s", name, d) {
+					rw.Write(p)
+					return
+				}
+			}
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/target/blobs/uploads/":
+		if from, mount := r.URL.Query().Get("from"), r.URL.Query().Get("mount"); mount != "" {
+			if p, ok := tr.blobs[from][mount]; ok {
+				tr.mounts++
+				tr.put("target", mount, p)
+				rw.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		rw.Header().Set("Location", "/v2/target/blobs/uploads/session")
+		rw.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPatch && r.URL.Path == "/v2/target/blobs/uploads/session":
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d", &start, &end); err != nil || start != len(tr.session) {
+			rw.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		tr.patches++
+		if tr.patches == tr.dropPatch {
+			half := make([]byte, (end-start+1)/2)
+			io.ReadFull(r.Body, half)
+			tr.session = append(tr.session, half...)
+			conn, _, err := rw.(http.Hijacker).Hijack()
+			if err != nil {
+				tr.t.Fatal(err)
+			}
+			conn.Close()
+			return
+		}
+		p, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.session = append(tr.session, p...)
+		rw.Header().Set("Location", "/v2/target/blobs/uploads/session")
+		rw.Header().Set("Range", fmt.Sprintf("0-%d", len(tr.session)-1))
+		rw.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/target/blobs/uploads/session":
+		rw.Header().Set("Location", "/v2/target/blobs/uploads/session")
+		if len(tr.session) > 0 {
+			rw.Header().Set("Range", fmt.Sprintf("0-%d", len(tr.session)-1))
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPut && r.URL.Path == "/v2/target/blobs/uploads/session":
+		dgst := r.URL.Query().Get("digest")
+		p, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr.uploads++
+		tr.put("target", dgst, append(tr.session, p...))
+		tr.session = nil
+		rw.WriteHeader(http.StatusCreated)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPushMount(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		layer   = newContent(ocispec.MediaTypeImageLayerGzip, []byte("layer"))
+		desc    = layer.Descriptor()
+		reg     = newTestRegistry(t)
+		s       = httptest.NewServer(reg)
+		base    = s.URL[7:] // strip "http://"
+		sources = NewInMemorySourceTracker()
+	)
+	defer s.Close()
+
+	reg.put("base", desc.Digest.String(), layer.content)
+	if err := sources.AddSource(ctx, desc.Digest, base, "base"); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewResolver(ResolverOptions{
+		PlainHTTP: true,
+		Sources:   sources,
+	})
+	p, err := resolver.Pusher(ctx, base+"/target:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Push(ctx, desc, bytes.NewReader(layer.content)); err != nil {
+		t.Fatal(err)
+	}
+	if reg.mounts != 1 || reg.uploads != 0 {
+		t.Fatalf("expected blob to be mounted: mounts=%d uploads=%d", reg.mounts, reg.uploads)
+	}
+
+	repos, err := sources.Sources(ctx, desc.Digest, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 || repos[0] != "target" {
+		t.Fatalf("unexpected sources after push: %v", repos)
+	}
+}
+
+func TestPushMountFetchedSource(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		layer = newContent(ocispec.MediaTypeImageLayerGzip, []byte("layer"))
+		desc  = layer.Descriptor()
+		reg   = newTestRegistry(t)
+		s     = httptest.NewServer(reg)
+		base  = s.URL[7:] // strip "http://"
+	)
+	defer s.Close()
+
+	root, err := ioutil.TempDir("", "docker-sources-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	cs, err := content.NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg.put("base", desc.Digest.String(), layer.content)
+
+	fetcher, err := NewResolver(ResolverOptions{
+		PlainHTTP: true,
+		Sources:   NewContentSourceTracker(cs),
+	}).Fetcher(ctx, base+"/base:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := remotes.FetchHandler(cs, fetcher)(ctx, desc); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := cs.Info(ctx, desc.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source := info.Labels[labelSourcePrefix+base]; source != "base" {
+		t.Fatalf("unexpected source label after fetch: %q", source)
+	}
+
+	// the source is found from the content store by a new tracker
+	p, err := NewResolver(ResolverOptions{
+		PlainHTTP: true,
+		Sources:   NewContentSourceTracker(cs),
+	}).Pusher(ctx, base+"/target:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Push(ctx, desc, bytes.NewReader(layer.content)); err != nil {
+		t.Fatal(err)
+	}
+	if reg.mounts != 1 || reg.uploads != 0 {
+		t.Fatalf("expected blob to be mounted: mounts=%d uploads=%d", reg.mounts, reg.uploads)
+	}
+
+	info, err = cs.Info(ctx, desc.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source := info.Labels[labelSourcePrefix+base]; source != "target,base" {
+		t.Fatalf("unexpected source label after push: %q", source)
+	}
+}
+
+func TestContentSourceTrackerConcurrent(t *testing.T) {
+	ctx := context.Background()
+	root, err := ioutil.TempDir("", "docker-sources-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	cs, err := content.NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer := newContent(ocispec.MediaTypeImageLayerGzip, []byte("layer"))
+	desc := layer.Descriptor()
+	if err := content.WriteBlob(ctx, cs, "layer", bytes.NewReader(layer.content), desc.Size, desc.Digest); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2*maxSources; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// each tracker reads and updates the labels of the blob
+			if err := NewContentSourceTracker(cs).AddSource(ctx, desc.Digest, "example.com", fmt.Sprintf("repo-%d", i%maxSources)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	repos, err := NewContentSourceTracker(cs).Sources(ctx, desc.Digest, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != maxSources {
+		t.Fatalf("expected %d sources without updates lost, got %v", maxSources, repos)
+	}
+
+	if err := NewContentSourceTracker(cs).AddSource(ctx, desc.Digest, "example.com", "other"); err != nil {
+		t.Fatal(err)
+	}
+	repos, err = NewContentSourceTracker(cs).Sources(ctx, desc.Digest, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != maxSources || repos[0] != "other" {
+		t.Fatalf("expected sources capped to %d with the latest first, got %v", maxSources, repos)
+	}
+}
+
+func TestPushMountRefused(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		layer   = newContent(ocispec.MediaTypeImageLayerGzip, []byte("layer"))
+		desc    = layer.Descriptor()
+		reg     = newTestRegistry(t)
+		s       = httptest.NewServer(reg)
+		base    = s.URL[7:] // strip "http://"
+		sources = NewInMemorySourceTracker()
+	)
+	defer s.Close()
+
+	// the source is recorded but the registry does not hold the blob there
+	if err := sources.AddSource(ctx, desc.Digest, base, "base"); err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewResolver(ResolverOptions{
+		PlainHTTP: true,
+		Sources:   sources,
+	})
+	p, err := resolver.Pusher(ctx, base+"/target:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Push(ctx, desc, bytes.NewReader(layer.content)); err != nil {
+		t.Fatal(err)
+	}
+	if reg.mounts != 0 || reg.uploads != 1 {
+		t.Fatalf("expected blob to be uploaded: mounts=%d uploads=%d", reg.mounts, reg.uploads)
+	}
+	if !bytes.Equal(reg.blobs["target"][desc.Digest.String()], layer.content) {
+		t.Fatal("uploaded content mismatch")
+	}
+}
+
+func TestPushChunkedResume(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		tracker = NewInMemoryTracker()
+		layer   = newContent(ocispec.MediaTypeImageLayerGzip, bytes.Repeat([]byte("0123456789"), 10))
+		desc    = layer.Descriptor()
+		reg     = newTestRegistry(t)
+		s       = httptest.NewServer(reg)
+		base    = s.URL[7:] // strip "http://"
+	)
+	defer s.Close()
+
+	reg.dropPatch = 2
+
+	resolver := NewResolver(ResolverOptions{
+		PlainHTTP: true,
+		Tracker:   tracker,
+		ChunkSize: 32,
+	})
+	p, err := resolver.Pusher(ctx, base+"/target:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Push(ctx, desc, bytes.NewReader(layer.content)); err != nil {
+		t.Fatal(err)
+	}
+	if reg.uploads != 1 {
+		t.Fatalf("expected a single upload, got %d", reg.uploads)
+	}
+	// 4 chunks, the second sent again partially after the dropped connection
+	if reg.patches != 5 {
+		t.Fatalf("unexpected number of chunks sent: %d", reg.patches)
+	}
+	if !bytes.Equal(reg.blobs["target"][desc.Digest.String()], layer.content) {
+		t.Fatal("uploaded content mismatch")
+	}
+
+	status, err := tracker.GetStatus(remotes.MakeRefKey(ctx, desc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Offset != desc.Size || status.UploadLocation != "" {
+		t.Fatalf("unexpected status after push: %+v", status)
+	}
+}
+
+func TestPushChunkedResumeFromFile(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		layer = newContent(ocispec.MediaTypeImageLayerGzip, bytes.Repeat([]byte("0123456789"), 10))
+		desc  = layer.Descriptor()
+		reg   = newTestRegistry(t)
+		s     = httptest.NewServer(reg)
+		base  = s.URL[7:] // strip "http://"
+	)
+	defer s.Close()
+
+	root, err := ioutil.TempDir("", "docker-status-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	push := func(r io.Reader) error {
+		p, err := NewResolver(ResolverOptions{
+			PlainHTTP: true,
+			Tracker:   NewFileTracker(root),
+			ChunkSize: 32,
+		}).Pusher(ctx, base+"/target:latest")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.Push(ctx, desc, r)
+	}
+
+	// the first push is interrupted while reading the second chunk
+	if err := push(io.LimitReader(bytes.NewReader(layer.content), 40)); err == nil {
+		t.Fatal("expected interrupted push to fail")
+	}
+	if reg.patches != 1 {
+		t.Fatalf("unexpected number of chunks sent: %d", reg.patches)
+	}
+
+	// a push with a new tracker resumes after the first chunk
+	if err := push(bytes.NewReader(layer.content)); err != nil {
+		t.Fatal(err)
+	}
+	if reg.uploads != 1 || reg.patches != 4 {
+		t.Fatalf("expected upload to be resumed: uploads=%d patches=%d", reg.uploads, reg.patches)
+	}
+	if !bytes.Equal(reg.blobs["target"][desc.Digest.String()], layer.content) {
+		t.Fatal("uploaded content mismatch")
+	}
+
+	// the status is removed once the upload is done
+	if _, err := NewFileTracker(root).GetStatus(remotes.MakeRefKey(ctx, desc)); !content.IsNotFound(err) {
+		t.Fatalf("expected status to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,588 @@
+// Package registry serves the content and images of containerd over the
+// Docker Registry HTTP API v2.
+//
+// The API is read-only. Manifests may be requested by tag or digest, blobs by
+// digest, and the tags of a repository may be listed. Content is streamed
+// directly from the content store. Only the manifests and blobs referenced by
+// the images of the requested repository are served.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/metadata"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/reference"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	errCodeBlobUnknown     = "BLOB_UNKNOWN"
+	errCodeManifestUnknown = "MANIFEST_UNKNOWN"
+	errCodeNameUnknown     = "NAME_UNKNOWN"
+	errCodeDigestInvalid   = "DIGEST_INVALID"
+	errCodeUnsupported     = "UNSUPPORTED"
+	errCodeUnknown         = "UNKNOWN"
+)
+
+// maxManifestSize bounds the size of the manifests and indexes read to find
+// the content referenced by a repository.
+const maxManifestSize = 4 << 20
+
+// The repositories are resolved from an index of the images, which is
+// rebuilt when it is older than indexMaxAge, or when a lookup misses and it
+// is older than indexMinAge. Images are thus listed at most once per
+// indexMinAge, while new images are found quickly.
+const (
+	indexMaxAge = 10 * time.Second
+	indexMinAge = time.Second
+)
+
+type handler struct {
+	namespace string
+	content   content.Store
+	images    images.Store
+
+	mu    sync.Mutex
+	index *repositoryIndex
+	// refs holds the content referenced by the manifests and indexes that
+	// are image targets, by target digest. As content is immutable, entries
+	// stay valid for as long as the target is part of an image.
+	refs map[digest.Digest]map[digest.Digest]ocispec.Descriptor
+}
+
+// NewHandler returns an http.Handler serving the images and content of the
+// namespace over the registry API.
+//
+// The repository name of a request is matched against the locator of the
+// image names, with or without the hostname. For example, the image
+// "docker.io/library/redis:latest" is served as both
+// "/v2/library/redis/manifests/latest" and
+// "/v2/docker.io/library/redis/manifests/latest".
+//
+// Manifests requested by tag are always resolved against the image store.
+// Repositories, as used to list tags and to check that blobs are referenced,
+// are resolved from an index of the images that may be up to 10 seconds old.
+func NewHandler(namespace string, cs content.Store, is images.Store) http.Handler {
+	return &handler{
+		namespace: namespace,
+		content:   cs,
+		images:    is,
+		refs:      map[digest.Digest]map[digest.Digest]ocispec.Descriptor{},
+	}
+}
+
+func (h *handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	ctx := namespaces.WithNamespace(r.Context(), h.namespace)
+	ctx = log.WithLogger(ctx, log.G(ctx).WithField("url", r.URL.String()))
+	log.G(ctx).WithField("method", r.Method).Debug("registry request")
+
+	rw.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(rw, http.StatusMethodNotAllowed, errCodeUnsupported, "registry is read-only")
+		return
+	}
+
+	p := r.URL.Path
+	if p == "/v2" || p == "/v2/" {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte("{}"))
+		return
+	}
+	if !strings.HasPrefix(p, "/v2/") {
+		writeError(rw, http.StatusNotFound, errCodeUnsupported, "unknown endpoint")
+		return
+	}
+	p = strings.TrimPrefix(p, "/v2/")
+
+	switch {
+	case strings.HasSuffix(p, "/tags/list"):
+		h.serveTags(ctx, rw, r, strings.TrimSuffix(p, "/tags/list"))
+	case strings.Contains(p, "/manifests/"):
+		i := strings.LastIndex(p, "/manifests/")
+		h.serveManifest(ctx, rw, r, p[:i], p[i+len("/manifests/"):])
+	case strings.Contains(p, "/blobs/"):
+		i := strings.LastIndex(p, "/blobs/")
+		dgst, err := digest.Parse(p[i+len("/blobs/"):])
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, errCodeDigestInvalid, err.Error())
+			return
+		}
+		h.serveRepositoryBlob(ctx, rw, r, p[:i], dgst)
+	default:
+		writeError(rw, http.StatusNotFound, errCodeUnsupported, "unknown endpoint")
+	}
+}
+
+func (h *handler) serveManifest(ctx context.Context, rw http.ResponseWriter, r *http.Request, name, ref string) {
+	var (
+		target ocispec.Descriptor
+		err    error
+		what   string
+	)
+	if dgst, perr := digest.Parse(ref); perr == nil {
+		target, err = h.lookup(ctx, name, func(imgs map[string]images.Image) (ocispec.Descriptor, error) {
+			desc, err := h.reference(ctx, imgs, dgst)
+			if err == nil && !isManifest(desc.MediaType) {
+				err = errors.Wrapf(content.ErrNotFound, "%v not a manifest", dgst)
+			}
+			return desc, err
+		})
+		what = dgst.String()
+	} else {
+		target, err = h.tag(ctx, name, ref)
+		what = name + ":" + ref
+	}
+	if err != nil {
+		h.writeLookupError(ctx, rw, err, name, errCodeManifestUnknown, fmt.Sprintf("manifest %v unknown", what))
+		return
+	}
+
+	h.serveBlob(ctx, rw, r, target.Digest, target.MediaType)
+}
+
+func (h *handler) serveRepositoryBlob(ctx context.Context, rw http.ResponseWriter, r *http.Request, name string, dgst digest.Digest) {
+	if _, err := h.lookup(ctx, name, func(imgs map[string]images.Image) (ocispec.Descriptor, error) {
+		return h.reference(ctx, imgs, dgst)
+	}); err != nil {
+		h.writeLookupError(ctx, rw, err, name, errCodeBlobUnknown, fmt.Sprintf("blob %v unknown", dgst))
+		return
+	}
+
+	h.serveBlob(ctx, rw, r, dgst, "application/octet-stream")
+}
+
+// writeLookupError writes the error of a lookup in the repository name. A
+// lookup that is not found is reported with code and message.
+func (h *handler) writeLookupError(ctx context.Context, rw http.ResponseWriter, err error, name, code, message string) {
+	switch {
+	case err == errRepositoryUnknown:
+		writeError(rw, http.StatusNotFound, errCodeNameUnknown, fmt.Sprintf("repository %v unknown", name))
+	case content.IsNotFound(err) || metadata.IsNotFound(err):
+		writeError(rw, http.StatusNotFound, code, message)
+	default:
+		writeServerError(ctx, rw, err)
+	}
+}
+
+func (h *handler) serveBlob(ctx context.Context, rw http.ResponseWriter, r *http.Request, dgst digest.Digest, mediaType string) {
+	info, err := h.content.Info(ctx, dgst)
+	if err != nil {
+		if content.IsNotFound(err) {
+			writeError(rw, http.StatusNotFound, errCodeBlobUnknown, fmt.Sprintf("blob %v unknown", dgst))
+			return
+		}
+		writeServerError(ctx, rw, err)
+		return
+	}
+
+	rw.Header().Set("Content-Type", mediaType)
+	rw.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	rw.Header().Set("Docker-Content-Digest", dgst.String())
+	rw.Header().Set("Etag", fmt.Sprintf(`"%s"`, dgst))
+
+	if r.Method == http.MethodHead {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	rc, err := h.content.Reader(ctx, dgst)
+	if err != nil {
+		writeServerError(ctx, rw, err)
+		return
+	}
+	defer rc.Close()
+
+	rw.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(rw, rc); err != nil {
+		log.G(ctx).WithError(err).WithField("digest", dgst).Warn("failed to serve blob")
+	}
+}
+
+type tagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func (h *handler) serveTags(ctx context.Context, rw http.ResponseWriter, r *http.Request, name string) {
+	imgs, err := h.repository(ctx, name, indexMaxAge)
+	if err == nil && len(imgs) == 0 {
+		imgs, err = h.repository(ctx, name, indexMinAge)
+	}
+	if err != nil {
+		writeServerError(ctx, rw, err)
+		return
+	}
+	if len(imgs) == 0 {
+		writeError(rw, http.StatusNotFound, errCodeNameUnknown, fmt.Sprintf("repository %v unknown", name))
+		return
+	}
+
+	tags := make([]string, 0, len(imgs))
+	for tag := range imgs {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	q := r.URL.Query()
+	if last := q.Get("last"); last != "" {
+		i := sort.SearchStrings(tags, last)
+		if i < len(tags) && tags[i] == last {
+			i++
+		}
+		tags = tags[i:]
+	}
+	if n, err := strconv.Atoi(q.Get("n")); err == nil && n >= 0 && n < len(tags) {
+		tags = tags[:n]
+
+		next := url.Values{}
+		next.Set("n", strconv.Itoa(n))
+		next.Set("last", tags[len(tags)-1])
+		rw.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodHead {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+	json.NewEncoder(rw).Encode(tagsResponse{
+		Name: name,
+		Tags: tags,
+	})
+}
+
+// errRepositoryUnknown is returned by lookups in repositories without
+// images.
+var errRepositoryUnknown = errors.New("repository unknown")
+
+// repositoryIndex holds the images of the namespace by repository and tag.
+type repositoryIndex struct {
+	built time.Time
+	repos map[string]map[string]indexedImage
+	hosts map[string]struct{}
+}
+
+type indexedImage struct {
+	images.Image
+
+	// exact is set if the repository is the full locator of the image,
+	// rather than the locator without the hostname.
+	exact bool
+}
+
+func newRepositoryIndex(imgs []images.Image) *repositoryIndex {
+	idx := &repositoryIndex{
+		built: time.Now(),
+		repos: map[string]map[string]indexedImage{},
+		hosts: map[string]struct{}{},
+	}
+	for _, img := range imgs {
+		idx.add(img)
+	}
+	return idx
+}
+
+// add indexes img under its locator, and its locator without hostname. The
+// images matching the full locator take precedence.
+func (idx *repositoryIndex) add(img images.Image) {
+	refspec, err := reference.Parse(img.Name)
+	if err != nil {
+		return
+	}
+	tag, _ := reference.SplitObject(refspec.Object)
+	tag = strings.TrimSuffix(tag, "@")
+	if tag == "" {
+		return
+	}
+
+	set := func(name string, exact bool) {
+		repo, ok := idx.repos[name]
+		if !ok {
+			repo = map[string]indexedImage{}
+			idx.repos[name] = repo
+		}
+		if cur, ok := repo[tag]; ok && cur.exact && !exact {
+			return
+		}
+		repo[tag] = indexedImage{Image: img, exact: exact}
+	}
+	set(refspec.Locator, true)
+	if short := strings.TrimPrefix(refspec.Locator, refspec.Hostname()+"/"); short != refspec.Locator {
+		set(short, false)
+		idx.hosts[refspec.Hostname()] = struct{}{}
+	}
+}
+
+// indexLocked returns the index, rebuilding it first if it is older than
+// maxAge. h.mu must be held.
+func (h *handler) indexLocked(ctx context.Context, maxAge time.Duration) (*repositoryIndex, error) {
+	if h.index != nil && time.Since(h.index.built) <= maxAge {
+		return h.index, nil
+	}
+
+	all, err := h.images.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h.index = newRepositoryIndex(all)
+
+	// drop the references of targets that are no longer used
+	targets := map[digest.Digest]struct{}{}
+	for _, img := range all {
+		targets[img.Target.Digest] = struct{}{}
+	}
+	for dgst := range h.refs {
+		if _, ok := targets[dgst]; !ok {
+			delete(h.refs, dgst)
+		}
+	}
+	return h.index, nil
+}
+
+// repository returns the images of the named repository by tag, rebuilding
+// the index first if it is older than maxAge.
+func (h *handler) repository(ctx context.Context, name string, maxAge time.Duration) (map[string]images.Image, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, err := h.indexLocked(ctx, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	imgs := map[string]images.Image{}
+	for tag, img := range idx.repos[name] {
+		imgs[tag] = img.Image
+	}
+	return imgs, nil
+}
+
+// hosts returns the sorted hostnames of the indexed images.
+func (h *handler) hosts(ctx context.Context) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	idx, err := h.indexLocked(ctx, indexMaxAge)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(idx.hosts))
+	for host := range idx.hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts, nil
+}
+
+// lookup calls match with the images of the repository name. If the
+// repository is unknown, or match returns a not found error, the lookup is
+// retried once the index is rebuilt, unless it was just built.
+func (h *handler) lookup(ctx context.Context, name string, match func(map[string]images.Image) (ocispec.Descriptor, error)) (ocispec.Descriptor, error) {
+	var err error
+	for _, maxAge := range []time.Duration{indexMaxAge, indexMinAge} {
+		var imgs map[string]images.Image
+		imgs, err = h.repository(ctx, name, maxAge)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if len(imgs) == 0 {
+			err = errRepositoryUnknown
+			continue
+		}
+
+		var desc ocispec.Descriptor
+		desc, err = match(imgs)
+		if err == nil || !(content.IsNotFound(err) || metadata.IsNotFound(err)) {
+			return desc, err
+		}
+	}
+	return ocispec.Descriptor{}, err
+}
+
+// tag returns the target of the image tagged tag in the repository name. The
+// image is looked up by name in the image store, first with name as the full
+// locator, then with the hostnames of the indexed images. Images of other
+// hostnames are found through the index.
+func (h *handler) tag(ctx context.Context, name, tag string) (ocispec.Descriptor, error) {
+	hosts, err := h.hosts(ctx)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	for _, locator := range append([]string{name}, hosts...) {
+		if locator != name {
+			locator = locator + "/" + name
+		}
+		img, err := h.images.Get(ctx, locator+":"+tag)
+		if err != nil {
+			if metadata.IsNotFound(err) {
+				continue
+			}
+			return ocispec.Descriptor{}, err
+		}
+
+		h.mu.Lock()
+		// the index may predate the image, its content is served right
+		// away all the same
+		h.index.add(img)
+		h.mu.Unlock()
+		return img.Target, nil
+	}
+
+	return h.lookup(ctx, name, func(imgs map[string]images.Image) (ocispec.Descriptor, error) {
+		img, ok := imgs[tag]
+		if !ok {
+			return ocispec.Descriptor{}, errors.Wrapf(metadata.ErrNotFound, "tag %v unknown", tag)
+		}
+		// the image may have been updated or deleted since it was indexed
+		img, err := h.images.Get(ctx, img.Name)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		return img.Target, nil
+	})
+}
+
+// reference returns the descriptor of dgst as referenced by the images imgs,
+// their manifests or indexes. If dgst is not referenced, an error wrapping
+// content.ErrNotFound is returned.
+func (h *handler) reference(ctx context.Context, imgs map[string]images.Image, dgst digest.Digest) (ocispec.Descriptor, error) {
+	for _, img := range imgs {
+		refs, err := h.references(ctx, img.Target)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if desc, ok := refs[dgst]; ok {
+			return desc, nil
+		}
+	}
+	return ocispec.Descriptor{}, errors.Wrapf(content.ErrNotFound, "%v not referenced", dgst)
+}
+
+// references returns the content referenced by target, including target
+// itself, by digest. Only manifests and indexes are read, once their size is
+// checked. The result is cached, unless some manifests are missing, such as
+// the manifests of other platforms that were not fetched.
+func (h *handler) references(ctx context.Context, target ocispec.Descriptor) (map[digest.Digest]ocispec.Descriptor, error) {
+	h.mu.Lock()
+	refs, ok := h.refs[target.Digest]
+	h.mu.Unlock()
+	if ok {
+		return refs, nil
+	}
+
+	var (
+		descs    = []ocispec.Descriptor{target}
+		complete = true
+	)
+	refs = map[digest.Digest]ocispec.Descriptor{}
+	for len(descs) > 0 {
+		desc := descs[0]
+		descs = descs[1:]
+		if _, ok := refs[desc.Digest]; ok {
+			continue
+		}
+		refs[desc.Digest] = desc
+		if !isManifest(desc.MediaType) {
+			continue
+		}
+
+		children, err := h.children(ctx, desc)
+		if err != nil {
+			if content.IsNotFound(err) {
+				complete = false
+				continue
+			}
+			return nil, err
+		}
+		descs = append(descs, children...)
+	}
+
+	if complete {
+		h.mu.Lock()
+		h.refs[target.Digest] = refs
+		h.mu.Unlock()
+	}
+	return refs, nil
+}
+
+// children returns the descriptors referenced by the manifest or index desc.
+func (h *handler) children(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	info, err := h.content.Info(ctx, desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size > maxManifestSize {
+		return nil, errors.Errorf("manifest %v too large", desc.Digest)
+	}
+
+	p, err := content.ReadBlob(ctx, h.content, desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	var m struct {
+		Config    ocispec.Descriptor   `json:"config"`
+		Layers    []ocispec.Descriptor `json:"layers"`
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}
+	if err := json.Unmarshal(p, &m); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal manifest %v", desc.Digest)
+	}
+
+	var children []ocispec.Descriptor
+	if m.Config.Digest != "" {
+		children = append(children, m.Config)
+	}
+	children = append(children, m.Layers...)
+	return append(children, m.Manifests...), nil
+}
+
+func isManifest(mediaType string) bool {
+	switch mediaType {
+	case images.MediaTypeDockerSchema2Manifest, images.MediaTypeDockerSchema2ManifestList,
+		ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex:
+		return true
+	}
+	return false
+}
+
+type registryError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeError(rw http.ResponseWriter, status int, code, message string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	json.NewEncoder(rw).Encode(struct {
+		Errors []registryError `json:"errors"`
+	}{
+		Errors: []registryError{{Code: code, Message: message}},
+	})
+}
+
+func writeServerError(ctx context.Context, rw http.ResponseWriter, err error) {
+	if metadata.IsNotFound(err) || content.IsNotFound(err) {
+		writeError(rw, http.StatusNotFound, errCodeNameUnknown, err.Error())
+		return
+	}
+	log.G(ctx).WithError(err).Error("registry request failed")
+	writeError(rw, http.StatusInternalServerError, errCodeUnknown, err.Error())
+}
@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	_ "crypto/sha256" // required for digest package
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/metadata"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type testImageStore map[string]images.Image
+
+func (s testImageStore) Put(ctx context.Context, name string, desc ocispec.Descriptor) error {
+	s[name] = images.Image{Name: name, Target: desc}
+	return nil
+}
+
+func (s testImageStore) Get(ctx context.Context, name string) (images.Image, error) {
+	img, ok := s[name]
+	if !ok {
+		return images.Image{}, metadata.ErrNotFound
+	}
+	return img, nil
+}
+
+func (s testImageStore) List(ctx context.Context) ([]images.Image, error) {
+	var imgs []images.Image
+	for _, img := range s {
+		imgs = append(imgs, img)
+	}
+	return imgs, nil
+}
+
+func (s testImageStore) Delete(ctx context.Context, name string) error {
+	delete(s, name)
+	return nil
+}
+
+func TestServeImage(t *testing.T) {
+	ctx := context.Background()
+
+	tmpdir, err := ioutil.TempDir("", "registry-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	cs, err := content.NewStore(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		layer    = []byte("layer")
+		config   = []byte("{}")
+		private  = []byte("private")
+		manifest = []byte(fmt.Sprintf(`{"schemaVersion":2,"config":{"mediaType":%q,"digest":%q,"size":%d},"layers":[{"mediaType":%q,"digest":%q,"size":%d}]}`,
+			ocispec.MediaTypeImageConfig, digest.FromBytes(config), len(config),
+			ocispec.MediaTypeImageLayerGzip, digest.FromBytes(layer), len(layer)))
+		target = ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromBytes(manifest),
+			Size:      int64(len(manifest)),
+		}
+		is = testImageStore{}
+	)
+	for _, p := range [][]byte{layer, config, private, manifest} {
+		if err := content.WriteBlob(ctx, cs, "test", bytes.NewReader(p), int64(len(p)), digest.FromBytes(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	is.Put(ctx, "docker.io/library/test:latest", target)
+	is.Put(ctx, "docker.io/library/test:1.0", target)
+	is.Put(ctx, "docker.io/library/other:latest", target)
+
+	s := httptest.NewServer(NewHandler("testing", cs, is))
+	defer s.Close()
+
+	var (
+		base     = s.URL[7:] // strip "http://"
+		ref      = base + "/library/test:latest"
+		resolver = docker.NewResolver(docker.ResolverOptions{PlainHTTP: true})
+	)
+
+	_, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.Digest != target.Digest || desc.MediaType != target.MediaType || desc.Size != target.Size {
+		t.Fatalf("unexpected descriptor: %+v", desc)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := fetcher.Fetch(ctx, ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayerGzip,
+		Digest:    digest.FromBytes(layer),
+		Size:      int64(len(layer)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, layer) {
+		t.Fatal("fetched content mismatch")
+	}
+
+	tags, err := resolver.(remotes.Lister).Tags(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"1.0", "latest"}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("unexpected tags: %v, expected %v", tags, expected)
+	}
+
+	for _, tc := range []struct {
+		path   string
+		status int
+	}{
+		{"/v2/library/test/manifests/" + target.Digest.String(), http.StatusOK},
+		{"/v2/library/test/blobs/" + digest.FromBytes(config).String(), http.StatusOK},
+		// content of the store not referenced by the repository
+		{"/v2/library/test/blobs/" + digest.FromBytes(private).String(), http.StatusNotFound},
+		{"/v2/library/unknown/blobs/" + digest.FromBytes(layer).String(), http.StatusNotFound},
+		{"/v2/library/unknown/manifests/" + target.Digest.String(), http.StatusNotFound},
+		// blobs are not served as manifests
+		{"/v2/library/test/manifests/" + digest.FromBytes(layer).String(), http.StatusNotFound},
+	} {
+		resp, err := http.Get(s.URL + tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%v: unexpected status %v, expected %v", tc.path, resp.Status, tc.status)
+		}
+	}
+
+	// images are resolved by name, even when the repositories were
+	// indexed before they were created or deleted
+	is.Put(ctx, "docker.io/library/new:latest", target)
+	is.Delete(ctx, "docker.io/library/other:latest")
+	for _, tc := range []struct {
+		path   string
+		status int
+	}{
+		{"/v2/library/new/manifests/latest", http.StatusOK},
+		{"/v2/library/new/blobs/" + digest.FromBytes(layer).String(), http.StatusOK},
+		{"/v2/library/other/manifests/latest", http.StatusNotFound},
+	} {
+		resp, err := http.Get(s.URL + tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%v: unexpected status %v, expected %v", tc.path, resp.Status, tc.status)
+		}
+	}
+
+	resp, err := http.Post(s.URL+"/v2/library/test/blobs/uploads/", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("unexpected status for upload: %v", resp.Status)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/containerd/containerd/content"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/reference"
@@ -38,6 +39,13 @@ type dockerResolver struct {
 	credentials func(string) (string, string, error)
 	plainHTTP   bool
 	client      *http.Client
+	sources     SourceTracker
+	tracker     StatusTracker
+	chunkSize   int64
+	retry       retryPolicy
+
+	foreignHosts      []string
+	pushForeignLayers bool
 }
 
 // ResolverOptions are used to configured a new Docker register resolver
@@ -52,14 +60,59 @@ type ResolverOptions struct {
 
 	// Client is the http client to used when making registry requests
 	Client *http.Client
+
+	// Sources records the repositories blobs have been fetched from or
+	// pushed to. When set, the pusher attempts to mount blobs from a known
+	// repository on the same host before uploading them.
+	Sources SourceTracker
+
+	// Tracker records the status of blob uploads. It is used to report
+	// progress and to resume interrupted uploads. If not set, an in-memory
+	// tracker is used.
+	Tracker StatusTracker
+
+	// ChunkSize is the maximum number of bytes sent in a single request
+	// when uploading a blob. Blobs larger than the chunk size are uploaded
+	// in chunks, allowing an interrupted upload to resume from the last
+	// chunk acknowledged by the registry. If zero, blobs are uploaded in a
+	// single request.
+	ChunkSize int64
+
+	// MaxAttempts is the number of times a request failing with a
+	// transient error, such as a reset connection, a 5xx response or 429
+	// Too Many Requests, is attempted. Retries back off exponentially with
+	// jitter, honoring the Retry-After header. If zero, DefaultMaxAttempts
+	// is used. Set to 1 to disable retries.
+	MaxAttempts int
+
+	// ForeignHosts lists the hosts from which foreign, non-distributable
+	// layers may be fetched using the URLs of their descriptor. A leading
+	// "*." matches any subdomain. When a layer has no URL on an allowed
+	// host, it is fetched from the registry.
+	ForeignHosts []string
+
+	// PushForeignLayers pushes foreign, non-distributable layers into the
+	// registry. By default, these layers are skipped on push.
+	PushForeignLayers bool
 }
 
 // NewResolver returns a new resolver to a Docker registry
 func NewResolver(options ResolverOptions) remotes.Resolver {
+	tracker := options.Tracker
+	if tracker == nil {
+		tracker = NewInMemoryTracker()
+	}
 	return &dockerResolver{
 		credentials: options.Credentials,
 		plainHTTP:   options.PlainHTTP,
 		client:      options.Client,
+		sources:     options.Sources,
+		tracker:     tracker,
+		chunkSize:   options.ChunkSize,
+		retry:       newRetryPolicy(options.MaxAttempts),
+
+		foreignHosts:      options.ForeignHosts,
+		pushForeignLayers: options.PushForeignLayers,
 	}
 }
 
@@ -70,6 +123,7 @@ func (r *dockerResolver) Resolve(ctx context.Context, ref string) (string, ocisp
 	if err != nil {
 		return "", ocispec.Descriptor{}, err
 	}
+	ctx = log.WithLogger(ctx, log.G(ctx).WithField("ref", ref))
 
 	if refspec.Object == "" {
 		return "", ocispec.Descriptor{}, reference.ErrObjectRequired
@@ -172,7 +226,7 @@ func (r *dockerResolver) Resolve(ctx context.Context, ref string) (string, ocisp
 		return ref, desc, nil
 	}
 
-	return "", ocispec.Descriptor{}, errors.Errorf("%v not found", ref)
+	return "", ocispec.Descriptor{}, errors.Wrapf(content.ErrNotFound, "object %v", ref)
 }
 
 func (r *dockerResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
@@ -187,7 +241,8 @@ func (r *dockerResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetch
 	}
 
 	return dockerFetcher{
-		dockerBase: base,
+		dockerBase:   base,
+		foreignHosts: r.foreignHosts,
 	}, nil
 }
 
@@ -212,14 +267,20 @@ func (r *dockerResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher
 	return dockerPusher{
 		dockerBase: base,
 		tag:        refspec.Object,
+		tracker:    r.tracker,
+		chunkSize:  r.chunkSize,
+		foreign:    r.pushForeignLayers,
 	}, nil
 }
 
 type dockerBase struct {
-	base  url.URL
-	token string
+	base       url.URL
+	repository string
+	token      string
 
 	client   *http.Client
+	sources  SourceTracker
+	retry    retryPolicy
 	useBasic bool
 	username string
 	secret   string
@@ -252,14 +313,20 @@ func (r *dockerResolver) base(refspec reference.Spec) (*dockerBase, error) {
 		}
 	}
 
-	prefix := strings.TrimPrefix(refspec.Locator, host+"/")
+	var prefix string
+	if refspec.Locator != host {
+		prefix = strings.TrimPrefix(refspec.Locator, host+"/")
+	}
 	base.Path = path.Join("/v2", prefix)
 
 	return &dockerBase{
-		base:     base,
-		client:   r.client,
-		username: username,
-		secret:   secret,
+		base:       base,
+		repository: prefix,
+		client:     r.client,
+		sources:    r.sources,
+		retry:      r.retry,
+		username:   username,
+		secret:     secret,
 	}, nil
 }
 
@@ -269,6 +336,17 @@ func (r *dockerBase) url(ps ...string) string {
 	return url.String()
 }
 
+// addSource records the repository of this base as a source of the blob, if
+// a source tracker is configured.
+func (r *dockerBase) addSource(ctx context.Context, dgst digest.Digest) {
+	if r.sources == nil {
+		return
+	}
+	if err := r.sources.AddSource(ctx, dgst, r.base.Host, r.repository); err != nil {
+		log.G(ctx).WithError(err).Warn("failed to record blob source")
+	}
+}
+
 func (r *dockerBase) authorize(req *http.Request) {
 	if r.useBasic {
 		req.SetBasicAuth(r.username, r.secret)
@@ -293,20 +371,65 @@ func (r *dockerBase) doRequest(ctx context.Context, req *http.Request) (*http.Re
 }
 
 func (r *dockerBase) doRequestWithRetries(ctx context.Context, req *http.Request, responses []*http.Response) (*http.Response, error) {
-	resp, err := r.doRequest(ctx, req)
-	if err != nil {
-		return nil, err
+	var failures int
+	for {
+		resp, err := r.doRequest(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil || !isTransientError(err) {
+				return nil, err
+			}
+			failures++
+			if req, err = r.retryTransient(ctx, req, failures, nil, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if isRetryableStatus(resp.StatusCode) {
+			failures++
+			next, err := r.retryTransient(ctx, req, failures, resp, errors.Errorf("unexpected status: %s", resp.Status))
+			if err == nil {
+				resp.Body.Close()
+				req = next
+				continue
+			}
+			// return the response for the caller to handle
+			return resp, nil
+		}
+
+		responses = append(responses, resp)
+		next, err := r.retryRequest(ctx, req, responses)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+		req = next
 	}
+}
 
-	responses = append(responses, resp)
-	req, err = r.retryRequest(ctx, req, responses)
-	if err != nil {
-		return nil, err
+// retryTransient waits for the backoff of the retry policy and returns a
+// copy of the request to send again. If the request may not be retried, the
+// cause is returned as an error.
+func (r *dockerBase) retryTransient(ctx context.Context, req *http.Request, failures int, resp *http.Response, cause error) (*http.Request, error) {
+	if !r.retry.allowed(failures) || !isReplayable(req) {
+		return nil, cause
 	}
-	if req != nil {
-		return r.doRequestWithRetries(ctx, req, responses)
+
+	delay := r.retry.delay(failures, resp)
+	log.G(ctx).WithError(cause).WithFields(logrus.Fields{
+		"attempt": failures + 1,
+		"delay":   delay,
+		"method":  req.Method,
+	}).Warn("retrying registry request")
+
+	if err := waitRetry(ctx, delay); err != nil {
+		return nil, err
 	}
-	return resp, err
+
+	return copyRequest(req)
 }
 
 func (r *dockerBase) retryRequest(ctx context.Context, req *http.Request, responses []*http.Response) (*http.Request, error) {
@@ -344,7 +467,6 @@ func (r *dockerBase) retryRequest(ctx context.Context, req *http.Request, respon
 		}
 	}
 
-	// TODO: Handle 50x errors accounting for attempt history
 	return nil, nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/containerd/containerd/remotes"
@@ -158,7 +159,7 @@ func TestBadTokenResolver(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	h := content(ocispec.MediaTypeImageManifest, []byte("not anything parse-able"))
+	h := newContent(ocispec.MediaTypeImageManifest, []byte("not anything parse-able"))
 
 	base, ro, close := withTokenServer(th, creds)(logHandler{t, h})
 	defer close()
@@ -175,6 +176,56 @@ func TestBadTokenResolver(t *testing.T) {
 	}
 }
 
+// withFlakyServer wraps the server so that the first requests to each path
+// fail with a transient error.
+func withFlakyServer(sf func(h http.Handler) (string, ResolverOptions, func()), failures int) func(h http.Handler) (string, ResolverOptions, func()) {
+	return func(h http.Handler) (string, ResolverOptions, func()) {
+		var (
+			mu       sync.Mutex
+			attempts = map[string]int{}
+		)
+		wrapped := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			attempts[r.URL.Path]++
+			n := attempts[r.URL.Path]
+			mu.Unlock()
+
+			if n <= failures {
+				rw.Header().Set("Retry-After", "0")
+				if n%2 == 0 {
+					rw.WriteHeader(http.StatusTooManyRequests)
+				} else {
+					rw.WriteHeader(http.StatusServiceUnavailable)
+				}
+				return
+			}
+			h.ServeHTTP(rw, r)
+		})
+		return sf(wrapped)
+	}
+}
+
+func TestRetryResolver(t *testing.T) {
+	runBasicTest(t, "testname", withFlakyServer(tlsServer, 2))
+}
+
+func TestRetryResolverExhausted(t *testing.T) {
+	var (
+		ctx = context.Background()
+		h   = newContent(ocispec.MediaTypeImageManifest, []byte("manifest"))
+	)
+
+	base, ro, close := withFlakyServer(tlsServer, 2)(logHandler{t, h})
+	defer close()
+
+	ro.MaxAttempts = 2
+	resolver := NewResolver(ro)
+
+	if _, _, err := resolver.Resolve(ctx, base+"/testname:latest"); err == nil {
+		t.Fatal("expected resolve to fail after exhausting attempts")
+	}
+}
+
 func withTokenServer(th http.Handler, creds func(string) (string, string, error)) func(h http.Handler) (string, ResolverOptions, func()) {
 	return func(h http.Handler) (string, ResolverOptions, func()) {
 		s := httptest.NewUnstartedServer(th)
@@ -247,10 +298,10 @@ func runBasicTest(t *testing.T, name string, sf func(h http.Handler) (string, Re
 	)
 
 	m := newManifest(
-		content(ocispec.MediaTypeImageConfig, []byte("1")),
-		content(ocispec.MediaTypeImageLayerGzip, []byte("2")),
+		newContent(ocispec.MediaTypeImageConfig, []byte("1")),
+		newContent(ocispec.MediaTypeImageLayerGzip, []byte("2")),
 	)
-	mc := content(ocispec.MediaTypeImageManifest, m.OCIManifest())
+	mc := newContent(ocispec.MediaTypeImageManifest, m.OCIManifest())
 	m.RegisterHandler(r, name)
 	r.Handle(fmt.Sprintf("/v2/%s/manifests/%s", name, tag), mc)
 	r.Handle(fmt.Sprintf("/v2/%s/manifests/%s", name, mc.Digest()), mc)
@@ -331,7 +382,7 @@ type testContent struct {
 	content   []byte
 }
 
-func content(mediaType string, b []byte) testContent {
+func newContent(mediaType string, b []byte) testContent {
 	return testContent{
 		mediaType: mediaType,
 		content:   b,
@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a registry request failing
+	// with a transient error is attempted when not configured otherwise.
+	DefaultMaxAttempts = 5
+
+	retryBaseDelay = 200 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+
+	// maxRetryAfter bounds the delay requested by a registry through the
+	// Retry-After header.
+	maxRetryAfter = 5 * time.Minute
+)
+
+// retryPolicy decides whether and when failed registry requests are retried.
+type retryPolicy struct {
+	maxAttempts int
+}
+
+func newRetryPolicy(maxAttempts int) retryPolicy {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return retryPolicy{maxAttempts: maxAttempts}
+}
+
+// allowed returns true if another attempt may be made after the given number
+// of failed attempts.
+func (p retryPolicy) allowed(failures int) bool {
+	return failures < p.maxAttempts
+}
+
+// backoff returns the delay before the next attempt after the given number of
+// failed attempts, using exponential backoff with jitter.
+func (p retryPolicy) backoff(failures int) time.Duration {
+	d := retryBaseDelay << uint(failures-1)
+	if d <= 0 || d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	// use a random delay between half and the full backoff
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// delay returns the delay before retrying after resp, honoring the
+// Retry-After header when present.
+func (p retryPolicy) delay(failures int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	return p.backoff(failures)
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		d = time.Until(t)
+	} else {
+		return 0, false
+	}
+
+	if d < 0 {
+		d = 0
+	}
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+	return d, true
+}
+
+// isRetryableStatus returns true for response codes which indicate a
+// transient condition on the registry.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientError returns true if the request error is likely to succeed
+// when retried, such as a reset connection or a timeout.
+func isTransientError(err error) bool {
+	cause := errors.Cause(err)
+	if uerr, ok := cause.(*url.Error); ok {
+		cause = uerr.Err
+	}
+
+	switch cause {
+	case io.EOF, io.ErrUnexpectedEOF, syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.EPIPE:
+		return true
+	}
+
+	switch e := cause.(type) {
+	case *net.OpError:
+		return true
+	case net.Error:
+		return e.Timeout()
+	}
+
+	return false
+}
+
+// isReplayable returns true if the request can be sent again.
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// waitRetry waits for the delay or until the context is done.
+func waitRetry(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
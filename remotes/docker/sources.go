@@ -0,0 +1,184 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/content"
+	digest "github.com/opencontainers/go-digest"
+)
+
+const (
+	// labelSourcePrefix prefixes the content labels recording the sources
+	// of a blob, it is followed by the registry host. The value of the
+	// label is the comma separated list of repositories, most recently
+	// recorded first.
+	labelSourcePrefix = "containerd.io/distribution.source."
+
+	// maxSources is the number of repositories recorded per blob and host,
+	// older sources are forgotten.
+	maxSources = 10
+)
+
+// SourceTracker records the repositories on a registry host which are known
+// to hold a blob. The pusher uses these records to mount a blob from another
+// repository on the same host rather than uploading it again.
+type SourceTracker interface {
+	// AddSource records that the blob identified by dgst is available from
+	// repository on host.
+	AddSource(ctx context.Context, dgst digest.Digest, host, repository string) error
+
+	// Sources returns the repositories on host known to hold the blob, most
+	// recently recorded first.
+	Sources(ctx context.Context, dgst digest.Digest, host string) ([]string, error)
+}
+
+type sourceKey struct {
+	dgst digest.Digest
+	host string
+}
+
+type memorySourceTracker struct {
+	mu      sync.Mutex
+	sources map[sourceKey][]string
+}
+
+// NewInMemorySourceTracker returns a SourceTracker which keeps the fetch and
+// push history of blobs for the lifetime of the process.
+//
+// Share the tracker between resolvers so that a push can mount blobs that
+// were fetched earlier.
+func NewInMemorySourceTracker() SourceTracker {
+	return &memorySourceTracker{
+		sources: make(map[sourceKey][]string),
+	}
+}
+
+func (t *memorySourceTracker) AddSource(ctx context.Context, dgst digest.Digest, host, repository string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := sourceKey{dgst: dgst, host: host}
+	t.sources[key] = addSource(t.sources[key], repository)
+
+	return nil
+}
+
+func (t *memorySourceTracker) Sources(ctx context.Context, dgst digest.Digest, host string) ([]string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	repos := t.sources[sourceKey{dgst: dgst, host: host}]
+	return append([]string(nil), repos...), nil
+}
+
+type contentSourceTracker struct {
+	cs content.Manager
+}
+
+var (
+	// sourceLocks serializes the updates of the sources of a blob within
+	// the process, as the labels are read, modified and written back.
+	sourceLocks   = map[digest.Digest]*sourceLock{}
+	sourceLocksMu sync.Mutex
+)
+
+type sourceLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockSources locks the sources of dgst, the returned function unlocks them.
+func lockSources(dgst digest.Digest) func() {
+	sourceLocksMu.Lock()
+	l, ok := sourceLocks[dgst]
+	if !ok {
+		l = &sourceLock{}
+		sourceLocks[dgst] = l
+	}
+	l.refs++
+	sourceLocksMu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		sourceLocksMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(sourceLocks, dgst)
+		}
+		sourceLocksMu.Unlock()
+	}
+}
+
+// NewContentSourceTracker returns a SourceTracker which records the sources
+// of blobs as labels on the blobs in the content store, so that they are
+// kept across processes. The source of a blob is only recorded once the blob
+// is in the content store.
+func NewContentSourceTracker(cs content.Manager) SourceTracker {
+	return &contentSourceTracker{
+		cs: cs,
+	}
+}
+
+func (t *contentSourceTracker) AddSource(ctx context.Context, dgst digest.Digest, host, repository string) error {
+	unlock := lockSources(dgst)
+	defer unlock()
+
+	info, err := t.cs.Info(ctx, dgst)
+	if err != nil {
+		if content.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	key := labelSourcePrefix + host
+	repos := addSource(splitSources(info.Labels[key]), repository)
+
+	_, err = t.cs.Update(ctx, content.Info{
+		Digest: dgst,
+		Labels: map[string]string{
+			key: strings.Join(repos, ","),
+		},
+	}, "labels."+key)
+	if content.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (t *contentSourceTracker) Sources(ctx context.Context, dgst digest.Digest, host string) ([]string, error) {
+	info, err := t.cs.Info(ctx, dgst)
+	if err != nil {
+		if content.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return splitSources(info.Labels[labelSourcePrefix+host]), nil
+}
+
+// addSource returns repos with repository moved or added to the front,
+// keeping at most maxSources repositories.
+func addSource(repos []string, repository string) []string {
+	updated := []string{repository}
+	for _, repo := range repos {
+		if len(updated) == maxSources {
+			break
+		}
+		if repo != repository {
+			updated = append(updated, repo)
+		}
+	}
+	return updated
+}
+
+func splitSources(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
@@ -0,0 +1,157 @@
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/log"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// Status of an upload to a registry.
+type Status struct {
+	content.Status
+
+	// UploadLocation is the location of the registry upload session. When
+	// set, a later push of the same content may resume the session rather
+	// than starting over.
+	UploadLocation string
+}
+
+// StatusTracker records the status of uploads by reference key, as returned
+// by remotes.MakeRefKey.
+type StatusTracker interface {
+	// GetStatus returns the status for the ref. If there is no status for
+	// the ref, content.ErrNotFound is returned.
+	GetStatus(ref string) (Status, error)
+
+	// SetStatus updates the status for the ref.
+	SetStatus(ref string, status Status)
+}
+
+type memoryStatusTracker struct {
+	statuses map[string]Status
+	m        sync.Mutex
+}
+
+// NewInMemoryTracker returns a StatusTracker which keeps upload statuses for
+// the lifetime of the process.
+func NewInMemoryTracker() StatusTracker {
+	return &memoryStatusTracker{
+		statuses: map[string]Status{},
+	}
+}
+
+func (t *memoryStatusTracker) GetStatus(ref string) (Status, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	status, ok := t.statuses[ref]
+	if !ok {
+		return Status{}, errors.Wrapf(content.ErrNotFound, "status for ref %v", ref)
+	}
+	return status, nil
+}
+
+func (t *memoryStatusTracker) SetStatus(ref string, status Status) {
+	t.m.Lock()
+	t.statuses[ref] = status
+	t.m.Unlock()
+}
+
+type fileStatusTracker struct {
+	root     string
+	statuses map[string]Status
+	m        sync.Mutex
+}
+
+// NewFileTracker returns a StatusTracker which keeps the upload locations in
+// files under root, so that an upload interrupted in one process can be
+// resumed by another. Only the changes of upload location are written, the
+// progress of an upload is kept in memory as it is queried from the registry
+// when the upload is resumed.
+func NewFileTracker(root string) StatusTracker {
+	return &fileStatusTracker{
+		root:     root,
+		statuses: map[string]Status{},
+	}
+}
+
+func (t *fileStatusTracker) GetStatus(ref string) (Status, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if status, ok := t.statuses[ref]; ok {
+		return status, nil
+	}
+
+	p, err := ioutil.ReadFile(t.path(ref))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Status{}, errors.Wrapf(content.ErrNotFound, "status for ref %v", ref)
+		}
+		return Status{}, err
+	}
+	var status Status
+	if err := json.Unmarshal(p, &status); err != nil {
+		return Status{}, errors.Wrapf(err, "invalid status for ref %v", ref)
+	}
+	t.statuses[ref] = status
+	return status, nil
+}
+
+func (t *fileStatusTracker) SetStatus(ref string, status Status) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	previous, ok := t.statuses[ref]
+	t.statuses[ref] = status
+	if ok && previous.UploadLocation == status.UploadLocation {
+		return
+	}
+
+	if err := t.write(ref, status); err != nil {
+		log.L.WithError(err).WithField("ref", ref).Warn("failed to save upload status")
+	}
+}
+
+// write saves the status of ref, a status without upload location removes
+// the file.
+func (t *fileStatusTracker) write(ref string, status Status) error {
+	path := t.path(ref)
+	if status.UploadLocation == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	p, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.root, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(t.root, ".status-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(p); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path returns the file of the status of ref, refs are hashed as they may
+// contain any character.
+func (t *fileStatusTracker) path(ref string) string {
+	return filepath.Join(t.root, digest.FromString(ref).Hex())
+}
@@ -0,0 +1,386 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Scheme is the prefix of references to an OCI image layout directory,
+	// such as "oci:/path/to/layout:tag".
+	Scheme = "oci:"
+
+	// AnnotationRefName is the annotation used to tag manifests in the
+	// index of an image layout.
+	AnnotationRefName = "org.opencontainers.image.ref.name"
+
+	indexFile = "index.json"
+)
+
+// IsReference returns true if ref refers to an OCI image layout directory.
+func IsReference(ref string) bool {
+	return strings.HasPrefix(ref, Scheme)
+}
+
+// layoutLocks serializes updates of index.json within the process.
+var layoutLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{
+	locks: map[string]*sync.Mutex{},
+}
+
+func layoutLock(root string) *sync.Mutex {
+	layoutLocks.Lock()
+	defer layoutLocks.Unlock()
+
+	l, ok := layoutLocks.locks[root]
+	if !ok {
+		l = &sync.Mutex{}
+		layoutLocks.locks[root] = l
+	}
+	return l
+}
+
+type ociResolver struct{}
+
+// NewResolver returns a resolver for references to OCI image layout
+// directories.
+//
+// References take the form "oci:<path>:<tag>" or "oci:<path>@<digest>".
+// Tags are recorded in the index of the layout with the
+// "org.opencontainers.image.ref.name" annotation.
+func NewResolver() remotes.Resolver {
+	return ociResolver{}
+}
+
+var _ remotes.Resolver = ociResolver{}
+
+func (r ociResolver) Resolve(ctx context.Context, ref string) (string, ocispec.Descriptor, error) {
+	root, object, err := parseReference(ref)
+	if err != nil {
+		return "", ocispec.Descriptor{}, err
+	}
+	if object == "" {
+		return "", ocispec.Descriptor{}, reference.ErrObjectRequired
+	}
+
+	index, err := readIndex(root)
+	if err != nil {
+		return "", ocispec.Descriptor{}, err
+	}
+
+	_, dgst := reference.SplitObject(object)
+	if dgst != "" {
+		if err := dgst.Validate(); err != nil {
+			return "", ocispec.Descriptor{}, err
+		}
+	}
+
+	for _, desc := range index.Manifests {
+		if dgst != "" {
+			if desc.Digest != dgst {
+				continue
+			}
+		} else if desc.Annotations[AnnotationRefName] != object {
+			continue
+		}
+
+		log.G(ctx).WithField("desc.digest", desc.Digest).Debug("resolved")
+		return ref, ocispec.Descriptor{
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size:      desc.Size,
+		}, nil
+	}
+
+	return "", ocispec.Descriptor{}, errors.Wrapf(content.ErrNotFound, "object %v", ref)
+}
+
+func (r ociResolver) Fetcher(ctx context.Context, ref string) (remotes.Fetcher, error) {
+	root, _, err := parseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return ociFetcher{root: root}, nil
+}
+
+func (r ociResolver) Pusher(ctx context.Context, ref string) (remotes.Pusher, error) {
+	root, object, err := parseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// As with registries, the pushed manifests may only be tagged.
+	if strings.Contains(object, "@") {
+		return nil, errors.New("cannot use digest reference for push locator")
+	}
+
+	return ociPusher{
+		root: root,
+		tag:  object,
+	}, nil
+}
+
+type ociFetcher struct {
+	root string
+}
+
+func (f ociFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	p, err := blobPath(f.root, desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	fp, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("%v not found in %v", desc.Digest, f.root)
+		}
+		return nil, err
+	}
+
+	return fp, nil
+}
+
+type ociPusher struct {
+	root string
+	tag  string
+}
+
+func (p ociPusher) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+	if err := initLayout(p.root); err != nil {
+		return err
+	}
+
+	if err := writeBlob(p.root, desc, r); err != nil {
+		return err
+	}
+
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2Manifest, images.MediaTypeDockerSchema2ManifestList,
+		ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex:
+		if p.tag != "" {
+			return tagManifest(p.root, p.tag, desc)
+		}
+	}
+
+	return nil
+}
+
+// parseReference splits an OCI layout reference into the layout directory and
+// the object, which is either a tag or a digest prefixed with "@".
+func parseReference(ref string) (string, string, error) {
+	if !IsReference(ref) {
+		return "", "", errors.Errorf("%q is not an oci layout reference", ref)
+	}
+
+	var (
+		root   = strings.TrimPrefix(ref, Scheme)
+		object string
+	)
+	if i := strings.LastIndex(root, "@"); i >= 0 {
+		root, object = root[:i], root[i:]
+	} else if i := strings.LastIndex(root, ":"); i > strings.LastIndex(root, "/") {
+		root, object = root[:i], root[i+1:]
+	}
+
+	if root == "" {
+		return "", "", errors.Wrapf(reference.ErrInvalid, "missing layout path in %q", ref)
+	}
+
+	return filepath.Clean(root), object, nil
+}
+
+func blobPath(root string, dgst digest.Digest) (string, error) {
+	if err := dgst.Validate(); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(root, "blobs", dgst.Algorithm().String(), dgst.Hex()), nil
+}
+
+func readIndex(root string) (ocispec.Index, error) {
+	var index ocispec.Index
+
+	p, err := ioutil.ReadFile(filepath.Join(root, indexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, errors.Errorf("%v is not an oci image layout", root)
+		}
+		return index, err
+	}
+
+	if err := json.Unmarshal(p, &index); err != nil {
+		return index, errors.Wrapf(err, "failed to decode %v", indexFile)
+	}
+
+	return index, nil
+}
+
+// initLayout creates the layout file and an empty index in root, unless they
+// already exist.
+func initLayout(root string) error {
+	if err := os.MkdirAll(filepath.Join(root, "blobs"), 0755); err != nil {
+		return err
+	}
+
+	layoutPath := filepath.Join(root, ocispec.ImageLayoutFile)
+	if _, err := os.Stat(layoutPath); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		p, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+		if err != nil {
+			return err
+		}
+		if err := writeFile(layoutPath, p); err != nil {
+			return err
+		}
+	}
+
+	l := layoutLock(root)
+	l.Lock()
+	defer l.Unlock()
+
+	if _, err := os.Stat(filepath.Join(root, indexFile)); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		return writeIndex(root, ocispec.Index{
+			Versioned: specs.Versioned{
+				SchemaVersion: 2,
+			},
+		})
+	}
+
+	return nil
+}
+
+// tagManifest records desc in the index of the layout under tag, replacing
+// any manifest previously tagged with it.
+func tagManifest(root, tag string, desc ocispec.Descriptor) error {
+	l := layoutLock(root)
+	l.Lock()
+	defer l.Unlock()
+
+	index, err := readIndex(root)
+	if err != nil {
+		return err
+	}
+
+	manifests := index.Manifests[:0]
+	for _, m := range index.Manifests {
+		if m.Annotations[AnnotationRefName] != tag {
+			manifests = append(manifests, m)
+		}
+	}
+
+	desc.Annotations = map[string]string{
+		AnnotationRefName: tag,
+	}
+	index.Manifests = append(manifests, desc)
+
+	return writeIndex(root, index)
+}
+
+func writeIndex(root string, index ocispec.Index) error {
+	if index.Manifests == nil {
+		index.Manifests = []ocispec.Descriptor{}
+	}
+
+	p, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(root, indexFile), p)
+}
+
+// writeFile atomically replaces the file at path with p.
+func writeFile(path string, p []byte) error {
+	fp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	if _, err := fp.Write(p); err != nil {
+		fp.Close()
+		os.Remove(fp.Name())
+		return err
+	}
+
+	if err := fp.Close(); err != nil {
+		os.Remove(fp.Name())
+		return err
+	}
+
+	if err := os.Chmod(fp.Name(), 0644); err != nil {
+		os.Remove(fp.Name())
+		return err
+	}
+
+	return os.Rename(fp.Name(), path)
+}
+
+// writeBlob writes the blob into the layout, verifying its size and digest.
+// If the blob already exists, the reader is not consumed.
+func writeBlob(root string, desc ocispec.Descriptor, r io.Reader) error {
+	p, err := blobPath(root, desc.Digest)
+	if err != nil {
+		return err
+	}
+
+	if fi, err := os.Stat(p); err == nil && fi.Size() == desc.Size {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+
+	fp, err := ioutil.TempFile(filepath.Dir(p), "."+desc.Digest.Hex())
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fp.Name())
+
+	digester := desc.Digest.Algorithm().Digester()
+	n, err := io.Copy(io.MultiWriter(fp, digester.Hash()), r)
+	if cerr := fp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if desc.Size > 0 && n != desc.Size {
+		return errors.Errorf("unexpected size %d for %v, expected %d", n, desc.Digest, desc.Size)
+	}
+	if dgst := digester.Digest(); dgst != desc.Digest {
+		return errors.Errorf("unexpected digest %v, expected %v", dgst, desc.Digest)
+	}
+
+	if err := os.Chmod(fp.Name(), 0444); err != nil {
+		return err
+	}
+
+	return os.Rename(fp.Name(), p)
+}
@@ -0,0 +1,132 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/content"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParseReference(t *testing.T) {
+	for _, tc := range []struct {
+		ref    string
+		root   string
+		object string
+	}{
+		{"oci:/tmp/layout:latest", "/tmp/layout", "latest"},
+		{"oci:/tmp/lay:out/image", "/tmp/lay:out/image", ""},
+		{"oci:relative/layout:1.0", "relative/layout", "1.0"},
+		{"oci:/tmp/layout@sha256:abcd", "/tmp/layout", "@sha256:abcd"},
+	} {
+		root, object, err := parseReference(tc.ref)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.ref, err)
+		}
+		if root != tc.root || object != tc.object {
+			t.Fatalf("%s: unexpected root %q and object %q", tc.ref, root, object)
+		}
+	}
+
+	for _, ref := range []string{"docker.io/library/ubuntu:latest", "oci::latest"} {
+		if _, _, err := parseReference(ref); err == nil {
+			t.Fatalf("%s: expected error", ref)
+		}
+	}
+}
+
+func TestPushResolveFetch(t *testing.T) {
+	ctx := context.Background()
+
+	tmpdir, err := ioutil.TempDir("", "oci-layout-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	var (
+		ref      = Scheme + filepath.Join(tmpdir, "layout") + ":latest"
+		resolver = NewResolver()
+		layer    = []byte("layer")
+		manifest = []byte(`{"schemaVersion":2}`)
+		descs    = []ocispec.Descriptor{
+			{
+				MediaType: ocispec.MediaTypeImageLayerGzip,
+				Digest:    digest.FromBytes(layer),
+				Size:      int64(len(layer)),
+			},
+			{
+				MediaType: ocispec.MediaTypeImageManifest,
+				Digest:    digest.FromBytes(manifest),
+				Size:      int64(len(manifest)),
+			},
+		}
+	)
+
+	pusher, err := resolver.Pusher(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range [][]byte{layer, manifest} {
+		if err := pusher.Push(ctx, descs[i], bytes.NewReader(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// pushing the manifest again must not duplicate the tag
+	if err := pusher.Push(ctx, descs[1], bytes.NewReader(manifest)); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := readIndex(filepath.Join(tmpdir, "layout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].Annotations[AnnotationRefName] != "latest" {
+		t.Fatalf("unexpected index: %+v", index)
+	}
+
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != ref || desc.Digest != descs[1].Digest || desc.MediaType != ocispec.MediaTypeImageManifest {
+		t.Fatalf("unexpected resolution: %s %+v", name, desc)
+	}
+
+	if _, _, err := resolver.Resolve(ctx, strings.TrimSuffix(ref, "latest")+"missing"); !content.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := fetcher.Fetch(ctx, descs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	p, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p, layer) {
+		t.Fatal("fetched content mismatch")
+	}
+
+	var layout ocispec.ImageLayout
+	b, err := ioutil.ReadFile(filepath.Join(tmpdir, "layout", ocispec.ImageLayoutFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &layout); err != nil || layout.Version != ocispec.ImageLayoutVersion {
+		t.Fatalf("unexpected layout file: %s", b)
+	}
+}
@@ -0,0 +1,138 @@
+// Package policy restricts the references which may be resolved and
+// registered as images.
+package policy
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/reference"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LabelAllow is the namespace label holding the comma separated
+	// patterns of references allowed in the namespace.
+	LabelAllow = "containerd.io/registry.allow"
+
+	// LabelDeny is the namespace label holding the comma separated patterns
+	// of references denied in the namespace.
+	LabelDeny = "containerd.io/registry.deny"
+)
+
+// ErrDenied is returned when a reference is not admitted by the policy.
+var ErrDenied = errors.New("policy: reference denied")
+
+// IsDenied returns true if the error is due to a denied reference.
+func IsDenied(err error) bool {
+	return errors.Cause(err) == ErrDenied
+}
+
+// Rules admit references by the locator of their reference.Spec.
+//
+// A pattern matches a locator, such as "docker.io/library/redis", when it
+// matches the locator or one of its parent namespaces, such as "docker.io" or
+// "docker.io/library". Each path component of a pattern may use the syntax
+// of path.Match, so "*.example.com" matches any subdomain and
+// "docker.io/*/redis" matches redis in any namespace of docker.io.
+type Rules struct {
+	// Allow lists the patterns of admitted references. If empty, all
+	// references not denied are admitted.
+	Allow []string `toml:"allow"`
+
+	// Deny lists the patterns of refused references. Deny takes precedence
+	// over Allow.
+	Deny []string `toml:"deny"`
+}
+
+// RulesFromLabels returns the rules set on a namespace with LabelAllow and
+// LabelDeny.
+func RulesFromLabels(labels map[string]string) Rules {
+	return Rules{
+		Allow: splitPatterns(labels[LabelAllow]),
+		Deny:  splitPatterns(labels[LabelDeny]),
+	}
+}
+
+func splitPatterns(v string) []string {
+	var patterns []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// Check returns an error wrapping ErrDenied if the rules do not admit ref.
+func (r Rules) Check(ref string) error {
+	if len(r.Allow) == 0 && len(r.Deny) == 0 {
+		return nil
+	}
+
+	spec, err := reference.Parse(ref)
+	if err != nil {
+		return errors.Wrapf(ErrDenied, "invalid reference %q: %v", ref, err)
+	}
+
+	for _, pattern := range r.Deny {
+		if match(pattern, spec.Locator) {
+			return errors.Wrapf(ErrDenied, "%v matches denied pattern %q", spec.Locator, pattern)
+		}
+	}
+	if len(r.Allow) == 0 {
+		return nil
+	}
+	for _, pattern := range r.Allow {
+		if match(pattern, spec.Locator) {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrDenied, "%v matches no allowed pattern", spec.Locator)
+}
+
+// match returns true if the pattern matches the locator or one of its
+// parents.
+func match(pattern, locator string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	n := strings.Count(pattern, "/") + 1
+
+	parts := strings.SplitN(locator, "/", n+1)
+	if len(parts) < n {
+		return false
+	}
+	ok, err := path.Match(pattern, strings.Join(parts[:n], "/"))
+	return err == nil && ok
+}
+
+// Config is the daemon configuration of the policy.
+type Config struct {
+	Rules
+
+	// Namespaces holds the rules applying to the named namespaces, in
+	// addition to the global rules.
+	Namespaces map[string]Rules `toml:"namespaces"`
+}
+
+// Check returns an error wrapping ErrDenied if ref is not admitted in the
+// namespace of ctx. A reference must be admitted by the global rules, the
+// rules configured for the namespace and the rules set in the namespace
+// labels.
+func (c *Config) Check(ctx context.Context, ref string, labels map[string]string) error {
+	if err := c.Rules.Check(ref); err != nil {
+		return err
+	}
+
+	namespace, _ := namespaces.Namespace(ctx)
+	if rules, ok := c.Namespaces[namespace]; ok {
+		if err := rules.Check(ref); err != nil {
+			return errors.Wrapf(err, "namespace %v", namespace)
+		}
+	}
+	if err := RulesFromLabels(labels).Check(ref); err != nil {
+		return errors.Wrapf(err, "namespace %v", namespace)
+	}
+	return nil
+}
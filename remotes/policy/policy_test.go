@@ -0,0 +1,86 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/containerd/namespaces"
+)
+
+func TestRulesCheck(t *testing.T) {
+	rules := Rules{
+		Allow: []string{"docker.io/library", "*.example.com", "quay.io/*/app"},
+		Deny:  []string{"docker.io/library/busybox", "untrusted.example.com"},
+	}
+
+	for _, tc := range []struct {
+		ref     string
+		allowed bool
+	}{
+		{"docker.io/library/redis:latest", true},
+		{"docker.io/library/redis/extra:latest", true},
+		{"docker.io/library/busybox:latest", false},
+		{"docker.io/library/busybox2:latest", true},
+		{"docker.io/other/redis:latest", false},
+		{"registry.example.com/team/app:1.0", true},
+		{"untrusted.example.com/team/app:1.0", false},
+		{"example.com/app:1.0", false},
+		{"quay.io/team/app@sha256:4c1e", true},
+		{"quay.io/team/other:latest", false},
+		{"quay.io/app:latest", false},
+		{"not a reference", false},
+	} {
+		err := rules.Check(tc.ref)
+		if tc.allowed && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.ref, err)
+		} else if !tc.allowed && !IsDenied(err) {
+			t.Errorf("%v: expected denied, got %v", tc.ref, err)
+		}
+	}
+
+	if err := (Rules{}).Check("oci:/path/to/layout:latest"); err != nil {
+		t.Fatalf("empty rules should admit all references: %v", err)
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	config := &Config{
+		Rules: Rules{
+			Deny: []string{"docker.io/library/busybox"},
+		},
+		Namespaces: map[string]Rules{
+			"production": {Allow: []string{"registry.example.com"}},
+		},
+	}
+
+	var (
+		prod    = namespaces.WithNamespace(context.Background(), "production")
+		dev     = namespaces.WithNamespace(context.Background(), "dev")
+		labeled = map[string]string{
+			LabelAllow: "docker.io, registry.example.com",
+			LabelDeny:  "registry.example.com/experimental",
+		}
+	)
+
+	for _, tc := range []struct {
+		ctx     context.Context
+		ref     string
+		labels  map[string]string
+		allowed bool
+	}{
+		{dev, "docker.io/library/redis:latest", nil, true},
+		{dev, "docker.io/library/busybox:latest", nil, false},
+		{prod, "docker.io/library/redis:latest", nil, false},
+		{prod, "registry.example.com/app:latest", nil, true},
+		{dev, "quay.io/app:latest", labeled, false},
+		{dev, "registry.example.com/app:latest", labeled, true},
+		{dev, "registry.example.com/experimental/app:latest", labeled, false},
+	} {
+		err := config.Check(tc.ctx, tc.ref, tc.labels)
+		if tc.allowed && err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.ref, err)
+		} else if !tc.allowed && !IsDenied(err) {
+			t.Errorf("%v: expected denied, got %v", tc.ref, err)
+		}
+	}
+}
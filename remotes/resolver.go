@@ -19,7 +19,8 @@ type Resolver interface {
 	// Dependending on the remote namespace, this may be immutable or mutable.
 	// While the name may differ from ref, it should itself be a valid ref.
 	//
-	// If the resolution fails, an error will be returned.
+	// If the resolution fails, an error will be returned. If the remote has
+	// no object for ref, the error wraps content.ErrNotFound.
 	Resolve(ctx context.Context, ref string) (name string, desc ocispec.Descriptor, err error)
 
 	// Fetcher returns a new fetcher for the provided reference.
@@ -31,6 +32,17 @@ type Resolver interface {
 	Pusher(ctx context.Context, ref string) (Pusher, error)
 }
 
+// Lister lists the objects available from a remote. Resolvers may optionally
+// implement Lister.
+type Lister interface {
+	// Tags returns the tags of the locator referred to by ref. Any object
+	// portion of ref is ignored.
+	Tags(ctx context.Context, ref string) ([]string, error)
+
+	// Catalog returns the locators of the repositories available from host.
+	Catalog(ctx context.Context, host string) ([]string, error)
+}
+
 type Fetcher interface {
 	// Fetch the resource identified by the descriptor.
 	Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error)
@@ -54,6 +66,6 @@ func (fn FetcherFunc) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.Re
 // function.
 type PusherFunc func(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error
 
-func (fn PusherFunc) Pusher(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
+func (fn PusherFunc) Push(ctx context.Context, desc ocispec.Descriptor, r io.Reader) error {
 	return fn(ctx, desc, r)
 }
@@ -17,6 +17,20 @@ type Applier interface {
 	Apply(context.Context, ocispec.Descriptor, []mount.Mount) (ocispec.Descriptor, error)
 }
 
+// SnapshotApplier is an Applier which can unpack a layer to a snapshot and
+// commit it in a single call, without exposing the active snapshot. The
+// snapshotter then records the applied layer, so that the committed snapshot
+// can be shared with other namespaces, see snapshot.DiffRecorder.
+type SnapshotApplier interface {
+	Applier
+	// ApplySnapshot unpacks layer on top of the committed snapshot parent
+	// and commits the result as name, if the applied diff matches the diff
+	// of the layer. If name exists, or a snapshot with the same layers is
+	// shared as name, an error wrapping snapshot.ErrSnapshotExist is
+	// returned.
+	ApplySnapshot(ctx context.Context, layer Layer, name, parent string) error
+}
+
 type Layer struct {
 	Diff ocispec.Descriptor
 	Blob ocispec.Descriptor
@@ -50,11 +64,34 @@ func applyLayer(ctx context.Context, layer Layer, chain []digest.Digest, sn snap
 		return errors.Wrap(err, "failed to stat snapshot")
 	}
 
+	if sa, ok := a.(SnapshotApplier); ok {
+		if err := sa.ApplySnapshot(ctx, layer, chainID.String(), parent.String()); err != nil {
+			if snapshot.IsExist(err) {
+				if _, serr := sn.Stat(ctx, chainID.String()); serr == nil {
+					log.G(ctx).Debugf("Extraction not needed, layer snapshot shared")
+					return nil
+				}
+			}
+			return errors.Wrapf(err, "failed to extract layer %s", layer.Diff.Digest)
+		}
+		return nil
+	}
+
 	key := fmt.Sprintf("extract %s", chainID)
 
-	// Prepare snapshot with from parent
-	mounts, err := sn.Prepare(ctx, key, parent.String())
+	// Prepare snapshot with from parent, the snapshotter may share the
+	// layer snapshot from another namespace instead, if it is known to hold
+	// the layers of the chain ID.
+	mounts, err := sn.Prepare(ctx, key, parent.String(), snapshot.WithLabels(map[string]string{
+		snapshot.LabelSnapshotRef: chainID.String(),
+	}))
 	if err != nil {
+		if snapshot.IsExist(err) {
+			if _, serr := sn.Stat(ctx, chainID.String()); serr == nil {
+				log.G(ctx).Debugf("Extraction not needed, layer snapshot shared")
+				return nil
+			}
+		}
 		//TODO: If is snapshot exists error, retry
 		return errors.Wrap(err, "failed to prepare extraction layer")
 	}
@@ -0,0 +1,126 @@
+package rootfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/naive"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// testApplier applies layers to naive snapshots like the daemon does, writing
+// the blob digest to the file "layer" and recording the diff.
+type testApplier struct {
+	sn      snapshot.Snapshotter
+	applied int
+}
+
+func (a *testApplier) Apply(ctx context.Context, desc ocispec.Descriptor, mounts []mount.Mount) (ocispec.Descriptor, error) {
+	a.applied++
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "layer"), []byte(desc.Digest), 0644); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return ocispec.Descriptor{Digest: desc.Digest}, nil
+}
+
+func (a *testApplier) ApplySnapshot(ctx context.Context, layer Layer, name, parent string) error {
+	key := "apply " + name
+	mounts, err := a.sn.Prepare(ctx, key, parent, snapshot.WithLabels(map[string]string{
+		snapshot.LabelSnapshotRef: name,
+	}))
+	if err != nil {
+		return err
+	}
+	diff, err := a.Apply(ctx, layer.Blob, mounts)
+	if err != nil {
+		return err
+	}
+	if diff.Digest != layer.Diff.Digest {
+		return errors.Errorf("unexpected diff %s", diff.Digest)
+	}
+	if err := a.sn.(snapshot.DiffRecorder).RecordDiff(ctx, key, diff.Digest); err != nil {
+		return err
+	}
+	return a.sn.Commit(ctx, name, key)
+}
+
+func TestApplyLayersForgedShare(t *testing.T) {
+	root, err := ioutil.TempDir("", "rootfs-apply-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	sn, err := naive.NewSnapshotter(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ctx    = context.Background()
+		tenant = namespaces.WithNamespace(ctx, "tenant-a")
+		victim = namespaces.WithNamespace(ctx, "tenant-b")
+		other  = namespaces.WithNamespace(ctx, "tenant-c")
+		diffID = digest.FromString("layer")
+		layers = []Layer{{
+			Diff: ocispec.Descriptor{Digest: diffID},
+			Blob: ocispec.Descriptor{Digest: diffID},
+		}}
+		a = &testApplier{sn: sn}
+	)
+
+	// tenant a commits arbitrary content named as the chain ID of the layer
+	mounts, err := sn.Prepare(tenant, "forge", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "layer"), []byte("forged"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sn.Commit(tenant, diffID.String(), "forge"); err != nil {
+		t.Fatal(err)
+	}
+
+	readLayer := func(ctx context.Context, key string) string {
+		mounts, err := sn.View(ctx, key, diffID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sn.Remove(ctx, key)
+		p, err := ioutil.ReadFile(filepath.Join(mounts[0].Source, "layer"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(p)
+	}
+
+	// the unpack of tenant b must extract the layer
+	if _, err := ApplyLayers(victim, layers, sn, a); err != nil {
+		t.Fatal(err)
+	}
+	if a.applied != 1 {
+		t.Fatalf("expected layer to be extracted, applied %d times", a.applied)
+	}
+	if p := readLayer(victim, "view"); p != diffID.String() {
+		t.Fatalf("unexpected layer content %q", p)
+	}
+
+	// the layer verified by the extraction is shared
+	if _, err := ApplyLayers(other, layers, sn, a); err != nil {
+		t.Fatal(err)
+	}
+	if a.applied != 1 {
+		t.Fatalf("expected layer to be shared, applied %d times", a.applied)
+	}
+	if p := readLayer(other, "view"); p != diffID.String() {
+		t.Fatalf("unexpected layer content %q", p)
+	}
+}
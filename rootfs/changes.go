@@ -0,0 +1,75 @@
+package rootfs
+
+import (
+	"context"
+	"os"
+
+	"github.com/containerd/containerd/fs"
+	"github.com/containerd/containerd/snapshot"
+	"github.com/pkg/errors"
+)
+
+// Change describes a file added, modified or deleted between two snapshots.
+// The size and mode are not set for deleted files.
+type Change struct {
+	Kind fs.ChangeKind
+	Path string
+	Size int64
+	Mode os.FileMode
+}
+
+// Changes calls fn for each change in the committed snapshot name relative
+// to parent, in path order. If parent is empty, the parent of name is used,
+// and if name has no parent, every file in name is reported as added.
+//
+// Read-only views of the snapshots are mounted on the local host, which
+// requires privileges.
+func Changes(ctx context.Context, sn snapshot.Snapshotter, name, parent string, fn func(Change) error) (err error) {
+	if parent == "" {
+		info, err := sn.Stat(ctx, name)
+		if err != nil {
+			return err
+		}
+		parent = info.Parent
+	}
+
+	lowerDir, unmountLower, err := mountSnapshot(ctx, sn, parent, "changes-lower-")
+	if err != nil {
+		return errors.Wrap(err, "failed to mount parent")
+	}
+	defer func() {
+		if uerr := unmountLower(); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	upperDir, unmountUpper, err := mountSnapshot(ctx, sn, name, "changes-upper-")
+	if err != nil {
+		return errors.Wrap(err, "failed to mount snapshot")
+	}
+	defer func() {
+		if uerr := unmountUpper(); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	return fs.Changes(ctx, lowerDir, upperDir, func(k fs.ChangeKind, p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if k == fs.ChangeKindUnmodified {
+			return nil
+		}
+		c := Change{
+			Kind: k,
+			Path: p,
+		}
+		if f != nil {
+			c.Mode = f.Mode()
+			if f.Mode().IsRegular() {
+				c.Size = f.Size()
+			}
+		}
+		return fn(c)
+	})
+}
@@ -0,0 +1,330 @@
+package rootfs
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/containerd/containerd/archive"
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/snapshot"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	exportManifestName = "snapshot.json"
+	exportLayerName    = "layer.tar"
+)
+
+// exportManifest describes the snapshot of an export archive.
+type exportManifest struct {
+	// Name is the name of the exported snapshot.
+	Name string `json:"name"`
+
+	// Labels are the labels of the exported snapshot.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Parents are the names of the ancestors of the snapshot, starting
+	// with its parent, that the layer is relative to. It is empty for a
+	// full export.
+	Parents []string `json:"parents,omitempty"`
+}
+
+// Export writes the committed snapshot name as an archive to w. The archive
+// is a tar holding the name, labels and parent chain of the snapshot in
+// "snapshot.json", followed by the layer tar "layer.tar". The layer holds
+// the changes relative to the parent of the snapshot, unless full is set, in
+// which case the complete filesystem of the snapshot is written and the
+// archive can be imported without a parent.
+//
+// The snapshots are mounted on the local host, which requires privileges.
+func Export(ctx context.Context, sn snapshot.Snapshotter, name string, full bool, w io.Writer) (err error) {
+	info, err := sn.Stat(ctx, name)
+	if err != nil {
+		return err
+	}
+	if info.Kind != snapshot.KindCommitted {
+		return errors.Wrapf(snapshot.ErrSnapshotNotCommitted, "cannot export %q", name)
+	}
+
+	manifest := exportManifest{
+		Name:   name,
+		Labels: info.Labels,
+	}
+	if !full {
+		for parent := info.Parent; parent != ""; {
+			manifest.Parents = append(manifest.Parents, parent)
+			pinfo, err := sn.Stat(ctx, parent)
+			if err != nil {
+				return errors.Wrapf(err, "failed to stat parent %q", parent)
+			}
+			parent = pinfo.Parent
+		}
+	}
+
+	var parent string
+	if len(manifest.Parents) > 0 {
+		parent = manifest.Parents[0]
+	}
+	lowerDir, unmountLower, err := mountSnapshot(ctx, sn, parent, "export-lower-")
+	if err != nil {
+		return errors.Wrap(err, "failed to mount parent")
+	}
+	defer func() {
+		if uerr := unmountLower(); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	upperDir, unmountUpper, err := mountSnapshot(ctx, sn, name, "export-upper-")
+	if err != nil {
+		return errors.Wrap(err, "failed to mount snapshot")
+	}
+	defer func() {
+		if uerr := unmountUpper(); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	// the size of the layer must be known before it is written to the
+	// archive
+	layer, err := ioutil.TempFile("", "export-layer-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer os.Remove(layer.Name())
+	defer layer.Close()
+
+	rc := archive.Diff(ctx, lowerDir, upperDir)
+	defer rc.Close()
+	size, err := io.Copy(layer, rc)
+	if err != nil {
+		return errors.Wrap(err, "failed to write layer")
+	}
+	if _, err := layer.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	p, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeTarFile(tw, exportManifestName, int64(len(p))); err != nil {
+		return err
+	}
+	if _, err := tw.Write(p); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	if err := writeTarFile(tw, exportLayerName, size); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, layer); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	return nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, size int64) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  time.Now(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+	return nil
+}
+
+// mountSnapshot mounts a view of the committed snapshot name on a new
+// temporary directory, named with prefix, and returns the directory. If
+// name is empty, the directory is left empty. Active snapshots are not
+// mounted, as their mounts are writable and may already be in use.
+//
+// The returned function unmounts the view and removes it and the directory.
+// If the unmount fails, an error is returned and the view and the directory
+// are left in place, as removing them would delete through the mount.
+func mountSnapshot(ctx context.Context, sn snapshot.Snapshotter, name, prefix string) (string, func() error, error) {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to create temporary directory")
+	}
+	if name == "" {
+		return dir, func() error { return os.RemoveAll(dir) }, nil
+	}
+
+	info, err := sn.Stat(ctx, name)
+	if err == nil && info.Kind != snapshot.KindCommitted {
+		err = errors.Wrapf(snapshot.ErrSnapshotNotCommitted, "cannot mount %q", name)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+
+	view := fmt.Sprintf("mount-%s-view-%d", name, time.Now().UnixNano())
+	mounts, err := sn.View(ctx, view, name)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	remove := func() error {
+		if err := sn.Remove(ctx, view); err != nil {
+			return errors.Wrapf(err, "failed to remove view %q", view)
+		}
+		return os.RemoveAll(dir)
+	}
+
+	if err := mount.MountAll(mounts, dir); err != nil {
+		if rerr := remove(); rerr != nil {
+			log.G(ctx).WithError(rerr).WithField("key", view).Warn("Failed to remove view")
+		}
+		return "", nil, err
+	}
+	return dir, func() error {
+		if err := mount.Unmount(dir, 0); err != nil {
+			return errors.Wrapf(err, "failed to unmount %s", dir)
+		}
+		return remove()
+	}, nil
+}
+
+// Import reads an archive written by Export from r and creates the active
+// snapshot key from it with the Applier. The layer is stored in the content
+// store first, its descriptor is returned.
+//
+// The layer is applied on top of parent, or if parent is empty, on top of
+// the parent the snapshot was exported with. The parent must have the
+// ancestors recorded in the archive. The snapshot gets the labels of the
+// archive, and the labels set by options, such as snapshot.WithLabels, which
+// take precedence.
+//
+// The active snapshot may be committed by the caller to recreate a committed
+// snapshot.
+func Import(ctx context.Context, sn snapshot.Snapshotter, store content.Store, a Applier, key, parent string, r io.Reader, opts ...snapshot.Opt) (ocispec.Descriptor, error) {
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to read archive")
+	}
+	if hdr.Name != exportManifestName {
+		return ocispec.Descriptor{}, errors.Errorf("unexpected %q in archive, expected %q", hdr.Name, exportManifestName)
+	}
+	var manifest exportManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to decode snapshot manifest")
+	}
+
+	if parent == "" && len(manifest.Parents) > 0 {
+		parent = manifest.Parents[0]
+	}
+	if err := checkParents(ctx, sn, parent, manifest.Parents); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	var base snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	labels := map[string]string{}
+	for k, v := range manifest.Labels {
+		labels[k] = v
+	}
+	for k, v := range base.Labels {
+		labels[k] = v
+	}
+
+	hdr, err = tr.Next()
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to read archive")
+	}
+	if hdr.Name != exportLayerName {
+		return ocispec.Descriptor{}, errors.Errorf("unexpected %q in archive, expected %q", hdr.Name, exportLayerName)
+	}
+
+	cw, err := store.Writer(ctx, fmt.Sprintf("import-%s", key), hdr.Size, "")
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to open writer")
+	}
+	defer cw.Close()
+
+	if _, err := io.Copy(cw, tr); err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to read archive")
+	}
+	dgst := cw.Digest()
+	if err := cw.Commit(hdr.Size, dgst); err != nil && !content.IsExists(err) {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to commit archive")
+	}
+
+	cinfo, err := store.Info(ctx, dgst)
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to get info from content store")
+	}
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageLayer,
+		Digest:    cinfo.Digest,
+		Size:      cinfo.Size,
+	}
+
+	mounts, err := sn.Prepare(ctx, key, parent, snapshot.WithLabels(labels))
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if _, err := a.Apply(ctx, desc, mounts); err != nil {
+		if rerr := sn.Remove(ctx, key); rerr != nil {
+			log.G(ctx).WithError(rerr).WithField("key", key).Warn("Failed to remove snapshot after failed import")
+		}
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to apply archive")
+	}
+
+	return desc, nil
+}
+
+// checkParents checks that the snapshot parent has the ancestors parents,
+// starting with parent itself. A layer exported relative to those ancestors
+// may only be applied on top of them.
+func checkParents(ctx context.Context, sn snapshot.Snapshotter, parent string, parents []string) error {
+	if len(parents) == 0 {
+		return nil
+	}
+	if parent == "" {
+		return errors.Errorf("archive must be imported on top of %q", parents[0])
+	}
+
+	var chain []string
+	for p := parent; p != ""; {
+		info, err := sn.Stat(ctx, p)
+		if err != nil {
+			return errors.Wrapf(err, "failed to stat parent %q", p)
+		}
+		chain = append(chain, p)
+		p = info.Parent
+	}
+	if len(chain) != len(parents) {
+		return errors.Errorf("parent %q has %d ancestors, archive was exported with %d", parent, len(chain), len(parents))
+	}
+	// the name of the parent itself may differ
+	for i := 1; i < len(chain); i++ {
+		if chain[i] != parents[i] {
+			return errors.Errorf("parent %q descends from %q, archive was exported on top of %q", parent, chain[i], parents[i])
+		}
+	}
+	return nil
+}
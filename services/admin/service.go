@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+
+	"github.com/boltdb/bolt"
+	api "github.com/containerd/containerd/api/services/admin"
+	"github.com/containerd/containerd/plugin"
+	"google.golang.org/grpc"
+)
+
+// backupChunkSize is the size of the parts of the database sent in each
+// response of a backup.
+const backupChunkSize = 1 << 20
+
+var _ api.AdminServer = &Service{}
+
+func init() {
+	plugin.Register("admin-grpc", &plugin.Registration{
+		Type: plugin.GRPCPlugin,
+		Init: New,
+	})
+}
+
+func New(ic *plugin.InitContext) (interface{}, error) {
+	return &Service{
+		root: ic.Root,
+		db:   ic.Meta,
+	}, nil
+}
+
+type Service struct {
+	root string
+	db   *bolt.DB
+}
+
+func (s *Service) Register(server *grpc.Server) error {
+	api.RegisterAdminServer(server, s)
+	return nil
+}
+
+// Backup copies the database to a temporary file in the root within a read
+// transaction, so that the copy is consistent while writers go on, and then
+// streams the file. The transaction is not held while the client reads.
+func (s *Service) Backup(req *api.BackupRequest, ss api.Admin_BackupServer) error {
+	f, err := ioutil.TempFile(s.root, "meta.db.backup-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	}); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(backupWriter{ss}, backupChunkSize)
+	if _, err := w.ReadFrom(f); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+type backupWriter struct {
+	ss api.Admin_BackupServer
+}
+
+func (w backupWriter) Write(p []byte) (int, error) {
+	if err := w.ss.Send(&api.BackupResponse{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
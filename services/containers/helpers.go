@@ -30,18 +30,20 @@ func containerToProto(container *containers.Container) api.Container {
 			TypeUrl: specs.Version,
 			Value:   container.Spec,
 		},
-		RootFS: container.RootFS,
+		RootFS:      container.RootFS,
+		Snapshotter: container.Snapshotter,
 	}
 }
 
 func containerFromProto(containerpb *api.Container) containers.Container {
 	return containers.Container{
-		ID:      containerpb.ID,
-		Labels:  containerpb.Labels,
-		Image:   containerpb.Image,
-		Runtime: containerpb.Runtime,
-		Spec:    containerpb.Spec.Value,
-		RootFS:  containerpb.RootFS,
+		ID:          containerpb.ID,
+		Labels:      containerpb.Labels,
+		Image:       containerpb.Image,
+		Runtime:     containerpb.Runtime,
+		Spec:        containerpb.Spec.Value,
+		RootFS:      containerpb.RootFS,
+		Snapshotter: containerpb.Snapshotter,
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/containerd/containerd/containers"
 	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/snapshot"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -16,17 +17,27 @@ func init() {
 	plugin.Register("containers-grpc", &plugin.Registration{
 		Type: plugin.GRPCPlugin,
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
-			return NewService(ic.Meta), nil
+			return NewService(ic.Meta, ic.SnapshotterName, ic.Snapshotters), nil
 		},
 	})
 }
 
 type Service struct {
-	db *bolt.DB
+	db           *bolt.DB
+	snapshotter  string
+	snapshotters map[string]snapshot.Snapshotter
 }
 
-func NewService(db *bolt.DB) api.ContainersServer {
-	return &Service{db: db}
+// NewService returns the containers service. Containers without snapshotter
+// are stored with the name of the default snapshotter, snapshotter, so that
+// they keep using it if the default changes. Containers may only use the
+// loaded snapshotters.
+func NewService(db *bolt.DB, snapshotter string, snapshotters map[string]snapshot.Snapshotter) api.ContainersServer {
+	return &Service{
+		db:           db,
+		snapshotter:  snapshotter,
+		snapshotters: snapshotters,
+	}
 }
 
 func (s *Service) Register(server *grpc.Server) error {
@@ -68,6 +79,12 @@ func (s *Service) Create(ctx context.Context, req *api.CreateContainerRequest) (
 
 	return &resp, s.withStoreUpdate(ctx, func(ctx context.Context, store containers.Store) error {
 		container := containerFromProto(&req.Container)
+		if container.Snapshotter == "" {
+			container.Snapshotter = s.snapshotter
+		}
+		if err := s.checkSnapshotter(container.Snapshotter); err != nil {
+			return err
+		}
 
 		created, err := store.Create(ctx, container)
 		if err != nil {
@@ -93,6 +110,7 @@ func (s *Service) Update(ctx context.Context, req *api.UpdateContainerRequest) (
 		if current.ID != container.ID {
 			return grpc.Errorf(codes.InvalidArgument, "container ids must match: %v != %v", current.ID, container.ID)
 		}
+		snapshotter, rootfs := current.Snapshotter, current.RootFS
 
 		// apply the field mask. If you update this code, you better follow the
 		// field mask rules in field_mask.proto. If you don't know what this
@@ -111,16 +129,31 @@ func (s *Service) Update(ctx context.Context, req *api.UpdateContainerRequest) (
 					current.Spec = container.Spec
 				case "rootfs":
 					current.RootFS = container.RootFS
+				case "snapshotter":
+					current.Snapshotter = container.Snapshotter
 				default:
 					return grpc.Errorf(codes.InvalidArgument, "cannot update %q field", path)
 				}
 			}
 		} else {
 			// no field mask present, just replace everything
+			if container.Snapshotter == "" {
+				container.Snapshotter = current.Snapshotter
+			}
 			current = container
 		}
 
-		created, err := store.Update(ctx, container)
+		if current.Snapshotter != snapshotter {
+			// the root filesystem stays in the snapshotter it was created in
+			if rootfs != "" {
+				return grpc.Errorf(codes.FailedPrecondition, "cannot change the snapshotter of container %v with a root filesystem", current.ID)
+			}
+			if err := s.checkSnapshotter(current.Snapshotter); err != nil {
+				return err
+			}
+		}
+
+		created, err := store.Update(ctx, current)
 		if err != nil {
 			return mapGRPCError(err, req.Container.ID)
 		}
@@ -130,6 +163,14 @@ func (s *Service) Update(ctx context.Context, req *api.UpdateContainerRequest) (
 	})
 }
 
+// checkSnapshotter returns an error if the snapshotter name is not loaded.
+func (s *Service) checkSnapshotter(name string) error {
+	if _, ok := s.snapshotters[name]; !ok {
+		return grpc.Errorf(codes.InvalidArgument, "snapshotter not loaded: %s", name)
+	}
+	return nil
+}
+
 func (s *Service) Delete(ctx context.Context, req *api.DeleteContainerRequest) (*empty.Empty, error) {
 	return &empty.Empty{}, s.withStoreUpdate(ctx, func(ctx context.Context, store containers.Store) error {
 		return mapGRPCError(store.Delete(ctx, req.ID), req.ID)
@@ -1,6 +1,7 @@
 package content
 
 import (
+	api "github.com/containerd/containerd/api/services/content"
 	"github.com/containerd/containerd/content"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -32,3 +33,21 @@ func serverErrorToGRPC(err error, id string) error {
 
 	return err
 }
+
+func infoToGRPC(info content.Info) api.Info {
+	return api.Info{
+		Digest:      info.Digest,
+		Size_:       info.Size,
+		CommittedAt: info.CommittedAt,
+		Labels:      info.Labels,
+	}
+}
+
+func infoFromGRPC(info api.Info) content.Info {
+	return content.Info{
+		Digest:      info.Digest,
+		Size:        info.Size_,
+		CommittedAt: info.CommittedAt,
+		Labels:      info.Labels,
+	}
+}
@@ -2,6 +2,7 @@ package content
 
 import (
 	"io"
+	"strings"
 	"sync"
 
 	"github.com/Sirupsen/logrus"
@@ -58,11 +59,32 @@ func (s *Service) Info(ctx context.Context, req *api.InfoRequest) (*api.InfoResp
 	}
 
 	return &api.InfoResponse{
-		Info: api.Info{
-			Digest:      bi.Digest,
-			Size_:       bi.Size,
-			CommittedAt: bi.CommittedAt,
-		},
+		Info: infoToGRPC(bi),
+	}, nil
+}
+
+func (s *Service) Update(ctx context.Context, req *api.UpdateRequest) (*api.UpdateResponse, error) {
+	if err := req.Info.Digest.Validate(); err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "%q failed validation", req.Info.Digest)
+	}
+
+	var fieldpaths []string
+	if req.UpdateMask != nil {
+		for _, path := range req.UpdateMask.Paths {
+			if !strings.HasPrefix(path, "labels.") {
+				return nil, grpc.Errorf(codes.InvalidArgument, "cannot update %q field", path)
+			}
+			fieldpaths = append(fieldpaths, path)
+		}
+	}
+
+	info, err := s.store.Update(ctx, infoFromGRPC(req.Info), fieldpaths...)
+	if err != nil {
+		return nil, serverErrorToGRPC(err, req.Info.Digest.String())
+	}
+
+	return &api.UpdateResponse{
+		Info: infoToGRPC(info),
 	}, nil
 }
 
@@ -78,11 +100,7 @@ func (s *Service) List(req *api.ListContentRequest, session api.Content_ListServ
 	)
 
 	if err := s.store.Walk(session.Context(), func(info content.Info) error {
-		buffer = append(buffer, api.Info{
-			Digest:      info.Digest,
-			Size_:       info.Size,
-			CommittedAt: info.CommittedAt,
-		})
+		buffer = append(buffer, infoToGRPC(info))
 
 		if len(buffer) >= 100 {
 			if err := sendBlock(buffer); err != nil {
@@ -6,6 +6,7 @@ import (
 
 	contentapi "github.com/containerd/containerd/api/services/content"
 	"github.com/containerd/containerd/content"
+	"github.com/gogo/protobuf/types"
 	digest "github.com/opencontainers/go-digest"
 )
 
@@ -27,11 +28,25 @@ func (rs *remoteStore) Info(ctx context.Context, dgst digest.Digest) (content.In
 		return content.Info{}, rewriteGRPCError(err)
 	}
 
-	return content.Info{
-		Digest:      resp.Info.Digest,
-		Size:        resp.Info.Size_,
-		CommittedAt: resp.Info.CommittedAt,
-	}, nil
+	return infoFromGRPC(resp.Info), nil
+}
+
+func (rs *remoteStore) Update(ctx context.Context, info content.Info, fieldpaths ...string) (content.Info, error) {
+	req := &contentapi.UpdateRequest{
+		Info: infoToGRPC(info),
+	}
+	if len(fieldpaths) > 0 {
+		req.UpdateMask = &types.FieldMask{
+			Paths: fieldpaths,
+		}
+	}
+
+	resp, err := rs.client.Update(ctx, req)
+	if err != nil {
+		return content.Info{}, rewriteGRPCError(err)
+	}
+
+	return infoFromGRPC(resp.Info), nil
 }
 
 func (rs *remoteStore) Walk(ctx context.Context, fn content.WalkFunc) error {
@@ -51,11 +66,7 @@ func (rs *remoteStore) Walk(ctx context.Context, fn content.WalkFunc) error {
 		}
 
 		for _, info := range msg.Info {
-			if err := fn(content.Info{
-				Digest:      info.Digest,
-				Size:        info.Size_,
-				CommittedAt: info.CommittedAt,
-			}); err != nil {
+			if err := fn(infoFromGRPC(info)); err != nil {
 				return err
 			}
 		}
@@ -6,25 +6,39 @@ import (
 	mounttypes "github.com/containerd/containerd/api/types/mount"
 	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/rootfs"
+	"github.com/containerd/containerd/snapshot"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 type DiffService interface {
-	rootfs.Applier
+	rootfs.SnapshotApplier
 	rootfs.MountDiffer
 }
 
 // NewApplierFromClient returns a new Applier which communicates
 // over a GRPC connection.
 func NewDiffServiceFromClient(client diffapi.DiffClient) DiffService {
+	return NewSnapshotDiffServiceFromClient(client, "")
+}
+
+// NewSnapshotDiffServiceFromClient returns a new DiffService which unpacks
+// layers to snapshots of the snapshotter loaded as snapshotterName by the
+// daemon, see rootfs.SnapshotApplier. The default snapshotter of the daemon
+// is used if snapshotterName is empty.
+func NewSnapshotDiffServiceFromClient(client diffapi.DiffClient, snapshotterName string) DiffService {
 	return &remote{
-		client: client,
+		client:          client,
+		snapshotterName: snapshotterName,
 	}
 }
 
 type remote struct {
-	client diffapi.DiffClient
+	client          diffapi.DiffClient
+	snapshotterName string
 }
 
 func (r *remote) Apply(ctx context.Context, diff ocispec.Descriptor, mounts []mount.Mount) (ocispec.Descriptor, error) {
@@ -39,6 +53,23 @@ func (r *remote) Apply(ctx context.Context, diff ocispec.Descriptor, mounts []mo
 	return toDescriptor(resp.Applied), nil
 }
 
+func (r *remote) ApplySnapshot(ctx context.Context, layer rootfs.Layer, name, parent string) error {
+	req := &diffapi.ApplyRequest{
+		Diff:        fromDescriptor(layer.Blob),
+		Snapshotter: r.snapshotterName,
+		Name:        name,
+		Parent:      parent,
+		DiffID:      layer.Diff.Digest,
+	}
+	if _, err := r.client.Apply(ctx, req); err != nil {
+		if grpc.Code(err) == codes.AlreadyExists {
+			return errors.Wrapf(snapshot.ErrSnapshotExist, "snapshot %q", name)
+		}
+		return err
+	}
+	return nil
+}
+
 func (r *remote) DiffMounts(ctx context.Context, a, b []mount.Mount, media, ref string) (ocispec.Descriptor, error) {
 	req := &diffapi.DiffRequest{
 		Left:      fromMounts(a),
@@ -1,12 +1,21 @@
 package diff
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
 	diffapi "github.com/containerd/containerd/api/services/diff"
 	mounttypes "github.com/containerd/containerd/api/types/mount"
+	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/mount"
 	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/snapshot"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 func init() {
@@ -14,14 +23,18 @@ func init() {
 		Type: plugin.GRPCPlugin,
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
 			return &service{
-				diff: ic.Differ,
+				diff:         ic.Differ,
+				snapshotter:  ic.Snapshotter,
+				snapshotters: ic.Snapshotters,
 			}, nil
 		},
 	})
 }
 
 type service struct {
-	diff plugin.Differ
+	diff         plugin.Differ
+	snapshotter  snapshot.Snapshotter
+	snapshotters map[string]snapshot.Snapshotter
 }
 
 func (s *service) Register(gs *grpc.Server) error {
@@ -33,17 +46,99 @@ func (s *service) Apply(ctx context.Context, er *diffapi.ApplyRequest) (*diffapi
 	desc := toDescriptor(er.Diff)
 	// TODO: Check for supported media types
 
-	mounts := toMounts(er.Mounts)
+	if er.Name != "" {
+		return s.applySnapshot(ctx, desc, er)
+	}
+
+	ocidesc, err := s.diff.Apply(ctx, desc, toMounts(er.Mounts))
+	if err != nil {
+		return nil, err
+	}
+
+	return &diffapi.ApplyResponse{
+		Applied: fromDescriptor(ocidesc),
+	}, nil
+
+}
+
+// applySnapshot unpacks desc to a snapshot prepared by the daemon on top of
+// the parent of the request and commits it as the name of the request. The
+// active snapshot is never handed to the client, so the diff recorded by the
+// snapshotter is known to be the content of the committed snapshot.
+func (s *service) applySnapshot(ctx context.Context, desc ocispec.Descriptor, er *diffapi.ApplyRequest) (_ *diffapi.ApplyResponse, err error) {
+	sn, err := s.getSnapshotter(er.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := applyKey(er.Name)
+	if err != nil {
+		return nil, err
+	}
+	mounts, err := sn.Prepare(ctx, key, er.Parent, snapshot.WithLabels(map[string]string{
+		snapshot.LabelSnapshotRef: er.Name,
+	}))
+	if err != nil {
+		if snapshot.IsExist(err) {
+			if _, serr := sn.Stat(ctx, er.Name); serr == nil {
+				return nil, grpc.Errorf(codes.AlreadyExists, "snapshot %q already exists", er.Name)
+			}
+		}
+		return nil, errors.Wrap(err, "failed to prepare snapshot")
+	}
+	defer func() {
+		if err != nil {
+			if rerr := sn.Remove(ctx, key); rerr != nil {
+				log.G(ctx).WithError(rerr).WithField("key", key).Warn("failed to remove snapshot after apply failure")
+			}
+		}
+	}()
 
 	ocidesc, err := s.diff.Apply(ctx, desc, mounts)
 	if err != nil {
 		return nil, err
 	}
+	if er.DiffID != "" && ocidesc.Digest != er.DiffID {
+		return nil, grpc.Errorf(codes.InvalidArgument, "applied diff %s, expected %s", ocidesc.Digest, er.DiffID)
+	}
+
+	if r, ok := sn.(snapshot.DiffRecorder); ok {
+		if err := r.RecordDiff(ctx, key, ocidesc.Digest); err != nil {
+			return nil, errors.Wrapf(err, "failed to record diff of %q", er.Name)
+		}
+	}
+	if err := sn.Commit(ctx, er.Name, key); err != nil {
+		if snapshot.IsExist(err) {
+			return nil, grpc.Errorf(codes.AlreadyExists, "snapshot %q already exists", er.Name)
+		}
+		return nil, errors.Wrapf(err, "failed to commit snapshot %q", er.Name)
+	}
 
 	return &diffapi.ApplyResponse{
 		Applied: fromDescriptor(ocidesc),
 	}, nil
+}
 
+// applyKey returns a unique key for the active snapshot name is unpacked to.
+func applyKey(name string) (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("apply-%s %s", hex.EncodeToString(b[:]), name), nil
+}
+
+// getSnapshotter returns the snapshotter loaded as name, or the default
+// snapshotter if name is empty.
+func (s *service) getSnapshotter(name string) (snapshot.Snapshotter, error) {
+	if name == "" {
+		return s.snapshotter, nil
+	}
+	sn, ok := s.snapshotters[name]
+	if !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "snapshotter not loaded: %s", name)
+	}
+	return sn, nil
 }
 
 func (s *service) Diff(ctx context.Context, dr *diffapi.DiffRequest) (*diffapi.DiffResponse, error) {
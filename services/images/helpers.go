@@ -1,11 +1,14 @@
 package images
 
 import (
+	"strings"
+
 	imagesapi "github.com/containerd/containerd/api/services/images"
 	"github.com/containerd/containerd/api/types/descriptor"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/metadata"
 	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/remotes/policy"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -72,6 +75,10 @@ func rewriteGRPCError(err error) error {
 		return metadata.ErrExists
 	case codes.NotFound:
 		return metadata.ErrNotFound
+	case codes.PermissionDenied:
+		// the description already ends with the message of ErrDenied
+		desc := strings.TrimSuffix(grpc.ErrorDesc(err), ": "+policy.ErrDenied.Error())
+		return errors.Wrap(policy.ErrDenied, desc)
 	}
 
 	return err
@@ -85,6 +92,8 @@ func mapGRPCError(err error, id string) error {
 		return grpc.Errorf(codes.AlreadyExists, "image %v already exists", id)
 	case namespaces.IsNamespaceRequired(err):
 		return grpc.Errorf(codes.InvalidArgument, "namespace required, please set %q header", namespaces.GRPCHeader)
+	case policy.IsDenied(err):
+		return grpc.Errorf(codes.PermissionDenied, "image %v not admitted: %v", id, err)
 	}
 
 	return err
@@ -5,7 +5,9 @@ import (
 	imagesapi "github.com/containerd/containerd/api/services/images"
 	"github.com/containerd/containerd/images"
 	"github.com/containerd/containerd/metadata"
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/remotes/policy"
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
@@ -13,19 +15,26 @@ import (
 
 func init() {
 	plugin.Register("images-grpc", &plugin.Registration{
-		Type: plugin.GRPCPlugin,
+		Type:   plugin.GRPCPlugin,
+		Config: &policy.Config{},
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
-			return NewService(ic.Meta), nil
+			return NewService(ic.Meta, ic.Config.(*policy.Config)), nil
 		},
 	})
 }
 
 type Service struct {
-	db *bolt.DB
+	db     *bolt.DB
+	policy *policy.Config
 }
 
-func NewService(db *bolt.DB) imagesapi.ImagesServer {
-	return &Service{db: db}
+// NewService returns the images service. Images are only registered if their
+// name is admitted by the policy, which may be nil to admit all images.
+func NewService(db *bolt.DB, p *policy.Config) imagesapi.ImagesServer {
+	if p == nil {
+		p = &policy.Config{}
+	}
+	return &Service{db: db, policy: p}
 }
 
 func (s *Service) Register(server *grpc.Server) error {
@@ -48,11 +57,29 @@ func (s *Service) Get(ctx context.Context, req *imagesapi.GetRequest) (*imagesap
 }
 
 func (s *Service) Put(ctx context.Context, req *imagesapi.PutRequest) (*empty.Empty, error) {
-	return &empty.Empty{}, s.withStoreUpdate(ctx, func(ctx context.Context, store images.Store) error {
+	return &empty.Empty{}, s.db.Update(func(tx *bolt.Tx) error {
+		if err := s.admit(ctx, tx, req.Image.Name); err != nil {
+			return mapGRPCError(err, req.Image.Name)
+		}
+		store := metadata.NewImageStore(tx)
 		return mapGRPCError(store.Put(ctx, req.Image.Name, descFromProto(&req.Image.Target)), req.Image.Name)
 	})
 }
 
+// admit checks the image name against the policy of the daemon and the
+// labels of the namespace.
+func (s *Service) admit(ctx context.Context, tx *bolt.Tx, name string) error {
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return err
+	}
+	labels, err := metadata.NewNamespaceStore(tx).Labels(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	return s.policy.Check(ctx, name, labels)
+}
+
 func (s *Service) List(ctx context.Context, _ *imagesapi.ListRequest) (*imagesapi.ListResponse, error) {
 	var resp imagesapi.ListResponse
 
@@ -3,31 +3,39 @@ package snapshot
 import (
 	"context"
 	"io"
+	"os"
 	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	snapshotapi "github.com/containerd/containerd/api/services/snapshot"
+	"github.com/containerd/containerd/fs"
 	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/rootfs"
 	"github.com/containerd/containerd/snapshot"
+	"github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 )
 
 // NewSnapshotterFromClient returns a new Snapshotter which communicates
-// over a GRPC connection.
-func NewSnapshotterFromClient(client snapshotapi.SnapshotClient) snapshot.Snapshotter {
+// over a GRPC connection with the snapshotter loaded as snapshotterName. The
+// default snapshotter of the daemon is used if snapshotterName is empty.
+func NewSnapshotterFromClient(client snapshotapi.SnapshotClient, snapshotterName string) snapshot.Snapshotter {
 	return &remoteSnapshotter{
-		client: client,
+		client:          client,
+		snapshotterName: snapshotterName,
 	}
 }
 
 type remoteSnapshotter struct {
-	client snapshotapi.SnapshotClient
+	client          snapshotapi.SnapshotClient
+	snapshotterName string
 }
 
 func (r *remoteSnapshotter) Stat(ctx context.Context, key string) (snapshot.Info, error) {
-	resp, err := r.client.Stat(ctx, &snapshotapi.StatRequest{Key: key})
+	resp, err := r.client.Stat(ctx, &snapshotapi.StatRequest{Snapshotter: r.snapshotterName, Key: key})
 	if err != nil {
 		return snapshot.Info{}, rewriteGRPCError(err)
 	}
@@ -35,7 +43,7 @@ func (r *remoteSnapshotter) Stat(ctx context.Context, key string) (snapshot.Info
 }
 
 func (r *remoteSnapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
-	resp, err := r.client.Usage(ctx, &snapshotapi.UsageRequest{Key: key})
+	resp, err := r.client.Usage(ctx, &snapshotapi.UsageRequest{Snapshotter: r.snapshotterName, Key: key})
 	if err != nil {
 		return snapshot.Usage{}, rewriteGRPCError(err)
 	}
@@ -43,44 +51,82 @@ func (r *remoteSnapshotter) Usage(ctx context.Context, key string) (snapshot.Usa
 }
 
 func (r *remoteSnapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, error) {
-	resp, err := r.client.Mounts(ctx, &snapshotapi.MountsRequest{Key: key})
+	resp, err := r.client.Mounts(ctx, &snapshotapi.MountsRequest{Snapshotter: r.snapshotterName, Key: key})
 	if err != nil {
 		return nil, rewriteGRPCError(err)
 	}
 	return toMounts(resp), nil
 }
 
-func (r *remoteSnapshotter) Prepare(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	resp, err := r.client.Prepare(ctx, &snapshotapi.PrepareRequest{Key: key, Parent: parent})
+func (r *remoteSnapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (snapshot.Info, error) {
+	resp, err := r.client.Update(ctx, &snapshotapi.UpdateSnapshotRequest{
+		Snapshotter: r.snapshotterName,
+		Info:        fromInfo(info),
+		UpdateMask: &types.FieldMask{
+			Paths: fieldpaths,
+		},
+	})
+	if err != nil {
+		return snapshot.Info{}, rewriteGRPCError(err)
+	}
+	return toInfo(resp.Info), nil
+}
+
+func (r *remoteSnapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	info, err := applyOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Prepare(ctx, &snapshotapi.PrepareRequest{
+		Snapshotter: r.snapshotterName,
+		Key:         key,
+		Parent:      parent,
+		Labels:      info.Labels,
+	})
 	if err != nil {
 		return nil, rewriteGRPCError(err)
 	}
 	return toMounts(resp), nil
 }
 
-func (r *remoteSnapshotter) View(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	resp, err := r.client.View(ctx, &snapshotapi.PrepareRequest{Key: key, Parent: parent})
+func (r *remoteSnapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	info, err := applyOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.View(ctx, &snapshotapi.PrepareRequest{
+		Snapshotter: r.snapshotterName,
+		Key:         key,
+		Parent:      parent,
+		Labels:      info.Labels,
+	})
 	if err != nil {
 		return nil, rewriteGRPCError(err)
 	}
 	return toMounts(resp), nil
 }
 
-func (r *remoteSnapshotter) Commit(ctx context.Context, name, key string) error {
-	_, err := r.client.Commit(ctx, &snapshotapi.CommitRequest{
-		Name: name,
-		Key:  key,
+func (r *remoteSnapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) error {
+	info, err := applyOpts(opts)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.Commit(ctx, &snapshotapi.CommitRequest{
+		Snapshotter: r.snapshotterName,
+		Name:        name,
+		Key:         key,
+		Labels:      info.Labels,
 	})
 	return rewriteGRPCError(err)
 }
 
 func (r *remoteSnapshotter) Remove(ctx context.Context, key string) error {
-	_, err := r.client.Remove(ctx, &snapshotapi.RemoveRequest{Key: key})
+	_, err := r.client.Remove(ctx, &snapshotapi.RemoveRequest{Snapshotter: r.snapshotterName, Key: key})
 	return rewriteGRPCError(err)
 }
 
 func (r *remoteSnapshotter) Walk(ctx context.Context, fn func(context.Context, snapshot.Info) error) error {
-	sc, err := r.client.List(ctx, &snapshotapi.ListRequest{})
+	sc, err := r.client.List(ctx, &snapshotapi.ListRequest{Snapshotter: r.snapshotterName})
 	if err != nil {
 		rewriteGRPCError(err)
 	}
@@ -103,6 +149,92 @@ func (r *remoteSnapshotter) Walk(ctx context.Context, fn func(context.Context, s
 	}
 }
 
+// Check reports the snapshots of the namespace of ctx whose storage is
+// missing. Snapshots are only repaired by the daemon, when the snapshotter is
+// loaded, so repair must be false.
+func (r *remoteSnapshotter) Check(ctx context.Context, repair bool) (snapshot.CheckResult, error) {
+	if repair {
+		return snapshot.CheckResult{}, errors.New("snapshots are only repaired by the daemon on startup")
+	}
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	resp, err := r.client.Check(ctx, &snapshotapi.CheckRequest{Snapshotter: r.snapshotterName})
+	if err != nil {
+		return snapshot.CheckResult{}, rewriteGRPCError(err)
+	}
+	var result snapshot.CheckResult
+	for _, key := range resp.Dangling {
+		result.Dangling = append(result.Dangling, namespace+"/"+key)
+	}
+	return result, nil
+}
+
+// ListUsage returns the usage of the snapshots identified by keys, or of all
+// snapshots if no keys are provided, with a single request to the snapshotter
+// loaded as snapshotterName.
+func ListUsage(ctx context.Context, client snapshotapi.SnapshotClient, snapshotterName string, keys ...string) (map[string]snapshot.Usage, error) {
+	sc, err := client.ListUsage(ctx, &snapshotapi.ListUsageRequest{Snapshotter: snapshotterName, Keys: keys})
+	if err != nil {
+		return nil, rewriteGRPCError(err)
+	}
+	usage := map[string]snapshot.Usage{}
+	for {
+		resp, err := sc.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return usage, nil
+			}
+			return nil, rewriteGRPCError(err)
+		}
+		for _, u := range resp.Usage {
+			usage[u.Key] = snapshot.Usage{
+				Inodes: u.Inodes,
+				Size:   u.Size_,
+			}
+		}
+	}
+}
+
+// Changes calls fn for each filesystem change in the snapshot key relative
+// to parent, or to the parent of key if parent is empty. The changes are
+// computed by the snapshotter loaded as snapshotterName.
+func Changes(ctx context.Context, client snapshotapi.SnapshotClient, snapshotterName, key, parent string, fn func(rootfs.Change) error) error {
+	sc, err := client.Changes(ctx, &snapshotapi.ChangesRequest{
+		Snapshotter: snapshotterName,
+		Key:         key,
+		Parent:      parent,
+	})
+	if err != nil {
+		return rewriteGRPCError(err)
+	}
+	for {
+		resp, err := sc.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return rewriteGRPCError(err)
+		}
+		for _, c := range resp.Changes {
+			if err := fn(toChange(c)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func applyOpts(opts []snapshot.Opt) (snapshot.Info, error) {
+	var info snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&info); err != nil {
+			return snapshot.Info{}, err
+		}
+	}
+	return info, nil
+}
+
 func rewriteGRPCError(err error) error {
 	switch grpc.Code(errors.Cause(err)) {
 	case codes.AlreadyExists:
@@ -135,6 +267,9 @@ func toInfo(info snapshotapi.Info) snapshot.Info {
 		Parent:   info.Parent,
 		Kind:     toKind(info.Kind),
 		Readonly: info.Readonly,
+		Labels:   info.Labels,
+		Created:  info.CreatedAt,
+		Updated:  info.UpdatedAt,
 	}
 }
 
@@ -145,6 +280,24 @@ func toUsage(resp *snapshotapi.UsageResponse) snapshot.Usage {
 	}
 }
 
+func toChange(c snapshotapi.Change) rootfs.Change {
+	var kind fs.ChangeKind
+	switch c.Kind {
+	case snapshotapi.ChangeKindModify:
+		kind = fs.ChangeKindModify
+	case snapshotapi.ChangeKindDelete:
+		kind = fs.ChangeKindDelete
+	default:
+		kind = fs.ChangeKindAdd
+	}
+	return rootfs.Change{
+		Kind: kind,
+		Path: c.Path,
+		Size: c.Size_,
+		Mode: os.FileMode(c.Mode),
+	}
+}
+
 func toMounts(resp *snapshotapi.MountsResponse) []mount.Mount {
 	mounts := make([]mount.Mount, len(resp.Mounts))
 	for i, m := range resp.Mounts {
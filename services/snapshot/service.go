@@ -2,12 +2,16 @@ package snapshot
 
 import (
 	gocontext "context"
+	"strings"
 
 	snapshotapi "github.com/containerd/containerd/api/services/snapshot"
 	mounttypes "github.com/containerd/containerd/api/types/mount"
+	"github.com/containerd/containerd/fs"
 	"github.com/containerd/containerd/log"
 	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/rootfs"
 	"github.com/containerd/containerd/snapshot"
 	protoempty "github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
@@ -19,7 +23,7 @@ func init() {
 	plugin.Register("snapshots-grpc", &plugin.Registration{
 		Type: plugin.GRPCPlugin,
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
-			return newService(ic.Snapshotter)
+			return newService(ic.Snapshotter, ic.Snapshotters)
 		},
 	})
 }
@@ -27,12 +31,14 @@ func init() {
 var empty = &protoempty.Empty{}
 
 type service struct {
-	snapshotter snapshot.Snapshotter
+	snapshotter  snapshot.Snapshotter
+	snapshotters map[string]snapshot.Snapshotter
 }
 
-func newService(snapshotter snapshot.Snapshotter) (*service, error) {
+func newService(snapshotter snapshot.Snapshotter, snapshotters map[string]snapshot.Snapshotter) (*service, error) {
 	return &service{
-		snapshotter: snapshotter,
+		snapshotter:  snapshotter,
+		snapshotters: snapshotters,
 	}, nil
 }
 
@@ -41,11 +47,30 @@ func (s *service) Register(gs *grpc.Server) error {
 	return nil
 }
 
+// getSnapshotter returns the snapshotter loaded as name, or the default
+// snapshotter if name is empty.
+func (s *service) getSnapshotter(name string) (snapshot.Snapshotter, error) {
+	if name == "" {
+		return s.snapshotter, nil
+	}
+	sn, ok := s.snapshotters[name]
+	if !ok {
+		return nil, grpc.Errorf(codes.InvalidArgument, "snapshotter not loaded: %s", name)
+	}
+	return sn, nil
+}
+
 func (s *service) Prepare(ctx context.Context, pr *snapshotapi.PrepareRequest) (*snapshotapi.MountsResponse, error) {
 	log.G(ctx).WithField("parent", pr.Parent).WithField("key", pr.Key).Debugf("Preparing snapshot")
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(pr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Lookup snapshot id from metadata store
-	mounts, err := s.snapshotter.Prepare(ctx, pr.Key, pr.Parent)
+	mounts, err := sn.Prepare(ctx, pr.Key, pr.Parent, snapshot.WithLabels(pr.Labels))
 	if err != nil {
 		return nil, grpcError(err)
 	}
@@ -54,9 +79,15 @@ func (s *service) Prepare(ctx context.Context, pr *snapshotapi.PrepareRequest) (
 
 func (s *service) View(ctx context.Context, pr *snapshotapi.PrepareRequest) (*snapshotapi.MountsResponse, error) {
 	log.G(ctx).WithField("parent", pr.Parent).WithField("key", pr.Key).Debugf("Preparing view snapshot")
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(pr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Lookup snapshot id from metadata store
-	mounts, err := s.snapshotter.View(ctx, pr.Key, pr.Parent)
+	mounts, err := sn.View(ctx, pr.Key, pr.Parent, snapshot.WithLabels(pr.Labels))
 	if err != nil {
 		return nil, grpcError(err)
 	}
@@ -65,9 +96,15 @@ func (s *service) View(ctx context.Context, pr *snapshotapi.PrepareRequest) (*sn
 
 func (s *service) Mounts(ctx context.Context, mr *snapshotapi.MountsRequest) (*snapshotapi.MountsResponse, error) {
 	log.G(ctx).WithField("key", mr.Key).Debugf("Getting snapshot mounts")
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(mr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Lookup snapshot id from metadata store
-	mounts, err := s.snapshotter.Mounts(ctx, mr.Key)
+	mounts, err := sn.Mounts(ctx, mr.Key)
 	if err != nil {
 		return nil, grpcError(err)
 	}
@@ -76,9 +113,15 @@ func (s *service) Mounts(ctx context.Context, mr *snapshotapi.MountsRequest) (*s
 
 func (s *service) Commit(ctx context.Context, cr *snapshotapi.CommitRequest) (*protoempty.Empty, error) {
 	log.G(ctx).WithField("key", cr.Key).WithField("name", cr.Name).Debugf("Committing snapshot")
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(cr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Lookup snapshot id from metadata store
-	if err := s.snapshotter.Commit(ctx, cr.Name, cr.Key); err != nil {
+	if err := sn.Commit(ctx, cr.Name, cr.Key, snapshot.WithLabels(cr.Labels)); err != nil {
 		return nil, grpcError(err)
 	}
 	return empty, nil
@@ -86,9 +129,15 @@ func (s *service) Commit(ctx context.Context, cr *snapshotapi.CommitRequest) (*p
 
 func (s *service) Remove(ctx context.Context, rr *snapshotapi.RemoveRequest) (*protoempty.Empty, error) {
 	log.G(ctx).WithField("key", rr.Key).Debugf("Removing snapshot")
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(rr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
 	// TODO: Lookup snapshot id from metadata store
-	if err := s.snapshotter.Remove(ctx, rr.Key); err != nil {
+	if err := sn.Remove(ctx, rr.Key); err != nil {
 		return nil, grpcError(err)
 	}
 	return empty, nil
@@ -96,8 +145,14 @@ func (s *service) Remove(ctx context.Context, rr *snapshotapi.RemoveRequest) (*p
 
 func (s *service) Stat(ctx context.Context, sr *snapshotapi.StatRequest) (*snapshotapi.StatResponse, error) {
 	log.G(ctx).WithField("key", sr.Key).Debugf("Statting snapshot")
-	// TODO: Apply namespace
-	info, err := s.snapshotter.Stat(ctx, sr.Key)
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(sr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
+	info, err := sn.Stat(ctx, sr.Key)
 	if err != nil {
 		return nil, grpcError(err)
 	}
@@ -105,8 +160,37 @@ func (s *service) Stat(ctx context.Context, sr *snapshotapi.StatRequest) (*snaps
 	return &snapshotapi.StatResponse{Info: fromInfo(info)}, nil
 }
 
+func (s *service) Update(ctx context.Context, sr *snapshotapi.UpdateSnapshotRequest) (*snapshotapi.UpdateSnapshotResponse, error) {
+	log.G(ctx).WithField("key", sr.Info.Name).Debugf("Updating snapshot")
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(sr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldpaths []string
+	if sr.UpdateMask != nil {
+		fieldpaths = sr.UpdateMask.Paths
+	}
+
+	info, err := sn.Update(ctx, toInfo(sr.Info), fieldpaths...)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &snapshotapi.UpdateSnapshotResponse{Info: fromInfo(info)}, nil
+}
+
 func (s *service) List(sr *snapshotapi.ListRequest, ss snapshotapi.Snapshot_ListServer) error {
-	// TODO: Apply namespace
+	if _, err := namespaces.NamespaceRequired(ss.Context()); err != nil {
+		return grpcError(err)
+	}
+	sn, err := s.getSnapshotter(sr.Snapshotter)
+	if err != nil {
+		return err
+	}
 
 	var (
 		buffer    []snapshotapi.Info
@@ -116,7 +200,7 @@ func (s *service) List(sr *snapshotapi.ListRequest, ss snapshotapi.Snapshot_List
 			})
 		}
 	)
-	err := s.snapshotter.Walk(ss.Context(), func(ctx gocontext.Context, info snapshot.Info) error {
+	err = sn.Walk(ss.Context(), func(ctx gocontext.Context, info snapshot.Info) error {
 		buffer = append(buffer, fromInfo(info))
 
 		if len(buffer) >= 100 {
@@ -143,8 +227,14 @@ func (s *service) List(sr *snapshotapi.ListRequest, ss snapshotapi.Snapshot_List
 }
 
 func (s *service) Usage(ctx context.Context, ur *snapshotapi.UsageRequest) (*snapshotapi.UsageResponse, error) {
-	// TODO: Apply namespace
-	usage, err := s.snapshotter.Usage(ctx, ur.Key)
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return nil, grpcError(err)
+	}
+	sn, err := s.getSnapshotter(ur.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := sn.Usage(ctx, ur.Key)
 	if err != nil {
 		return nil, grpcError(err)
 	}
@@ -152,7 +242,143 @@ func (s *service) Usage(ctx context.Context, ur *snapshotapi.UsageRequest) (*sna
 	return fromUsage(usage), nil
 }
 
+func (s *service) ListUsage(sr *snapshotapi.ListUsageRequest, ss snapshotapi.Snapshot_ListUsageServer) error {
+	ctx := ss.Context()
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return grpcError(err)
+	}
+	sn, err := s.getSnapshotter(sr.Snapshotter)
+	if err != nil {
+		return err
+	}
+
+	keys := sr.Keys
+	if len(keys) == 0 {
+		// collect the keys first, usage may not be queried while walking
+		if err := sn.Walk(ctx, func(ctx gocontext.Context, info snapshot.Info) error {
+			keys = append(keys, info.Name)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	var (
+		buffer    []snapshotapi.SnapshotUsage
+		sendBlock = func(block []snapshotapi.SnapshotUsage) error {
+			return ss.Send(&snapshotapi.ListUsageResponse{
+				Usage: block,
+			})
+		}
+	)
+	for _, key := range keys {
+		usage, err := sn.Usage(ctx, key)
+		if err != nil {
+			return grpcError(err)
+		}
+		buffer = append(buffer, snapshotapi.SnapshotUsage{
+			Key:    key,
+			Inodes: usage.Inodes,
+			Size_:  usage.Size,
+		})
+
+		if len(buffer) >= 100 {
+			if err := sendBlock(buffer); err != nil {
+				return err
+			}
+
+			buffer = buffer[:0]
+		}
+	}
+	if len(buffer) > 0 {
+		// Send remaining usage
+		if err := sendBlock(buffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) Check(ctx context.Context, cr *snapshotapi.CheckRequest) (*snapshotapi.CheckResponse, error) {
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	log.G(ctx).Debugf("Checking snapshots")
+	sn, err := s.getSnapshotter(cr.Snapshotter)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := sn.(snapshot.Checker)
+	if !ok {
+		return nil, grpc.Errorf(codes.Unimplemented, "snapshotter does not support checks")
+	}
+
+	// orphans are only repaired by the daemon, see checkSnapshots in
+	// cmd/containerd, and only the snapshots of the namespace are reported
+	result, err := checker.Check(ctx, false)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	var resp snapshotapi.CheckResponse
+	for _, ref := range result.Dangling {
+		if strings.HasPrefix(ref, namespace+"/") {
+			resp.Dangling = append(resp.Dangling, strings.TrimPrefix(ref, namespace+"/"))
+		}
+	}
+	return &resp, nil
+}
+
+func (s *service) Changes(cr *snapshotapi.ChangesRequest, ss snapshotapi.Snapshot_ChangesServer) error {
+	ctx := ss.Context()
+	if _, err := namespaces.NamespaceRequired(ctx); err != nil {
+		return grpcError(err)
+	}
+	sn, err := s.getSnapshotter(cr.Snapshotter)
+	if err != nil {
+		return err
+	}
+
+	var (
+		buffer    []snapshotapi.Change
+		sendBlock = func(block []snapshotapi.Change) error {
+			return ss.Send(&snapshotapi.ChangesResponse{
+				Changes: block,
+			})
+		}
+	)
+	err = rootfs.Changes(ctx, sn, cr.Key, cr.Parent, func(c rootfs.Change) error {
+		buffer = append(buffer, fromChange(c))
+
+		if len(buffer) >= 100 {
+			if err := sendBlock(buffer); err != nil {
+				return err
+			}
+
+			buffer = buffer[:0]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	if len(buffer) > 0 {
+		// Send remaining changes
+		if err := sendBlock(buffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func grpcError(err error) error {
+	if namespaces.IsNamespaceRequired(err) {
+		return grpc.Errorf(codes.InvalidArgument, "namespace required, please set %q header", namespaces.GRPCHeader)
+	}
 	if snapshot.IsNotExist(err) {
 		return grpc.Errorf(codes.NotFound, err.Error())
 	}
@@ -175,10 +401,13 @@ func fromKind(kind snapshot.Kind) snapshotapi.Kind {
 
 func fromInfo(info snapshot.Info) snapshotapi.Info {
 	return snapshotapi.Info{
-		Name:     info.Name,
-		Parent:   info.Parent,
-		Kind:     fromKind(info.Kind),
-		Readonly: info.Readonly,
+		Name:      info.Name,
+		Parent:    info.Parent,
+		Kind:      fromKind(info.Kind),
+		Readonly:  info.Readonly,
+		Labels:    info.Labels,
+		CreatedAt: info.Created,
+		UpdatedAt: info.Updated,
 	}
 }
 
@@ -189,6 +418,24 @@ func fromUsage(usage snapshot.Usage) *snapshotapi.UsageResponse {
 	}
 }
 
+func fromChange(c rootfs.Change) snapshotapi.Change {
+	var kind snapshotapi.ChangeKind
+	switch c.Kind {
+	case fs.ChangeKindModify:
+		kind = snapshotapi.ChangeKindModify
+	case fs.ChangeKindDelete:
+		kind = snapshotapi.ChangeKindDelete
+	default:
+		kind = snapshotapi.ChangeKindAdd
+	}
+	return snapshotapi.Change{
+		Kind:  kind,
+		Path:  c.Path,
+		Size_: c.Size,
+		Mode:  uint32(c.Mode),
+	}
+}
+
 func fromMounts(mounts []mount.Mount) *snapshotapi.MountsResponse {
 	resp := &snapshotapi.MountsResponse{
 		Mounts: make([]*mounttypes.Mount, len(mounts)),
@@ -0,0 +1,111 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// Keyring holds the public keys trusted to sign images.
+//
+// A signature is made over the string form of the manifest digest, such as
+// "sha256:4c1e...". RSA signatures use PKCS #1 v1.5 and ECDSA signatures use
+// ASN.1 encoding, both over the SHA-256 hash of the digest string.
+type Keyring []crypto.PublicKey
+
+// LoadKeyring reads the PEM encoded public keys from the files at paths. If a
+// path is a directory, keys are read from every file within it.
+func LoadKeyring(paths ...string) (Keyring, error) {
+	var keyring Keyring
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+
+		files := []string{p}
+		if fi.IsDir() {
+			files, err = filepath.Glob(filepath.Join(p, "*"))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, f := range files {
+			keys, err := readKeys(f)
+			if err != nil {
+				return nil, err
+			}
+			keyring = append(keyring, keys...)
+		}
+	}
+	return keyring, nil
+}
+
+func readKeys(p string) ([]crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse public key from %v", p)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.Errorf("no public keys found in %v", p)
+	}
+	return keys, nil
+}
+
+// Verify returns nil if sig is a signature of dgst made by any key in the
+// keyring.
+func (k Keyring) Verify(dgst digest.Digest, sig []byte) error {
+	hashed := sha256.Sum256([]byte(dgst.String()))
+
+	for _, key := range k {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], sig) == nil {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			var es ecdsaSignature
+			if rest, err := asn1.Unmarshal(sig, &es); err != nil || len(rest) != 0 {
+				continue
+			}
+			if ecdsa.Verify(key, hashed[:], es.R, es.S) {
+				return nil
+			}
+		}
+	}
+	return errors.Wrapf(ErrUnverified, "no trusted key signed %v", dgst)
+}
+
+// ecdsaSignature is the ASN.1 encoding of an ECDSA signature.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
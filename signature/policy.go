@@ -0,0 +1,188 @@
+// Package signature verifies the detached signatures of image manifests
+// before they are pulled.
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ErrUnverified is returned when an image has no signature made by a trusted
+// key.
+var ErrUnverified = errors.New("signature: image not verified")
+
+// Requirement describes the signatures required for images in a scope.
+type Requirement struct {
+	// Keyring holds the keys trusted to sign images. If empty, images are
+	// accepted without verification.
+	Keyring Keyring
+
+	// Store provides the signatures of images.
+	Store Store
+}
+
+// Policy selects the signature requirement of an image by its name.
+type Policy struct {
+	// Default applies to images not matched by any scope. If nil, these
+	// images are accepted without verification.
+	Default *Requirement
+
+	// Scopes maps registry hosts or repository namespaces, such as
+	// "docker.io" or "docker.io/library", to their requirements. The longest
+	// scope matching the locator of an image name applies.
+	Scopes map[string]*Requirement
+}
+
+// Requirement returns the requirement applying to the image name.
+func (p *Policy) Requirement(name string) *Requirement {
+	locator := name
+	if spec, err := reference.Parse(name); err == nil {
+		locator = spec.Locator
+	}
+
+	var (
+		match    = p.Default
+		matchLen = -1
+	)
+	for scope, r := range p.Scopes {
+		scope = strings.TrimSuffix(scope, "/")
+		if locator != scope && !strings.HasPrefix(locator, scope+"/") {
+			continue
+		}
+		if len(scope) > matchLen {
+			match, matchLen = r, len(scope)
+		}
+	}
+	return match
+}
+
+// Verify checks the signatures of the manifest desc resolved for the image
+// name against the requirement for the image. It returns ErrUnverified if
+// no signature was made by a trusted key.
+func (p *Policy) Verify(ctx context.Context, name string, desc ocispec.Descriptor) error {
+	r := p.Requirement(name)
+	if r == nil || len(r.Keyring) == 0 {
+		return nil
+	}
+	if r.Store == nil {
+		return errors.Wrapf(ErrUnverified, "no signature store configured for %v", name)
+	}
+
+	sigs, err := r.Store.Signatures(ctx, name, desc.Digest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get signatures for %v", name)
+	}
+	for _, sig := range sigs {
+		if err := r.Keyring.Verify(desc.Digest, sig); err == nil {
+			log.G(ctx).WithField("image", name).WithField("digest", desc.Digest).Debug("verified signature")
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrUnverified, "%v@%v has no trusted signature", name, desc.Digest)
+}
+
+// Handler returns a handler which verifies the manifest target resolved for
+// the image name before calling h with it. Other descriptors are passed to h
+// directly, as they are only reached through a verified manifest.
+func (p *Policy) Handler(name string, target ocispec.Descriptor, h images.Handler) images.Handler {
+	return images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if desc.Digest == target.Digest {
+			if err := p.Verify(ctx, name, desc); err != nil {
+				return nil, err
+			}
+		}
+		return h.Handle(ctx, desc)
+	})
+}
+
+// policyFile is the on-disk format of a policy.
+//
+//	{
+//		"default": {"keyring": ["/etc/containerd/keys"], "signatures": "registry"},
+//		"scopes": {
+//			"docker.io/library": {},
+//			"registry.example.com": {"keyring": ["/etc/keys/release.pem"], "signatures": "/var/lib/signatures"}
+//		}
+//	}
+type policyFile struct {
+	Default *requirementFile            `json:"default,omitempty"`
+	Scopes  map[string]*requirementFile `json:"scopes,omitempty"`
+}
+
+type requirementFile struct {
+	// Keyring lists the files or directories of PEM encoded public keys.
+	Keyring []string `json:"keyring,omitempty"`
+
+	// Signatures is the directory to read signatures from, or "registry"
+	// to fetch them from the registry of the image.
+	Signatures string `json:"signatures,omitempty"`
+}
+
+// LoadPolicy reads a policy from the JSON file at path. Signatures stored in
+// a registry are fetched with resolver.
+func LoadPolicy(path string, resolver remotes.Resolver) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pf policyFile
+	if err := json.NewDecoder(f).Decode(&pf); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode signature policy %v", path)
+	}
+
+	policy := &Policy{
+		Scopes: map[string]*Requirement{},
+	}
+	if policy.Default, err = pf.Default.requirement(resolver); err != nil {
+		return nil, err
+	}
+	for scope, rf := range pf.Scopes {
+		r, err := rf.requirement(resolver)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid scope %v", scope)
+		}
+		if r == nil {
+			r = &Requirement{}
+		}
+		policy.Scopes[scope] = r
+	}
+	return policy, nil
+}
+
+func (rf *requirementFile) requirement(resolver remotes.Resolver) (*Requirement, error) {
+	if rf == nil {
+		return nil, nil
+	}
+
+	var (
+		r   Requirement
+		err error
+	)
+	if len(rf.Keyring) > 0 {
+		if r.Keyring, err = LoadKeyring(rf.Keyring...); err != nil {
+			return nil, err
+		}
+	}
+	switch rf.Signatures {
+	case "":
+		if len(r.Keyring) > 0 {
+			return nil, errors.New("signatures are required with a keyring")
+		}
+	case "registry":
+		r.Store = NewRegistryStore(resolver)
+	default:
+		r.Store = NewDirectoryStore(rf.Signatures)
+	}
+	return &r, nil
+}
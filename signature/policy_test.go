@@ -0,0 +1,180 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containerd/containerd/images"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+func writeKey(t *testing.T, path string, key interface{}) {
+	p, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: p}), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeSignature(t *testing.T, root string, dgst digest.Digest, name string, sig []byte) {
+	dir := filepath.Join(root, dgst.Algorithm().String(), dgst.Hex())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name), sig, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func signRSA(t *testing.T, key *rsa.PrivateKey, dgst digest.Digest) []byte {
+	hashed := sha256.Sum256([]byte(dgst.String()))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func signECDSA(t *testing.T, key *ecdsa.PrivateKey, dgst digest.Digest) []byte {
+	hashed := sha256.Sum256([]byte(dgst.String()))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestPolicyVerify(t *testing.T) {
+	ctx := context.Background()
+
+	tmpdir, err := ioutil.TempDir("", "signature-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrusted, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := filepath.Join(tmpdir, "keys")
+	if err := os.Mkdir(keys, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, filepath.Join(keys, "release.pem"), &rsaKey.PublicKey)
+	writeKey(t, filepath.Join(keys, "ci.pem"), &ecKey.PublicKey)
+	keyring, err := LoadKeyring(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keyring) != 2 {
+		t.Fatalf("unexpected number of keys: %d", len(keyring))
+	}
+
+	var (
+		sigs     = filepath.Join(tmpdir, "signatures")
+		signed   = digest.FromString("signed")
+		ecSigned = digest.FromString("ecdsa signed")
+		forged   = digest.FromString("forged")
+		unsigned = digest.FromString("unsigned")
+	)
+	writeSignature(t, sigs, signed, "release", signRSA(t, rsaKey, signed))
+	writeSignature(t, sigs, ecSigned, "ci", signECDSA(t, ecKey, ecSigned))
+	writeSignature(t, sigs, forged, "release", signRSA(t, untrusted, forged))
+
+	required := &Requirement{Keyring: keyring, Store: NewDirectoryStore(sigs)}
+	policy := &Policy{
+		Default: required,
+		Scopes: map[string]*Requirement{
+			"docker.io/library":        {},
+			"docker.io/library/secure": required,
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		dgst     digest.Digest
+		verified bool
+	}{
+		{"example.com/app:latest", signed, true},
+		{"example.com/app:latest", ecSigned, true},
+		{"example.com/app:latest", forged, false},
+		{"example.com/app:latest", unsigned, false},
+		{"docker.io/library/redis:latest", unsigned, true},
+		{"docker.io/library/secure:latest", unsigned, false},
+		{"docker.io/library/secure/app:latest", signed, true},
+	} {
+		err := policy.Verify(ctx, tc.name, ocispec.Descriptor{Digest: tc.dgst})
+		if tc.verified && err != nil {
+			t.Errorf("%v@%v: unexpected error: %v", tc.name, tc.dgst, err)
+		} else if !tc.verified && errors.Cause(err) != ErrUnverified {
+			t.Errorf("%v@%v: expected unverified, got %v", tc.name, tc.dgst, err)
+		}
+	}
+}
+
+func TestPolicyHandler(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		target = ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString("manifest"),
+		}
+		layer = ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageLayer,
+			Digest:    digest.FromString("layer"),
+		}
+		handled int
+		policy  = &Policy{
+			Default: &Requirement{
+				Keyring: Keyring{&ecdsa.PublicKey{Curve: elliptic.P256(), X: big.NewInt(1), Y: big.NewInt(1)}},
+				Store:   NewDirectoryStore("/nonexistent"),
+			},
+		}
+	)
+
+	h := policy.Handler("example.com/app:latest", target, images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		handled++
+		return nil, nil
+	}))
+
+	if _, err := h.Handle(ctx, target); errors.Cause(err) != ErrUnverified {
+		t.Fatalf("expected unverified target, got %v", err)
+	}
+	if _, err := h.Handle(ctx, layer); err != nil {
+		t.Fatal(err)
+	}
+	if handled != 1 {
+		t.Fatalf("unexpected number of handled descriptors: %d", handled)
+	}
+}
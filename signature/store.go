@@ -0,0 +1,151 @@
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containerd/containerd/content"
+	"github.com/containerd/containerd/images"
+	"github.com/containerd/containerd/reference"
+	"github.com/containerd/containerd/remotes"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Store provides the detached signatures of manifests.
+type Store interface {
+	// Signatures returns the signatures made for the manifest dgst, resolved
+	// for the image name. An empty result means no signatures were found.
+	Signatures(ctx context.Context, name string, dgst digest.Digest) ([][]byte, error)
+}
+
+// NewDirectoryStore returns a store which reads signatures from the local
+// directory root. The signatures of a manifest are the files in the
+// directory "<root>/<algorithm>/<hex>", such as
+// "<root>/sha256/4c1e...", regardless of the image name.
+func NewDirectoryStore(root string) Store {
+	return directoryStore(root)
+}
+
+type directoryStore string
+
+func (s directoryStore) Signatures(ctx context.Context, name string, dgst digest.Digest) ([][]byte, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(string(s), dgst.Algorithm().String(), dgst.Hex())
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sigs [][]byte
+	for _, fi := range fis {
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		p, err := ioutil.ReadFile(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, p)
+	}
+	return sigs, nil
+}
+
+// NewRegistryStore returns a store which fetches signatures stored alongside
+// the image. The signatures of a manifest are the layers of the manifest
+// tagged "<algorithm>-<hex>.sig" in the repository of the image.
+func NewRegistryStore(resolver remotes.Resolver) Store {
+	return &registryStore{resolver: resolver}
+}
+
+type registryStore struct {
+	resolver remotes.Resolver
+}
+
+// SignatureTag returns the tag under which the signatures of the manifest
+// dgst are stored in a registry.
+func SignatureTag(dgst digest.Digest) string {
+	return dgst.Algorithm().String() + "-" + dgst.Hex() + ".sig"
+}
+
+func (s *registryStore) Signatures(ctx context.Context, name string, dgst digest.Digest) ([][]byte, error) {
+	spec, err := reference.Parse(name)
+	if err != nil {
+		return nil, err
+	}
+	spec.Object = SignatureTag(dgst)
+	ref := spec.String()
+
+	name, desc, err := s.resolver.Resolve(ctx, ref)
+	if err != nil {
+		// a missing signature tag means the image is not signed
+		if content.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to resolve signatures %v", ref)
+	}
+	switch desc.MediaType {
+	case images.MediaTypeDockerSchema2Manifest, ocispec.MediaTypeImageManifest:
+	default:
+		return nil, errors.Errorf("unexpected signature media type %v", desc.MediaType)
+	}
+
+	fetcher, err := s.resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := fetch(ctx, fetcher, desc)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(p, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal signature manifest %v", ref)
+	}
+
+	var sigs [][]byte
+	for _, layer := range manifest.Layers {
+		sig, err := fetch(ctx, fetcher, layer)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// maxSignatureSize bounds the size of signature blobs read from a registry.
+const maxSignatureSize = 4 << 20
+
+func fetch(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor) ([]byte, error) {
+	if desc.Size > maxSignatureSize {
+		return nil, errors.Errorf("signature object %v too large", desc.Digest)
+	}
+
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	p, err := ioutil.ReadAll(io.LimitReader(rc, maxSignatureSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if desc.Digest.Algorithm().Available() && desc.Digest.Algorithm().FromBytes(p) != desc.Digest {
+		return nil, errors.Errorf("signature object %v failed verification", desc.Digest)
+	}
+	return p, nil
+}
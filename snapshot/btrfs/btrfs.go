@@ -16,6 +16,7 @@ import (
 	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/snapshot"
 	"github.com/containerd/containerd/snapshot/storage"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
 
@@ -113,6 +114,70 @@ func (b *snapshotter) Stat(ctx context.Context, key string) (snapshot.Info, erro
 	return info, nil
 }
 
+// Update updates the labels of an active or committed snapshot.
+func (b *snapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (_ snapshot.Info, err error) {
+	ctx, t, err := b.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	info, err = storage.UpdateInfo(ctx, info, fieldpaths...)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	err = t.Commit()
+	t = nil
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
+// Check compares the metadata with the active and committed subvolumes, see
+// snapshot.Checker. Orphaned subvolumes are quarantined under the root.
+func (b *snapshotter) Check(ctx context.Context, repair bool) (snapshot.CheckResult, error) {
+	ctx, t, err := b.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	defer t.Rollback()
+
+	records, err := storage.Records(ctx)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+
+	var active, committed []storage.Record
+	for _, r := range records {
+		if r.Kind == snapshot.KindActive {
+			active = append(active, r)
+		} else {
+			committed = append(committed, r)
+		}
+	}
+
+	quarantine := filepath.Join(b.root, "quarantine")
+	ar, err := storage.CheckDirectory(ctx, filepath.Join(b.root, "active"), quarantine, active, repair, btrfs.SubvolDelete)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	cr, err := storage.CheckDirectory(ctx, filepath.Join(b.root, "snapshots"), quarantine, committed, repair, btrfs.SubvolDelete)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+
+	return storage.MergeCheckResults(ar, cr), nil
+}
+
 // Usage retrieves the disk usage of the top-level snapshot.
 func (b *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
 	panic("not implemented")
@@ -139,15 +204,24 @@ func (b *snapshotter) Walk(ctx context.Context, fn func(context.Context, snapsho
 	return storage.WalkInfo(ctx, fn)
 }
 
-func (b *snapshotter) Prepare(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return b.makeActive(ctx, key, parent, false)
+func (b *snapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	if err := b.ms.LinkShared(ctx, parent, opts...); err != nil {
+		return nil, err
+	}
+	return b.makeActive(ctx, key, parent, false, opts)
 }
 
-func (b *snapshotter) View(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return b.makeActive(ctx, key, parent, true)
+// RecordDiff records the layer applied to the active snapshot key, allowing
+// it to be shared once committed.
+func (b *snapshotter) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	return b.ms.RecordDiff(ctx, key, diffID)
 }
 
-func (b *snapshotter) makeActive(ctx context.Context, key, parent string, readonly bool) ([]mount.Mount, error) {
+func (b *snapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	return b.makeActive(ctx, key, parent, true, opts)
+}
+
+func (b *snapshotter) makeActive(ctx context.Context, key, parent string, readonly bool, opts []snapshot.Opt) ([]mount.Mount, error) {
 	ctx, t, err := b.ms.TransactionContext(ctx, true)
 	if err != nil {
 		return nil, err
@@ -160,7 +234,7 @@ func (b *snapshotter) makeActive(ctx context.Context, key, parent string, readon
 		}
 	}()
 
-	a, err := storage.CreateActive(ctx, key, parent, readonly)
+	a, err := storage.CreateActive(ctx, key, parent, readonly, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +292,7 @@ func (b *snapshotter) mounts(dir string) ([]mount.Mount, error) {
 	}, nil
 }
 
-func (b *snapshotter) Commit(ctx context.Context, name, key string) (err error) {
+func (b *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) (err error) {
 	ctx, t, err := b.ms.TransactionContext(ctx, true)
 	if err != nil {
 		return err
@@ -231,7 +305,7 @@ func (b *snapshotter) Commit(ctx context.Context, name, key string) (err error)
 		}
 	}()
 
-	id, err := storage.CommitActive(ctx, key, name, snapshot.Usage{}) // TODO(stevvooe): Resolve a usage value for btrfs
+	id, err := storage.CommitActive(ctx, key, name, snapshot.Usage{}, opts...) // TODO(stevvooe): Resolve a usage value for btrfs
 	if err != nil {
 		return errors.Wrap(err, "failed to commit")
 	}
@@ -309,6 +383,17 @@ func (b *snapshotter) Remove(ctx context.Context, key string) (err error) {
 		return errors.Wrap(err, "failed to remove snapshot")
 	}
 
+	shared, err := storage.Referenced(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check snapshot references")
+	}
+	if shared {
+		// the storage is still used by another namespace
+		err = t.Commit()
+		t = nil
+		return err
+	}
+
 	if k == snapshot.KindActive {
 		source = filepath.Join(b.root, "active", id)
 
@@ -0,0 +1,486 @@
+// +build linux
+
+package devmapper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/storage"
+	units "github.com/docker/go-units"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	plugin.Register("snapshot-devmapper", &plugin.Registration{
+		Type:   plugin.SnapshotPlugin,
+		Config: &Config{},
+		Init: func(ic *plugin.InitContext) (interface{}, error) {
+			root := filepath.Join(ic.Root, "snapshot", "devmapper")
+			return NewSnapshotter(root, *ic.Config.(*Config))
+		},
+	})
+}
+
+const (
+	defaultBaseImageSize  = "10GB"
+	defaultFileSystemType = "ext4"
+)
+
+// Config configures the devmapper snapshotter.
+type Config struct {
+	// PoolName is the name of an existing thin-pool device in /dev/mapper.
+	PoolName string `toml:"pool_name"`
+	// BaseImageSize is the size of every thin device, such as "10GB". It
+	// limits the size of the filesystem of each snapshot. Changing it only
+	// affects snapshots created afterwards, and it must not be reduced
+	// while snapshots exist.
+	BaseImageSize string `toml:"base_image_size"`
+	// FileSystemType is the filesystem created on new thin devices, either
+	// "ext4" or "xfs".
+	FileSystemType string `toml:"fs_type"`
+}
+
+type snapshotter struct {
+	root   string
+	pool   string
+	size   uint64
+	fsType string
+	ms     *storage.MetaStore
+}
+
+// NewSnapshotter returns a Snapshotter which creates a thin device in the
+// configured thin-pool for every snapshot. Active snapshots are thin devices
+// mounted with the configured filesystem, and are committed by taking a thin
+// snapshot of the active device. The metadata is stored in a file under the
+// provided root.
+func NewSnapshotter(root string, config Config) (snapshot.Snapshotter, error) {
+	if config.PoolName == "" {
+		return nil, errors.New("devmapper snapshotter requires a thin-pool name")
+	}
+	if config.BaseImageSize == "" {
+		config.BaseImageSize = defaultBaseImageSize
+	}
+	if config.FileSystemType == "" {
+		config.FileSystemType = defaultFileSystemType
+	}
+	switch config.FileSystemType {
+	case "ext4", "xfs":
+	default:
+		return nil, errors.Errorf("unsupported filesystem type %q", config.FileSystemType)
+	}
+	size, err := units.RAMInBytes(config.BaseImageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base image size")
+	}
+	if size <= 0 || size%sectorSize != 0 {
+		return nil, errors.Errorf("base image size must be a positive multiple of %d bytes", sectorSize)
+	}
+	if err := checkPool(config.PoolName); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	ms, err := storage.NewMetaStore(filepath.Join(root, "metadata.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotter{
+		root:   root,
+		pool:   config.PoolName,
+		size:   uint64(size),
+		fsType: config.FileSystemType,
+		ms:     ms,
+	}, nil
+}
+
+// Stat returns the info for an active or committed snapshot by name or
+// key.
+//
+// Should be used for parent resolution, existence checks and to discern
+// the kind of snapshot.
+func (s *snapshotter) Stat(ctx context.Context, key string) (snapshot.Info, error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+	defer t.Rollback()
+	_, info, _, err := storage.GetInfo(ctx, key)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
+// Update updates the labels of an active or committed snapshot.
+func (s *snapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (_ snapshot.Info, err error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	info, err = storage.UpdateInfo(ctx, info, fieldpaths...)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	err = t.Commit()
+	t = nil
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
+// Usage returns the resources taken by the snapshot identified by key.
+//
+// For active snapshots, this is the space allocated in the pool for the thin
+// device beyond the blocks it shared with its parent when created. Blocks of
+// the parent overwritten by the snapshot are not reported by the pool and are
+// not counted. For committed snapshots, the value computed the same way on
+// commit is returned from the metadata database.
+func (s *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.Usage{}, err
+	}
+	id, info, usage, err := storage.GetInfo(ctx, key)
+	t.Rollback() // transaction no longer needed at this point.
+	if err != nil {
+		return snapshot.Usage{}, err
+	}
+
+	if info.Kind == snapshot.KindActive {
+		size, err := mappedBytes(s.deviceName(id))
+		if err != nil {
+			return snapshot.Usage{}, err
+		}
+		usage = snapshot.Usage{Size: exclusiveBytes(size, usage.Size)}
+	}
+
+	return usage, nil
+}
+
+// Walk the committed snapshots.
+func (s *snapshotter) Walk(ctx context.Context, fn func(context.Context, snapshot.Info) error) error {
+	ctx, t, err := s.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer t.Rollback()
+	return storage.WalkInfo(ctx, fn)
+}
+
+func (s *snapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	if err := s.ms.LinkShared(ctx, parent, opts...); err != nil {
+		return nil, err
+	}
+	return s.makeActive(ctx, key, parent, false, opts)
+}
+
+// RecordDiff records the layer applied to the active snapshot key, allowing
+// it to be shared once committed.
+func (s *snapshotter) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	return s.ms.RecordDiff(ctx, key, diffID)
+}
+
+func (s *snapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	return s.makeActive(ctx, key, parent, true, opts)
+}
+
+func (s *snapshotter) makeActive(ctx context.Context, key, parent string, readonly bool, opts []snapshot.Opt) (_ []mount.Mount, err error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	a, err := storage.CreateActive(ctx, key, parent, readonly, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := activeDeviceID(a.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := s.deviceName(a.ID)
+
+	if len(a.ParentIDs) == 0 {
+		err = createThin(s.pool, id)
+	} else {
+		var origin uint64
+		origin, err = committedDeviceID(a.ParentIDs[0])
+		if err != nil {
+			return nil, err
+		}
+		err = createSnap(s.pool, id, origin)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = activateThin(s.pool, name, id, s.size); err == nil {
+		if len(a.ParentIDs) == 0 {
+			err = mkfs(s.fsType, name)
+		} else {
+			// the blocks mapped by the new device are those of its parent,
+			// they are kept as the usage of the active snapshot.
+			var shared int64
+			if shared, err = mappedBytes(name); err == nil {
+				err = storage.SetUsage(ctx, key, snapshot.Usage{Size: shared})
+			}
+		}
+	}
+	if err == nil {
+		err = t.Commit()
+		t = nil
+	}
+	if err != nil {
+		s.removeDevice(ctx, name, id)
+		return nil, err
+	}
+
+	return s.mounts(a), nil
+}
+
+func (s *snapshotter) mounts(a storage.Active) []mount.Mount {
+	var options []string
+	if a.Readonly {
+		options = append(options, "ro")
+	}
+	if s.fsType == "xfs" {
+		// snapshots share the filesystem uuid of their parent
+		options = append(options, "nouuid")
+	}
+
+	return []mount.Mount{
+		{
+			Type:    s.fsType,
+			Source:  devicePath(s.deviceName(a.ID)),
+			Options: options,
+		},
+	}
+}
+
+func (s *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) (err error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	a, err := storage.GetActive(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get active snapshot")
+	}
+	device := s.deviceName(a.ID)
+
+	_, _, shared, err := storage.GetInfo(ctx, key)
+	if err != nil {
+		return err
+	}
+	size, err := mappedBytes(device)
+	if err != nil {
+		return err
+	}
+
+	id, err := storage.CommitActive(ctx, key, name, snapshot.Usage{Size: exclusiveBytes(size, shared.Size)}, opts...)
+	if err != nil {
+		return errors.Wrap(err, "failed to commit")
+	}
+
+	source, err := activeDeviceID(id)
+	if err != nil {
+		return err
+	}
+	target, err := committedDeviceID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := suspend(device); err != nil {
+		return err
+	}
+	err = createSnap(s.pool, target, source)
+	if rerr := resume(device); rerr != nil {
+		log.G(ctx).WithError(rerr).WithField("device", device).Error("Failed to resume device")
+	}
+	if err != nil {
+		return err
+	}
+
+	err = t.Commit()
+	t = nil
+	if err != nil {
+		if derr := deleteThin(s.pool, target); derr != nil {
+			log.G(ctx).WithError(derr).WithField("id", target).Error("Failed to delete thin device")
+		}
+		return err
+	}
+
+	// Only needed for cleanup, the active device id will not be reused
+	s.removeDevice(ctx, device, source)
+
+	return nil
+}
+
+// Mounts returns the mounts for the transaction identified by key. Can be
+// called on an read-write or readonly transaction.
+//
+// This can be used to recover mounts after calling View or Prepare.
+func (s *snapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	a, err := storage.GetActive(ctx, key)
+	t.Rollback()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get active snapshot")
+	}
+
+	// devices are not activated again after a reboot of the host
+	id, err := activeDeviceID(a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := activateThin(s.pool, s.deviceName(a.ID), id, s.size); err != nil {
+		return nil, err
+	}
+
+	return s.mounts(a), nil
+}
+
+// Remove abandons the transaction identified by key. All resources
+// associated with the key will be removed.
+func (s *snapshotter) Remove(ctx context.Context, key string) (err error) {
+	ctx, t, err := s.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	id, k, err := storage.Remove(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove snapshot")
+	}
+
+	shared, err := storage.Referenced(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check snapshot references")
+	}
+
+	err = t.Commit()
+	t = nil
+	if err != nil || shared {
+		// a shared device is still used by another namespace
+		return err
+	}
+
+	if k == snapshot.KindActive {
+		did, err := activeDeviceID(id)
+		if err != nil {
+			return err
+		}
+		s.removeDevice(ctx, s.deviceName(id), did)
+	} else {
+		did, err := committedDeviceID(id)
+		if err != nil {
+			return err
+		}
+		if derr := deleteThin(s.pool, did); derr != nil {
+			log.G(ctx).WithError(derr).WithField("id", did).Warn("Failed to delete thin device")
+		}
+	}
+
+	return nil
+}
+
+// removeDevice deactivates the device name and deletes its thin device id
+// from the pool. Failures are only logged, the id is never reused.
+func (s *snapshotter) removeDevice(ctx context.Context, name string, id uint64) {
+	if err := deactivate(name); err != nil {
+		log.G(ctx).WithError(err).WithField("device", name).Warn("Failed to deactivate device")
+	}
+	if err := deleteThin(s.pool, id); err != nil {
+		// a device still in use is deactivated once closed, but its
+		// blocks remain allocated in the pool.
+		log.G(ctx).WithError(err).WithField("id", id).Warn("Failed to delete thin device")
+	}
+}
+
+// exclusiveBytes returns the size of the blocks mapped by a device beyond
+// the size of those it shared with its parent when created.
+func exclusiveBytes(mapped, shared int64) int64 {
+	if mapped < shared {
+		// blocks discarded by the snapshot
+		return 0
+	}
+	return mapped - shared
+}
+
+func (s *snapshotter) deviceName(id string) string {
+	return fmt.Sprintf("%s-snap-%s", s.pool, id)
+}
+
+// activeDeviceID and committedDeviceID map a snapshot identifier to a thin
+// device id. An active snapshot keeps its identifier when committed, so the
+// committed thin snapshot is given the odd id next to that of the active
+// device.
+func activeDeviceID(id string) (uint64, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid snapshot id %q", id)
+	}
+	if n*2+1 > maxDeviceID {
+		return 0, errors.Errorf("snapshot id %d exceeds thin device ids", n)
+	}
+	return n * 2, nil
+}
+
+func committedDeviceID(id string) (uint64, error) {
+	n, err := activeDeviceID(id)
+	if err != nil {
+		return 0, err
+	}
+	return n + 1, nil
+}
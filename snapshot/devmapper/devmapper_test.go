@@ -0,0 +1,118 @@
+// +build linux
+
+package devmapper
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/testsuite"
+	"github.com/containerd/containerd/testutil"
+)
+
+const (
+	mib = 1024 * 1024
+)
+
+func thinPoolSnapshotter(ctx context.Context, root string) (snapshot.Snapshotter, func(), error) {
+	pool, err := setupLoopbackThinPool("containerd-test-" + filepath.Base(filepath.Dir(root)))
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshotter, err := NewSnapshotter(root, Config{
+		PoolName:      pool.name,
+		BaseImageSize: "64MB",
+	})
+	if err != nil {
+		pool.remove()
+		return nil, nil, err
+	}
+
+	return snapshotter, func() {
+		pool.remove()
+	}, nil
+}
+
+func TestDevmapper(t *testing.T) {
+	testutil.RequiresRoot(t)
+	if _, err := dmsetup("version"); err != nil {
+		t.Skipf("device-mapper not available: %v", err)
+	}
+	testsuite.SnapshotterSuite(t, "Devmapper", thinPoolSnapshotter)
+}
+
+type testPool struct {
+	name    string
+	files   []string
+	devices []string
+}
+
+// setupLoopbackThinPool creates sparse data and metadata files, attaches them
+// as loopback devices and creates a thin-pool named name on top of them. The
+// pool should be cleaned up by calling remove.
+func setupLoopbackThinPool(name string) (_ *testPool, err error) {
+	pool := &testPool{name: name}
+	defer func() {
+		if err != nil {
+			pool.remove()
+		}
+	}()
+
+	for _, size := range []int64{16 * mib, 512 * mib} {
+		file, err := ioutil.TempFile("", "containerd-devmapper-test")
+		if err != nil {
+			return nil, err
+		}
+		pool.files = append(pool.files, file.Name())
+		err = file.Truncate(size)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := exec.Command("losetup", "--find", "--show", file.Name()).Output()
+		if err != nil {
+			return nil, err
+		}
+		pool.devices = append(pool.devices, strings.TrimSpace(string(p)))
+	}
+
+	// thin-pool <metadata dev> <data dev> <data block size> <low water mark> <features>
+	table := fmt.Sprintf("0 %d thin-pool %s %s 128 32768 1 skip_block_zeroing",
+		512*mib/sectorSize, pool.devices[0], pool.devices[1])
+	if _, err := dmsetup("create", name, "--table", table); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// remove removes the thin devices and the thin-pool, detaching the loopback
+// devices and removing their files. Errors are ignored, as the devices may
+// only be partially set up.
+func (pool *testPool) remove() {
+	if out, err := dmsetup("ls"); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) > 0 && strings.HasPrefix(fields[0], pool.name+"-snap-") {
+				dmsetup("remove", fields[0])
+			}
+		}
+	}
+	if _, err := os.Stat(devicePath(pool.name)); err == nil {
+		dmsetup("remove", pool.name)
+	}
+	for _, device := range pool.devices {
+		exec.Command("losetup", "--detach", device).Run()
+	}
+	for _, file := range pool.files {
+		os.Remove(file)
+	}
+}
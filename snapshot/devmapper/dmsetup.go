@@ -0,0 +1,154 @@
+// +build linux
+
+package devmapper
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// sectorSize is the unit used by device-mapper tables.
+	sectorSize = 512
+
+	// maxDeviceID is the largest thin device id supported by the kernel.
+	maxDeviceID = 1<<24 - 1
+)
+
+// devicePath returns the path of the device-mapper device with name.
+func devicePath(name string) string {
+	return filepath.Join("/dev/mapper", name)
+}
+
+func dmsetup(args ...string) (string, error) {
+	out, err := exec.Command("dmsetup", args...).CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "dmsetup %s: %s", strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// poolMessage sends a message to the thin-pool device.
+func poolMessage(pool, msg string) error {
+	_, err := dmsetup("message", devicePath(pool), "0", msg)
+	return err
+}
+
+// createThin allocates a new, empty thin device with id in the pool.
+func createThin(pool string, id uint64) error {
+	return poolMessage(pool, fmt.Sprintf("create_thin %d", id))
+}
+
+// createSnap allocates the thin device id in the pool as a copy-on-write
+// snapshot of the thin device origin. An active origin device must be
+// suspended while the snapshot is taken.
+func createSnap(pool string, id, origin uint64) error {
+	return poolMessage(pool, fmt.Sprintf("create_snap %d %d", id, origin))
+}
+
+// deleteThin releases the thin device id and its blocks in the pool. The
+// device must not be active.
+func deleteThin(pool string, id uint64) error {
+	return poolMessage(pool, fmt.Sprintf("delete %d", id))
+}
+
+// activateThin creates the device name for the thin device id, exposing size
+// bytes. Activating an already active device is not an error.
+func activateThin(pool, name string, id, size uint64) error {
+	if _, err := os.Stat(devicePath(name)); err == nil {
+		return nil
+	}
+	table := fmt.Sprintf("0 %d thin %s %d", size/sectorSize, devicePath(pool), id)
+	_, err := dmsetup("create", name, "--table", table)
+	return err
+}
+
+// deactivate removes the device name. If the device is still open, for
+// example when it is mounted, it is removed once it is closed.
+func deactivate(name string) error {
+	if _, err := os.Stat(devicePath(name)); os.IsNotExist(err) {
+		return nil
+	}
+	_, err := dmsetup("remove", "--deferred", name)
+	return err
+}
+
+func suspend(name string) error {
+	_, err := dmsetup("suspend", name)
+	return err
+}
+
+func resume(name string) error {
+	_, err := dmsetup("resume", name)
+	return err
+}
+
+// status returns the status fields of the device name, excluding the start
+// and length of the target.
+func status(name string) ([]string, error) {
+	out, err := dmsetup("status", name)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(out)
+	if len(fields) < 3 {
+		return nil, errors.Errorf("unexpected status for device %s: %q", name, out)
+	}
+	return fields[2:], nil
+}
+
+// checkPool verifies that the device pool exists and is a thin-pool.
+func checkPool(pool string) error {
+	fields, err := status(pool)
+	if err != nil {
+		return errors.Wrapf(err, "thin-pool %s not found", pool)
+	}
+	if fields[0] != "thin-pool" {
+		return errors.Errorf("device %s is a %s target, not a thin-pool", pool, fields[0])
+	}
+	return nil
+}
+
+// mappedBytes returns the number of bytes allocated in the pool for the
+// active thin device name.
+func mappedBytes(name string) (int64, error) {
+	// thin status: thin <nr mapped sectors> <highest mapped sector>
+	fields, err := status(name)
+	if err != nil {
+		return 0, err
+	}
+	if fields[0] != "thin" || len(fields) < 2 {
+		return 0, errors.Errorf("device %s is not a thin device", name)
+	}
+	sectors, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid mapped sectors for device %s", name)
+	}
+	return sectors * sectorSize, nil
+}
+
+// mkfs creates a filesystem of type fsType on the device name.
+func mkfs(fsType, name string) error {
+	var args []string
+	switch fsType {
+	case "ext4":
+		args = []string{"-E", "nodiscard,lazy_itable_init=0,lazy_journal_init=0"}
+	case "xfs":
+		args = []string{"-f", "-K"}
+	default:
+		return errors.Errorf("unsupported filesystem type %q", fsType)
+	}
+	args = append(args, devicePath(name))
+
+	out, err := exec.Command("mkfs."+fsType, args...).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "mkfs.%s: %s", fsType, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
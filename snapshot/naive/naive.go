@@ -12,14 +12,20 @@ import (
 	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/snapshot"
 	"github.com/containerd/containerd/snapshot/storage"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
 
 func init() {
 	plugin.Register("snapshot-naive", &plugin.Registration{
-		Type: plugin.SnapshotPlugin,
+		Type:   plugin.SnapshotPlugin,
+		Config: &storage.Config{},
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
-			return NewSnapshotter(filepath.Join(ic.Root, "snapshot", "naive"))
+			opts, err := ic.Config.(*storage.Config).Opts(ic.Context)
+			if err != nil {
+				return nil, err
+			}
+			return NewSnapshotter(filepath.Join(ic.Root, "snapshot", "naive"), opts...)
 		},
 	})
 }
@@ -27,11 +33,13 @@ func init() {
 type snapshotter struct {
 	root string
 	ms   *storage.MetaStore
+
+	opts storage.Options
 }
 
 // NewSnapshotter returns a Snapshotter which copies layers on the underlying
 // file system. A metadata file is stored under the root.
-func NewSnapshotter(root string) (snapshot.Snapshotter, error) {
+func NewSnapshotter(root string, opts ...storage.Opt) (snapshot.Snapshotter, error) {
 	if err := os.MkdirAll(root, 0700); err != nil {
 		return nil, err
 	}
@@ -44,10 +52,19 @@ func NewSnapshotter(root string) (snapshot.Snapshotter, error) {
 		return nil, err
 	}
 
-	return &snapshotter{
+	o := &snapshotter{
 		root: root,
 		ms:   ms,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(&o.opts)
+	}
+	o.ms.RefreshUsageWith(o.opts, func(id string) (snapshot.Usage, error) {
+		du, err := fs.DiskUsage(o.getSnapshotDir(id))
+		return snapshot.Usage(du), err
+	})
+
+	return o, nil
 }
 
 // Stat returns the info for an active or committed snapshot by name or
@@ -69,6 +86,28 @@ func (o *snapshotter) Stat(ctx context.Context, key string) (snapshot.Info, erro
 	return info, nil
 }
 
+// Update updates the labels of an active or committed snapshot.
+func (o *snapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (snapshot.Info, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	info, err = storage.UpdateInfo(ctx, info, fieldpaths...)
+	if err != nil {
+		if rerr := t.Rollback(); rerr != nil {
+			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+		}
+		return snapshot.Info{}, err
+	}
+
+	if err := t.Commit(); err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
 func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
 	ctx, t, err := o.ms.TransactionContext(ctx, false)
 	if err != nil {
@@ -81,7 +120,7 @@ func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, er
 		return snapshot.Usage{}, err
 	}
 
-	if info.Kind == snapshot.KindActive {
+	if info.Kind == snapshot.KindActive && o.opts.UsageRefreshInterval == 0 {
 		du, err := fs.DiskUsage(o.getSnapshotDir(id))
 		if err != nil {
 			return snapshot.Usage{}, err
@@ -92,12 +131,21 @@ func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, er
 	return usage, nil
 }
 
-func (o *snapshotter) Prepare(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return o.createActive(ctx, key, parent, false)
+func (o *snapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	if err := o.ms.LinkShared(ctx, parent, opts...); err != nil {
+		return nil, err
+	}
+	return o.createActive(ctx, key, parent, false, opts)
+}
+
+// RecordDiff records the layer applied to the active snapshot key, allowing
+// it to be shared once committed.
+func (o *snapshotter) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	return o.ms.RecordDiff(ctx, key, diffID)
 }
 
-func (o *snapshotter) View(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return o.createActive(ctx, key, parent, true)
+func (o *snapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	return o.createActive(ctx, key, parent, true, opts)
 }
 
 // Mounts returns the mounts for the transaction identified by key. Can be
@@ -117,7 +165,7 @@ func (o *snapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, er
 	return o.mounts(active), nil
 }
 
-func (o *snapshotter) Commit(ctx context.Context, name, key string) error {
+func (o *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) error {
 	ctx, t, err := o.ms.TransactionContext(ctx, true)
 	if err != nil {
 		return err
@@ -133,7 +181,7 @@ func (o *snapshotter) Commit(ctx context.Context, name, key string) error {
 		return err
 	}
 
-	if _, err := storage.CommitActive(ctx, key, name, snapshot.Usage(usage)); err != nil {
+	if _, err := storage.CommitActive(ctx, key, name, snapshot.Usage(usage), opts...); err != nil {
 		if rerr := t.Rollback(); rerr != nil {
 			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
 		}
@@ -162,6 +210,17 @@ func (o *snapshotter) Remove(ctx context.Context, key string) (err error) {
 		return errors.Wrap(err, "failed to remove")
 	}
 
+	shared, err := storage.Referenced(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check snapshot references")
+	}
+	if shared {
+		// the storage is still used by another namespace
+		err = t.Commit()
+		t = nil
+		return err
+	}
+
 	path := o.getSnapshotDir(id)
 	renamed := filepath.Join(o.root, "snapshots", "rm-"+id)
 	if err := os.Rename(path, renamed); err != nil {
@@ -202,7 +261,33 @@ func (o *snapshotter) Walk(ctx context.Context, fn func(context.Context, snapsho
 	return storage.WalkInfo(ctx, fn)
 }
 
-func (o *snapshotter) createActive(ctx context.Context, key, parent string, readonly bool) ([]mount.Mount, error) {
+// Check compares the metadata with the snapshot directories, see
+// snapshot.Checker. Orphaned directories are quarantined under the root.
+func (o *snapshotter) Check(ctx context.Context, repair bool) (snapshot.CheckResult, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	defer t.Rollback()
+
+	records, err := storage.Records(ctx)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+
+	// views of a parent use the directory of the parent
+	var expected []storage.Record
+	for _, r := range records {
+		if r.Kind == snapshot.KindActive && r.Readonly && r.Parent != "" {
+			continue
+		}
+		expected = append(expected, r)
+	}
+
+	return storage.CheckDirectory(ctx, filepath.Join(o.root, "snapshots"), filepath.Join(o.root, "quarantine"), expected, repair, os.RemoveAll)
+}
+
+func (o *snapshotter) createActive(ctx context.Context, key, parent string, readonly bool, opts []snapshot.Opt) ([]mount.Mount, error) {
 	var (
 		err      error
 		path, td string
@@ -234,7 +319,7 @@ func (o *snapshotter) createActive(ctx context.Context, key, parent string, read
 		return nil, err
 	}
 
-	active, err := storage.CreateActive(ctx, key, parent, readonly)
+	active, err := storage.CreateActive(ctx, key, parent, readonly, opts...)
 	if err != nil {
 		if rerr := t.Rollback(); rerr != nil {
 			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
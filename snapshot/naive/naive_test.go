@@ -2,11 +2,19 @@ package naive
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/storage"
 	"github.com/containerd/containerd/snapshot/testsuite"
 	"github.com/containerd/containerd/testutil"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
 )
 
 func newSnapshotter(ctx context.Context, root string) (snapshot.Snapshotter, func(), error) {
@@ -22,3 +30,152 @@ func TestNaive(t *testing.T) {
 	testutil.RequiresRoot(t)
 	testsuite.SnapshotterSuite(t, "Naive", newSnapshotter)
 }
+
+func TestNaiveUsageEstimates(t *testing.T) {
+	ctx, cancel := context.WithCancel(namespaces.WithNamespace(context.Background(), "snapshotter-naive-test"))
+	defer cancel()
+	root, err := ioutil.TempDir("", "naive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	o, err := NewSnapshotter(root, storage.WithUsageEstimates(ctx, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mounts, err := o.Prepare(ctx, "estimated", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "foo"), make([]byte, 8192), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; ; i++ {
+		usage, err := o.Usage(ctx, "estimated")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage.Size >= 8192 {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("usage estimate was not refreshed, got %+v", usage)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNaiveCheck(t *testing.T) {
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-naive-test")
+	root, err := ioutil.TempDir("", "naive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	o, err := NewSnapshotter(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Prepare(ctx, "dangling", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Prepare(ctx, "active", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(root, "snapshots", "1")); err != nil {
+		t.Fatal(err)
+	}
+
+	// an orphan without metadata and a stale directory of an interrupted
+	// removal
+	orphan := filepath.Join(root, "snapshots", "100")
+	stale := filepath.Join(root, "snapshots", "rm-2")
+	for _, dir := range []string{orphan, stale} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(dir, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker := o.(snapshot.Checker)
+	result, err := checker.Check(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"snapshotter-naive-test/dangling"}, result.Dangling)
+	assert.Equal(t, []string{orphan, stale}, result.Orphans)
+	assert.Empty(t, result.Repaired)
+
+	result, err = checker.Check(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{orphan, stale}, result.Repaired)
+	if _, err := os.Stat(filepath.Join(root, "quarantine", "snapshots-100")); err != nil {
+		t.Fatalf("expected orphan to be quarantined: %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale directory to be removed: %v", err)
+	}
+
+	result, err = checker.Check(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, result.Orphans)
+}
+
+func TestNaiveShare(t *testing.T) {
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-naive-test")
+	other := namespaces.WithNamespace(ctx, "snapshotter-naive-other")
+	root, err := ioutil.TempDir("", "naive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	o, err := NewSnapshotter(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mounts, err := o.Prepare(ctx, "extract", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "foo"), []byte("foo"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	layer := digest.FromString("layer").String()
+	if err := o.(snapshot.DiffRecorder).RecordDiff(ctx, "extract", digest.Digest(layer)); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Commit(ctx, layer, "extract"); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := snapshot.WithLabels(map[string]string{snapshot.LabelSnapshotRef: layer})
+	if _, err := o.Prepare(other, "extract", "", ref); !snapshot.IsExist(err) {
+		t.Fatalf("expected layer to be shared, got %v", err)
+	}
+	if _, err := o.Stat(other, "extract"); !snapshot.IsNotExist(err) {
+		t.Fatalf("expected key not to be prepared, got %v", err)
+	}
+
+	// the data remains available to the other namespace
+	if err := o.Remove(ctx, layer); err != nil {
+		t.Fatal(err)
+	}
+	mounts, err = o.View(other, "view", layer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(mounts[0].Source, "foo")); err != nil {
+		t.Fatal(err)
+	}
+}
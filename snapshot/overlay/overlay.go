@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/containerd/fs"
@@ -16,21 +17,55 @@ import (
 	"github.com/containerd/containerd/plugin"
 	"github.com/containerd/containerd/snapshot"
 	"github.com/containerd/containerd/snapshot/storage"
+	units "github.com/docker/go-units"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
 
 func init() {
 	plugin.Register("snapshot-overlay", &plugin.Registration{
-		Type: plugin.SnapshotPlugin,
+		Type:   plugin.SnapshotPlugin,
+		Config: &storage.Config{},
 		Init: func(ic *plugin.InitContext) (interface{}, error) {
-			return NewSnapshotter(filepath.Join(ic.Root, "snapshot", "overlay"))
+			opts, err := ic.Config.(*storage.Config).Opts(ic.Context)
+			if err != nil {
+				return nil, err
+			}
+			return NewSnapshotter(filepath.Join(ic.Root, "snapshot", "overlay"), opts...)
 		},
 	})
 }
 
+// LabelQuota is the snapshot label limiting the size of an active snapshot,
+// in bytes or in a human readable form such as "10GB". It is only applied on
+// Prepare, updating the label afterwards does not change the limit.
+const LabelQuota = "containerd.io/snapshot/overlay.quota"
+
+// WithQuota limits the size of an active snapshot to size bytes by setting
+// the LabelQuota label. Quotas require the root to be on xfs mounted with
+// project quotas enabled.
+func WithQuota(size int64) snapshot.Opt {
+	return func(info *snapshot.Info) error {
+		labels := map[string]string{}
+		for k, v := range info.Labels {
+			labels[k] = v
+		}
+		labels[LabelQuota] = strconv.FormatInt(size, 10)
+		info.Labels = labels
+		return nil
+	}
+}
+
 type snapshotter struct {
 	root string
 	ms   *storage.MetaStore
+
+	// quota is nil when the backing filesystem does not support project
+	// quotas, for the reason in quotaErr.
+	quota    *projectQuota
+	quotaErr error
+
+	opts storage.Options
 }
 
 type activeSnapshot struct {
@@ -43,7 +78,7 @@ type activeSnapshot struct {
 // NewSnapshotter returns a Snapshotter which uses overlayfs. The overlayfs
 // diffs are stored under the provided root. A metadata file is stored under
 // the root.
-func NewSnapshotter(root string) (snapshot.Snapshotter, error) {
+func NewSnapshotter(root string, opts ...storage.Opt) (snapshot.Snapshotter, error) {
 	if err := os.MkdirAll(root, 0700); err != nil {
 		return nil, err
 	}
@@ -63,10 +98,23 @@ func NewSnapshotter(root string) (snapshot.Snapshotter, error) {
 		return nil, err
 	}
 
-	return &snapshotter{
-		root: root,
-		ms:   ms,
-	}, nil
+	quota, quotaErr := newProjectQuota(root)
+
+	o := &snapshotter{
+		root:     root,
+		ms:       ms,
+		quota:    quota,
+		quotaErr: quotaErr,
+	}
+	for _, opt := range opts {
+		opt(&o.opts)
+	}
+	o.ms.RefreshUsageWith(o.opts, func(id string) (snapshot.Usage, error) {
+		du, err := fs.DiskUsage(o.upperPath(id))
+		return snapshot.Usage(du), err
+	})
+
+	return o, nil
 }
 
 // Stat returns the info for an active or committed snapshot by name or
@@ -88,10 +136,34 @@ func (o *snapshotter) Stat(ctx context.Context, key string) (snapshot.Info, erro
 	return info, nil
 }
 
+// Update updates the labels of an active or committed snapshot.
+func (o *snapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (snapshot.Info, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	info, err = storage.UpdateInfo(ctx, info, fieldpaths...)
+	if err != nil {
+		if rerr := t.Rollback(); rerr != nil {
+			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+		}
+		return snapshot.Info{}, err
+	}
+
+	if err := t.Commit(); err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
 // Usage returns the resources taken by the snapshot identified by key.
 //
 // For active snapshots, this will scan the usage of the overlay "diff" (aka
-// "upper") directory and may take some time.
+// "upper") directory and may take some time. If a quota is set, the usage is
+// taken from the quota instead, and if usage estimates are enabled, the last
+// estimate is returned.
 //
 // For committed snapshots, the value is returned from the metadata database.
 func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
@@ -108,6 +180,14 @@ func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, er
 	t.Rollback() // transaction no longer needed at this point.
 
 	if info.Kind == snapshot.KindActive {
+		if quotaID, ok := o.quotaID(id, upperPath); ok {
+			// report usage against the quota of the snapshot
+			return o.quota.usage(quotaID)
+		}
+		if o.opts.UsageRefreshInterval > 0 {
+			return usage, nil
+		}
+
 		du, err := fs.DiskUsage(upperPath)
 		if err != nil {
 			// TODO(stevvooe): Consider not reporting an error in this case.
@@ -120,12 +200,21 @@ func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, er
 	return usage, nil
 }
 
-func (o *snapshotter) Prepare(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return o.createActive(ctx, key, parent, false)
+func (o *snapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	if err := o.ms.LinkShared(ctx, parent, opts...); err != nil {
+		return nil, err
+	}
+	return o.createActive(ctx, key, parent, false, opts)
 }
 
-func (o *snapshotter) View(ctx context.Context, key, parent string) ([]mount.Mount, error) {
-	return o.createActive(ctx, key, parent, true)
+// RecordDiff records the layer applied to the active snapshot key, allowing
+// it to be shared once committed.
+func (o *snapshotter) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	return o.ms.RecordDiff(ctx, key, diffID)
+}
+
+func (o *snapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	return o.createActive(ctx, key, parent, true, opts)
 }
 
 // Mounts returns the mounts for the transaction identified by key. Can be
@@ -145,7 +234,7 @@ func (o *snapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, er
 	return o.mounts(active), nil
 }
 
-func (o *snapshotter) Commit(ctx context.Context, name, key string) error {
+func (o *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) error {
 	ctx, t, err := o.ms.TransactionContext(ctx, true)
 	if err != nil {
 		return err
@@ -170,7 +259,7 @@ func (o *snapshotter) Commit(ctx context.Context, name, key string) error {
 		return err
 	}
 
-	if _, err = storage.CommitActive(ctx, key, name, snapshot.Usage(usage)); err != nil {
+	if _, err = storage.CommitActive(ctx, key, name, snapshot.Usage(usage), opts...); err != nil {
 		return errors.Wrap(err, "failed to commit snapshot")
 	}
 	return t.Commit()
@@ -196,6 +285,27 @@ func (o *snapshotter) Remove(ctx context.Context, key string) (err error) {
 		return errors.Wrap(err, "failed to remove")
 	}
 
+	shared, err := storage.Referenced(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check snapshot references")
+	}
+	if shared {
+		// the storage is still used by another namespace
+		err = t.Commit()
+		t = nil
+		return err
+	}
+
+	if quotaID, ok := o.quotaID(id, o.upperPath(id)); ok {
+		defer func() {
+			if err == nil {
+				if err := o.quota.clear(quotaID); err != nil {
+					log.G(ctx).WithError(err).WithField("id", id).Warn("Failed to clear quota")
+				}
+			}
+		}()
+	}
+
 	path := filepath.Join(o.root, "snapshots", id)
 	renamed := filepath.Join(o.root, "snapshots", "rm-"+id)
 	if err := os.Rename(path, renamed); err != nil {
@@ -229,12 +339,34 @@ func (o *snapshotter) Walk(ctx context.Context, fn func(context.Context, snapsho
 	return storage.WalkInfo(ctx, fn)
 }
 
-func (o *snapshotter) createActive(ctx context.Context, key, parent string, readonly bool) ([]mount.Mount, error) {
+// Check compares the metadata with the snapshot directories, see
+// snapshot.Checker. Orphaned directories are quarantined under the root.
+func (o *snapshotter) Check(ctx context.Context, repair bool) (snapshot.CheckResult, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	defer t.Rollback()
+
+	records, err := storage.Records(ctx)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+
+	return storage.CheckDirectory(ctx, filepath.Join(o.root, "snapshots"), filepath.Join(o.root, "quarantine"), records, repair, os.RemoveAll)
+}
+
+func (o *snapshotter) createActive(ctx context.Context, key, parent string, readonly bool, opts []snapshot.Opt) ([]mount.Mount, error) {
 	var (
 		path        string
 		snapshotDir = filepath.Join(o.root, "snapshots")
 	)
 
+	quota, err := o.quotaSize(readonly, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	td, err := ioutil.TempDir(snapshotDir, "new-")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create temp dir")
@@ -268,7 +400,7 @@ func (o *snapshotter) createActive(ctx context.Context, key, parent string, read
 		return nil, err
 	}
 
-	active, err := storage.CreateActive(ctx, key, parent, readonly)
+	active, err := storage.CreateActive(ctx, key, parent, readonly, opts...)
 	if err != nil {
 		if rerr := t.Rollback(); rerr != nil {
 			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
@@ -276,6 +408,15 @@ func (o *snapshotter) createActive(ctx context.Context, key, parent string, read
 		return nil, errors.Wrap(err, "failed to create active")
 	}
 
+	if quota > 0 {
+		if err = o.setQuota(active.ID, quota, td); err != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+			return nil, errors.Wrap(err, "failed to set quota")
+		}
+	}
+
 	path = filepath.Join(snapshotDir, active.ID)
 	if err = os.Rename(td, path); err != nil {
 		if rerr := t.Rollback(); rerr != nil {
@@ -292,6 +433,60 @@ func (o *snapshotter) createActive(ctx context.Context, key, parent string, read
 	return o.mounts(active), nil
 }
 
+// quotaSize returns the quota requested by opts for a new active snapshot,
+// or 0 if none is requested.
+func (o *snapshotter) quotaSize(readonly bool, opts []snapshot.Opt) (uint64, error) {
+	var info snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&info); err != nil {
+			return 0, err
+		}
+	}
+	value, ok := info.Labels[LabelQuota]
+	if !ok || readonly {
+		return 0, nil
+	}
+
+	size, err := units.RAMInBytes(value)
+	if err != nil || size <= 0 {
+		return 0, errors.Errorf("invalid quota %q", value)
+	}
+	if o.quota == nil {
+		return 0, o.quotaErr
+	}
+	return uint64(size), nil
+}
+
+// setQuota limits the snapshot directory dir of the snapshot id to size bytes.
+func (o *snapshotter) setQuota(id string, size uint64, dir string) error {
+	quotaID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid snapshot id %q", id)
+	}
+	return o.quota.set(uint32(quotaID), size, dir, filepath.Join(dir, "fs"), filepath.Join(dir, "work"))
+}
+
+// quotaID returns the quota identifier of the snapshot id if a quota was set
+// on its upper directory.
+func (o *snapshotter) quotaID(id, upperPath string) (uint32, bool) {
+	if o.quota == nil {
+		return 0, false
+	}
+	quotaID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	expected, err := o.quota.projectID(uint32(quotaID))
+	if err != nil {
+		return 0, false
+	}
+	projectID, err := getProjectID(upperPath)
+	if err != nil || projectID != expected {
+		return 0, false
+	}
+	return uint32(quotaID), true
+}
+
 func (o *snapshotter) mounts(active storage.Active) []mount.Mount {
 	if len(active.ParentIDs) == 0 {
 		// if we only have one layer/no parents then just return a bind mount as overlay
@@ -337,6 +532,8 @@ func (o *snapshotter) mounts(active storage.Active) []mount.Mount {
 		parentPaths[i] = o.upperPath(active.ParentIDs[i])
 	}
 
+	// the lowerdir of long chains may exceed the size limit of mount
+	// options, the paths are shortened when mounted, see mount.Mount.
 	options = append(options, fmt.Sprintf("lowerdir=%s", strings.Join(parentPaths, ":")))
 	return []mount.Mount{
 		{
@@ -6,8 +6,11 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/containerd/containerd/snapshot/storage"
 	"github.com/containerd/containerd/snapshot/testsuite"
 	"github.com/containerd/containerd/testutil"
+	"github.com/pkg/errors"
 )
 
 func newSnapshotter(ctx context.Context, root string) (snapshot.Snapshotter, func(), error) {
@@ -238,6 +242,74 @@ func TestOverlayOverlayRead(t *testing.T) {
 	}
 }
 
+func TestOverlayDeepChain(t *testing.T) {
+	testutil.RequiresRoot(t)
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-overlay-test")
+	root, err := ioutil.TempDir("", "overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	o, _, err := newSnapshotter(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const depth = 150
+	parent := ""
+	for i := 0; i < depth; i++ {
+		key := fmt.Sprintf("/tmp/layer%d-active", i)
+		mounts, err := o.Prepare(ctx, key, parent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dir := filepath.Join(root, fmt.Sprintf("layer%d", i))
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := mount.MountAll(mounts, dir); err != nil {
+			t.Fatalf("failed to mount layer %d: %v", i, err)
+		}
+		werr := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("file%d", i)), []byte(fmt.Sprint(i)), 0660)
+		if err := syscall.Unmount(dir, 0); err != nil {
+			t.Fatal(err)
+		}
+		if werr != nil {
+			t.Fatal(werr)
+		}
+		parent = fmt.Sprintf("/tmp/layer%d", i)
+		if err := o.Commit(ctx, parent, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mounts, err := o.Prepare(ctx, "/tmp/top", parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := len(strings.Join(mounts[0].Options, ",")); size < os.Getpagesize() {
+		t.Fatalf("expected mount options to exceed the page size, got %d bytes", size)
+	}
+	dest := filepath.Join(root, "dest")
+	if err := os.Mkdir(dest, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := mount.MountAll(mounts, dest); err != nil {
+		t.Fatal(err)
+	}
+	defer syscall.Unmount(dest, 0)
+
+	for _, i := range []int{0, depth / 2, depth - 1} {
+		data, err := ioutil.ReadFile(filepath.Join(dest, fmt.Sprintf("file%d", i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e := string(data); e != fmt.Sprint(i) {
+			t.Errorf("expected file contents %d but got %q", i, e)
+		}
+	}
+}
+
 func TestOverlayView(t *testing.T) {
 	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-overlay-test")
 	root, err := ioutil.TempDir("", "overlay")
@@ -319,3 +391,102 @@ func TestOverlayView(t *testing.T) {
 		t.Errorf("expected option %q but received %q", expected, m.Options[0])
 	}
 }
+
+func TestOverlayQuotaNotSupported(t *testing.T) {
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-overlay-test")
+	root, err := ioutil.TempDir("", "overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	o, _, err := newSnapshotter(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.(*snapshotter).quota != nil {
+		t.Skip("backing filesystem supports project quotas")
+	}
+
+	_, err = o.Prepare(ctx, "/tmp/quota", "", WithQuota(1<<20))
+	if errors.Cause(err) != ErrQuotaNotSupported {
+		t.Fatalf("expected quota not supported error but received %v", err)
+	}
+	if _, err := o.Stat(ctx, "/tmp/quota"); !snapshot.IsNotExist(err) {
+		t.Fatalf("expected snapshot to not exist but received %v", err)
+	}
+
+	// quotas are not applied to views
+	if _, err := o.View(ctx, "/tmp/view", "", WithQuota(1<<20)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOverlayQuota(t *testing.T) {
+	testutil.RequiresRoot(t)
+	if _, err := exec.LookPath("mkfs.xfs"); err != nil {
+		t.Skip("mkfs.xfs not available")
+	}
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-overlay-test")
+	root, err := ioutil.TempDir("", "overlay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	image := filepath.Join(root, "xfs.img")
+	f, err := os.Create(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.Truncate(300 << 20)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("mkfs.xfs", "-q", image).CombinedOutput(); err != nil {
+		t.Fatalf("mkfs.xfs failed: %v: %s", err, out)
+	}
+	mnt := filepath.Join(root, "mnt")
+	if err := os.Mkdir(mnt, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("mount", "-o", "loop,prjquota", image, mnt).CombinedOutput(); err != nil {
+		t.Fatalf("mount failed: %v: %s", err, out)
+	}
+	defer testutil.Unmount(t, mnt)
+
+	o, _, err := newSnapshotter(ctx, mnt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mounts, err := o.Prepare(ctx, "/tmp/quota", "", WithQuota(1<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 2<<20)
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "big"), data, 0600); err == nil {
+		t.Fatal("expected write exceeding the quota to fail")
+	}
+	usage, err := o.Usage(ctx, "/tmp/quota")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Size > 1<<20 {
+		t.Fatalf("expected usage within the quota but received %d", usage.Size)
+	}
+	if err := o.Remove(ctx, "/tmp/quota"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectIDRange(t *testing.T) {
+	q := &projectQuota{base: math.MaxUint32 - 10}
+	if id, err := q.projectID(10); err != nil || id != math.MaxUint32 {
+		t.Fatalf("unexpected project id %d: %v", id, err)
+	}
+	for _, id := range []uint32{0, 11} {
+		if _, err := q.projectID(id); err == nil {
+			t.Fatalf("expected snapshot id %d to be out of range", id)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// +build linux
+
+package overlay
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"github.com/containerd/containerd/snapshot"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// ErrQuotaNotSupported is returned when a quota is requested on a snapshot
+// but the backing filesystem does not support project quotas.
+var ErrQuotaNotSupported = errors.New("snapshot quotas not supported")
+
+const (
+	xfsSuperMagic = 0x58465342
+
+	fsIocFsGetXattr     = 0x801c581f // FS_IOC_FSGETXATTR
+	fsIocFsSetXattr     = 0x401c5820 // FS_IOC_FSSETXATTR
+	fsXflagProjInherit  = 0x200      // FS_XFLAG_PROJINHERIT
+	prjQuota            = 2          // PRJQUOTA
+	qXGetQuota          = 0x5803     // Q_XGETQUOTA
+	qXSetQLim           = 0x5804     // Q_XSETQLIM
+	fsDquotVersion      = 1          // FS_DQUOT_VERSION
+	fsProjQuota         = 2          // FS_PROJ_QUOTA
+	fsDqBSoft           = 1 << 2     // FS_DQ_BSOFT
+	fsDqBHard           = 1 << 3     // FS_DQ_BHARD
+	quotaBasicBlockSize = 512
+)
+
+// fsxattr is struct fsxattr from linux/fs.h.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// fsDiskQuota is struct fs_disk_quota from linux/dqblk_xfs.h.
+type fsDiskQuota struct {
+	version      int8
+	flags        int8
+	fieldmask    uint16
+	id           uint32
+	blkHardlimit uint64
+	blkSoftlimit uint64
+	inoHardlimit uint64
+	inoSoftlimit uint64
+	bcount       uint64
+	icount       uint64
+	itimer       int32
+	btimer       int32
+	iwarns       uint16
+	bwarns       uint16
+	padding2     int32
+	rtbHardlimit uint64
+	rtbSoftlimit uint64
+	rtbcount     uint64
+	rtbtimer     int32
+	rtbwarns     uint16
+	padding3     int16
+	padding4     [8]byte
+}
+
+// projectQuota limits the size of snapshot directories with XFS project
+// quotas. Each snapshot is assigned the project id of the snapshotter root
+// plus its numeric identifier, so that the projects of snapshots are above
+// the project of the root.
+type projectQuota struct {
+	root string
+	dev  uint64 // device number of the backing filesystem
+	base uint32
+
+	// mu serializes quotactl calls, which share the device node
+	mu sync.Mutex
+}
+
+// newProjectQuota checks that the filesystem of root supports project
+// quotas, returning an error wrapping ErrQuotaNotSupported if it does not.
+func newProjectQuota(root string) (*projectQuota, error) {
+	var sfs unix.Statfs_t
+	if err := unix.Statfs(root, &sfs); err != nil {
+		return nil, err
+	}
+	if sfs.Type != xfsSuperMagic {
+		return nil, errors.Wrapf(ErrQuotaNotSupported, "backing filesystem of %s is not xfs", root)
+	}
+
+	base, err := getProjectID(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var st unix.Stat_t
+	if err := unix.Stat(root, &st); err != nil {
+		return nil, err
+	}
+
+	q := &projectQuota{
+		root: root,
+		dev:  st.Dev,
+		base: base,
+	}
+
+	// reading the quota of the root project only succeeds when project
+	// quotas are enabled, a project without quota is not found.
+	var d fsDiskQuota
+	if err := q.quotactl(qXGetQuota, base, &d); err != nil && err != unix.ENOENT {
+		return nil, errors.Wrapf(ErrQuotaNotSupported, "project quotas not enabled on %s, mount with prjquota: %v", root, err)
+	}
+
+	return q, nil
+}
+
+// projectID returns the project id of the snapshot id.
+func (q *projectQuota) projectID(id uint32) (uint32, error) {
+	if id == 0 || id > math.MaxUint32-q.base {
+		return 0, errors.Errorf("snapshot id %d out of the range of project ids above %d", id, q.base)
+	}
+	return q.base + id, nil
+}
+
+// set assigns the project of the snapshot id to the directories and limits
+// the project to size bytes.
+func (q *projectQuota) set(id uint32, size uint64, dirs ...string) error {
+	projectID, err := q.projectID(id)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := setProjectID(dir, projectID); err != nil {
+			return err
+		}
+	}
+	return q.setLimit(projectID, size)
+}
+
+// clear removes the limit of the snapshot id.
+func (q *projectQuota) clear(id uint32) error {
+	projectID, err := q.projectID(id)
+	if err != nil {
+		return err
+	}
+	return q.setLimit(projectID, 0)
+}
+
+// usage returns the space and inodes consumed by the snapshot id.
+func (q *projectQuota) usage(id uint32) (snapshot.Usage, error) {
+	projectID, err := q.projectID(id)
+	if err != nil {
+		return snapshot.Usage{}, err
+	}
+	var d fsDiskQuota
+	if err := q.quotactl(qXGetQuota, projectID, &d); err != nil {
+		return snapshot.Usage{}, errors.Wrap(err, "failed to get quota")
+	}
+	return snapshot.Usage{
+		Inodes: int64(d.icount),
+		Size:   int64(d.bcount) * quotaBasicBlockSize,
+	}, nil
+}
+
+func (q *projectQuota) setLimit(projectID uint32, size uint64) error {
+	d := fsDiskQuota{
+		version:      fsDquotVersion,
+		flags:        fsProjQuota,
+		fieldmask:    fsDqBHard | fsDqBSoft,
+		id:           projectID,
+		blkHardlimit: size / quotaBasicBlockSize,
+		blkSoftlimit: size / quotaBasicBlockSize,
+	}
+	if err := q.quotactl(qXSetQLim, projectID, &d); err != nil {
+		return errors.Wrapf(err, "failed to set quota limit for project %d", projectID)
+	}
+	return nil
+}
+
+// quotactl runs the quotactl command cmd for the project. quotactl needs a
+// block device, so a device node of the backing filesystem is created under
+// the root for the duration of the call.
+func (q *projectQuota) quotactl(cmd int, projectID uint32, d *fsDiskQuota) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	device := filepath.Join(q.root, "backingFsBlockDev")
+	if err := os.Remove(device); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := unix.Mknod(device, unix.S_IFBLK|0600, int(q.dev)); err != nil {
+		return errors.Wrap(err, "failed to create backing filesystem device")
+	}
+	defer os.Remove(device)
+
+	p, err := unix.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL,
+		uintptr(cmd<<8|prjQuota),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(projectID),
+		uintptr(unsafe.Pointer(d)), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getProjectID(path string) (uint32, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var fsx fsxattr
+	if err := ioctl(f, fsIocFsGetXattr, &fsx); err != nil {
+		return 0, errors.Wrapf(err, "failed to get project id of %s", path)
+	}
+	return fsx.projid, nil
+}
+
+// setProjectID sets the project id of path, which is inherited by files and
+// directories created within it.
+func setProjectID(path string, projectID uint32) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var fsx fsxattr
+	if err := ioctl(f, fsIocFsGetXattr, &fsx); err != nil {
+		return errors.Wrapf(err, "failed to get project id of %s", path)
+	}
+	fsx.projid = projectID
+	fsx.xflags |= fsXflagProjInherit
+	if err := ioctl(f, fsIocFsSetXattr, &fsx); err != nil {
+		return errors.Wrapf(err, "failed to set project id of %s", path)
+	}
+	return nil
+}
+
+func ioctl(f *os.File, req uintptr, fsx *fsxattr) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(fsx)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package snapshot
 
 import (
 	"context"
+	"time"
 
 	"github.com/containerd/containerd/mount"
+	digest "github.com/opencontainers/go-digest"
 )
 
 // Kind identifies the kind of snapshot.
@@ -21,8 +23,34 @@ type Info struct {
 	Parent   string // name of parent snapshot
 	Kind     Kind   // active or committed snapshot
 	Readonly bool   // true if readonly, only valid for active
+
+	// Labels are arbitrary data on snapshots, such as the image or layer
+	// the snapshot represents.
+	Labels map[string]string
+
+	Created time.Time // created or committed time
+	Updated time.Time // last update time
+}
+
+// Opt allows setting mutable snapshot properties on creation or commit.
+type Opt func(info *Info) error
+
+// WithLabels sets the labels of a snapshot.
+func WithLabels(labels map[string]string) Opt {
+	return func(info *Info) error {
+		info.Labels = labels
+		return nil
+	}
 }
 
+// LabelSnapshotRef is the layer chain ID that a prepared key is expected to
+// be committed as. If a committed snapshot of another namespace is known to
+// hold the layers of that chain ID, Prepare shares it with the namespace of
+// the caller and returns ErrSnapshotExist rather than preparing the key. A
+// snapshot is only known to hold a chain ID when the daemon applied its
+// layers, see DiffRecorder; names chosen by clients are never trusted.
+const LabelSnapshotRef = "containerd.io/snapshot.ref"
+
 // Usage defines statistics for disk resources consumed by the snapshot.
 //
 // These resources only include the resources consumed by the snapshot itself
@@ -164,6 +192,15 @@ type Snapshotter interface {
 	// the kind of snapshot.
 	Stat(ctx context.Context, key string) (Info, error)
 
+	// Update updates the mutable properties of the active or committed
+	// snapshot identified by info.Name and returns the updated info.
+	//
+	// Only labels may be updated. If fieldpaths are provided, only the
+	// named fields are updated, where "labels" replaces all labels and
+	// "labels.<key>" sets or, if absent from info, removes a single label.
+	// Otherwise, all labels are replaced.
+	Update(ctx context.Context, info Info, fieldpaths ...string) (Info, error)
+
 	// Usage returns the resource usage of an active or committed snapshot
 	// excluding the usage of parent snapshots.
 	//
@@ -195,7 +232,14 @@ type Snapshotter interface {
 	// one is done with the transaction, Remove should be called on the key.
 	//
 	// Multiple calls to Prepare or View with the same key should fail.
-	Prepare(ctx context.Context, key, parent string) ([]mount.Mount, error)
+	//
+	// Options, such as WithLabels, set properties of the new snapshot. If
+	// the LabelSnapshotRef label gives the chain ID of a committed snapshot
+	// of another namespace, it may be shared rather than prepared, see
+	// LabelSnapshotRef.
+	//
+	// Snapshot keys are scoped to the namespace of ctx.
+	Prepare(ctx context.Context, key, parent string, opts ...Opt) ([]mount.Mount, error)
 
 	// View behaves identically to Prepare except the result may not be
 	// committed back to the snapshot snapshotter. View returns a readonly view on
@@ -210,7 +254,7 @@ type Snapshotter interface {
 	// Commit may not be called on the provided key and will return an error.
 	// To collect the resources associated with key, Remove must be called with
 	// key as the argument.
-	View(ctx context.Context, key, parent string) ([]mount.Mount, error)
+	View(ctx context.Context, key, parent string, opts ...Opt) ([]mount.Mount, error)
 
 	// Commit captures the changes between key and its parent into a snapshot
 	// identified by name.  The name can then be used with the snapshotter's other
@@ -222,7 +266,10 @@ type Snapshotter interface {
 	// Commit may be called multiple times on the same key. Snapshots created
 	// in this manner will all reference the parent used to start the
 	// transaction.
-	Commit(ctx context.Context, name, key string) error
+	//
+	// Labels of the active snapshot are not carried over, they are set on
+	// the committed snapshot with options such as WithLabels.
+	Commit(ctx context.Context, name, key string, opts ...Opt) error
 
 	// Remove the committed or active snapshot by the provided key.
 	//
@@ -236,3 +283,41 @@ type Snapshotter interface {
 	// snapshotter, the function will be called.
 	Walk(ctx context.Context, fn func(context.Context, Info) error) error
 }
+
+// CheckResult reports inconsistencies between the metadata of a snapshotter
+// and the snapshots it stores.
+type CheckResult struct {
+	// Orphans are the paths of stored snapshots without metadata, such as
+	// those left behind by an interrupted operation.
+	Orphans []string
+
+	// Dangling are the snapshots whose storage is missing, as
+	// "<namespace>/<key>".
+	Dangling []string
+
+	// Repaired are the orphans removed or moved into quarantine.
+	Repaired []string
+}
+
+// Checker is implemented by snapshotters which can reconcile their metadata
+// with the stored snapshots.
+type Checker interface {
+	// Check compares the metadata of snapshots in all namespaces with the
+	// stored snapshots. If repair is true, orphans are removed when they are
+	// left by an interrupted operation, and moved into quarantine
+	// otherwise. Dangling metadata is only reported.
+	Check(ctx context.Context, repair bool) (CheckResult, error)
+}
+
+// DiffRecorder is implemented by snapshotters which share committed snapshots
+// between namespaces. It is only called by the daemon, on a snapshot it
+// prepared, applied a layer to and commits within the same call, without
+// handing its mounts to a client, so that shared snapshots are known to hold
+// the layers they are shared as.
+type DiffRecorder interface {
+	// RecordDiff records that the layer with the uncompressed digest diffID
+	// was applied to the active snapshot key. When key is committed, on top
+	// of no parent or of a parent with a chain ID, the committed snapshot
+	// gets the chain ID of its layers and may be shared as that chain ID.
+	RecordDiff(ctx context.Context, key string, diffID digest.Digest) error
+}
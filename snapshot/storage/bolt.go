@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/boltdb/bolt"
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/snapshot"
 	db "github.com/containerd/containerd/snapshot/storage/proto"
 	"github.com/gogo/protobuf/proto"
+	digest "github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
 
@@ -78,18 +81,78 @@ func GetInfo(ctx context.Context, key string) (string, snapshot.Info, snapshot.U
 		Size:   ss.Size_,
 	}
 
-	return fmt.Sprint(ss.ID), snapshot.Info{
-		Name:     key,
-		Parent:   ss.Parent,
-		Kind:     fromProtoKind(ss.Kind),
-		Readonly: ss.Readonly,
-	}, usage, nil
+	return fmt.Sprint(ss.ID), fromProtoInfo(key, &ss), usage, nil
+}
+
+// UpdateInfo updates the labels of the snapshot info.Name, as selected by
+// fieldpaths, and returns the updated info. Only "labels" and
+// "labels.<key>" may be given as fieldpaths. With no fieldpaths, all labels
+// are replaced. The provided context must contain a writable transaction.
+func UpdateInfo(ctx context.Context, info snapshot.Info, fieldpaths ...string) (snapshot.Info, error) {
+	var updated snapshot.Info
+	err := withBucket(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
+		var ss db.Snapshot
+		if err := getSnapshot(bkt, info.Name, &ss); err != nil {
+			return err
+		}
+
+		if len(fieldpaths) == 0 {
+			fieldpaths = []string{"labels"}
+		}
+		for _, path := range fieldpaths {
+			if strings.HasPrefix(path, "labels.") {
+				key := strings.TrimPrefix(path, "labels.")
+				if value, ok := info.Labels[key]; ok {
+					if ss.Labels == nil {
+						ss.Labels = map[string]string{}
+					}
+					ss.Labels[key] = value
+				} else {
+					delete(ss.Labels, key)
+				}
+				continue
+			}
+
+			switch path {
+			case "labels":
+				ss.Labels = info.Labels
+			default:
+				return errors.Errorf("cannot update %q field on snapshot %q", path, info.Name)
+			}
+		}
+		ss.UpdatedAt = time.Now().UTC()
+
+		if err := putSnapshot(bkt, info.Name, &ss); err != nil {
+			return err
+		}
+
+		updated = fromProtoInfo(info.Name, &ss)
+		return nil
+	})
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return updated, nil
 }
 
-// WalkInfo iterates through all metadata Info for the stored snapshots and
-// calls the provided function for each. Requires a context with a storage
+// WalkInfo iterates through all metadata Info for the snapshots stored in
+// the namespace of ctx and calls the provided function for each. A namespace
+// without snapshots is walked as empty. Requires a context with a storage
 // transaction.
 func WalkInfo(ctx context.Context, fn func(context.Context, snapshot.Info) error) error {
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return err
+	}
+	t, ok := ctx.Value(transactionKey{}).(*boltFileTransactor)
+	if !ok {
+		return ErrNoTransaction
+	}
+	if nbkt := t.tx.Bucket(bucketKeyStorageVersion); nbkt == nil || nbkt.Bucket([]byte(namespace)) == nil {
+		return nil
+	}
+
 	return withBucket(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
 		return bkt.ForEach(func(k, v []byte) error {
 			// skip nested buckets
@@ -101,13 +164,7 @@ func WalkInfo(ctx context.Context, fn func(context.Context, snapshot.Info) error
 				return errors.Wrap(err, "failed to unmarshal snapshot")
 			}
 
-			info := snapshot.Info{
-				Name:     string(k),
-				Parent:   ss.Parent,
-				Kind:     fromProtoKind(ss.Kind),
-				Readonly: ss.Readonly,
-			}
-			return fn(ctx, info)
+			return fn(ctx, fromProtoInfo(string(k), &ss))
 		})
 	})
 }
@@ -117,7 +174,14 @@ func WalkInfo(ctx context.Context, fn func(context.Context, snapshot.Info) error
 // parent. If the readonly option is given, the active snapshot will be
 // marked as readonly and can only be removed, and not committed. The
 // provided context must contain a writable transaction.
-func CreateActive(ctx context.Context, key, parent string, readonly bool) (a Active, err error) {
+func CreateActive(ctx context.Context, key, parent string, readonly bool, opts ...snapshot.Opt) (a Active, err error) {
+	var base snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return Active{}, err
+		}
+	}
+
 	err = createBucketIfNotExists(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
 		var (
 			parentS *db.Snapshot
@@ -142,11 +206,15 @@ func CreateActive(ctx context.Context, key, parent string, readonly bool) (a Act
 			return errors.Wrap(err, "unable to get identifier")
 		}
 
+		now := time.Now().UTC()
 		ss := db.Snapshot{
-			ID:       id,
-			Parent:   parent,
-			Kind:     db.KindActive,
-			Readonly: readonly,
+			ID:        id,
+			Parent:    parent,
+			Kind:      db.KindActive,
+			Readonly:  readonly,
+			Labels:    base.Labels,
+			CreatedAt: now,
+			UpdatedAt: now,
 		}
 		if err := putSnapshot(bkt, key, &ss); err != nil {
 			return err
@@ -267,9 +335,17 @@ func Remove(ctx context.Context, key string) (id string, k snapshot.Kind, err er
 // as a committed snapshot referenced by `Name`. The resulting snapshot  will be
 // committed and readonly. The `key` reference will no longer be available for
 // lookup or removal. The returned string identifier for the committed snapshot
-// is the same identifier of the original active snapshot. The provided context
-// must contain a writable transaction.
-func CommitActive(ctx context.Context, key, name string, usage snapshot.Usage) (id string, err error) {
+// is the same identifier of the original active snapshot. The committed
+// snapshot only has the labels set by opts. The provided context must contain
+// a writable transaction.
+func CommitActive(ctx context.Context, key, name string, usage snapshot.Usage, opts ...snapshot.Opt) (id string, err error) {
+	var base snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return "", err
+		}
+	}
+
 	err = withBucket(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
 		b := bkt.Get([]byte(name))
 		if len(b) != 0 {
@@ -287,10 +363,21 @@ func CommitActive(ctx context.Context, key, name string, usage snapshot.Usage) (
 			return errors.Errorf("active snapshot is readonly")
 		}
 
+		if ss.DiffID != "" {
+			chain, err := chainID(bkt, ss.Parent, digest.Digest(ss.DiffID))
+			if err != nil {
+				return err
+			}
+			ss.ChainID = chain.String()
+		}
+
 		ss.Kind = db.KindCommitted
 		ss.Readonly = true
 		ss.Inodes = usage.Inodes
 		ss.Size_ = usage.Size
+		ss.Labels = base.Labels
+		ss.CreatedAt = time.Now().UTC()
+		ss.UpdatedAt = ss.CreatedAt
 
 		if err := putSnapshot(bkt, name, &ss); err != nil {
 			return err
@@ -390,6 +477,18 @@ func fromProtoKind(k db.Kind) snapshot.Kind {
 	return snapshot.KindCommitted
 }
 
+func fromProtoInfo(key string, ss *db.Snapshot) snapshot.Info {
+	return snapshot.Info{
+		Name:     key,
+		Parent:   ss.Parent,
+		Kind:     fromProtoKind(ss.Kind),
+		Readonly: ss.Readonly,
+		Labels:   ss.Labels,
+		Created:  ss.CreatedAt,
+		Updated:  ss.UpdatedAt,
+	}
+}
+
 func parents(bkt *bolt.Bucket, parent *db.Snapshot) (parents []string, err error) {
 	for {
 		parents = append(parents, fmt.Sprintf("%d", parent.ID))
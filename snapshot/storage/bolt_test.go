@@ -1,9 +1,15 @@
 package storage
 
 import (
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/metadata/schema"
+	"github.com/pkg/errors"
+
 	// Does not require root but flag must be defined for snapshot tests
 	_ "github.com/containerd/containerd/testutil"
 )
@@ -19,3 +25,27 @@ func BenchmarkSuite(b *testing.B) {
 		return NewMetaStore(filepath.Join(root, "metadata.db"))
 	})
 }
+
+func TestMetastoreNewerSchema(t *testing.T) {
+	root, err := ioutil.TempDir("", "metastore-schema-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	dbfile := filepath.Join(root, "metadata.db")
+
+	db, err := bolt.Open(dbfile, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer := schema.Schema{Bucket: bucketKeyStorageVersion, Version: dbSchema.Version + 1}
+	_, err = newer.Update(db, false)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewMetaStore(dbfile); errors.Cause(err) != schema.ErrNewerVersion {
+		t.Fatalf("expected newer schema to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/snapshot"
+	db "github.com/containerd/containerd/snapshot/storage/proto"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Record describes a stored snapshot in any namespace.
+type Record struct {
+	Namespace string
+	Key       string
+	ID        string
+	Kind      snapshot.Kind
+	Readonly  bool
+	Parent    string
+}
+
+// Ref returns the reference of the snapshot as "<namespace>/<key>".
+func (r Record) Ref() string {
+	return r.Namespace + "/" + r.Key
+}
+
+// Records returns the records of all snapshots in all namespaces. The
+// provided context must contain a transaction.
+func Records(ctx context.Context) ([]Record, error) {
+	t, ok := ctx.Value(transactionKey{}).(*boltFileTransactor)
+	if !ok {
+		return nil, ErrNoTransaction
+	}
+
+	vbkt := t.tx.Bucket(bucketKeyStorageVersion)
+	if vbkt == nil {
+		return nil, nil
+	}
+
+	var records []Record
+	if err := vbkt.ForEach(func(ns, v []byte) error {
+		if v != nil {
+			return nil
+		}
+		bkt := vbkt.Bucket(ns).Bucket(bucketKeySnapshot)
+		if bkt == nil {
+			return nil
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			var ss db.Snapshot
+			if err := proto.Unmarshal(v, &ss); err != nil {
+				return errors.Wrap(err, "failed to unmarshal snapshot")
+			}
+			records = append(records, Record{
+				Namespace: string(ns),
+				Key:       string(k),
+				ID:        fmt.Sprint(ss.ID),
+				Kind:      fromProtoKind(ss.Kind),
+				Readonly:  ss.Readonly,
+				Parent:    ss.Parent,
+			})
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// tempGracePeriod protects the temporary directories of operations in
+// progress from being removed as orphans.
+const tempGracePeriod = time.Minute
+
+// CheckDirectory compares the entries of dir, named by snapshot identifier,
+// with the records expected to be stored in dir.
+//
+// Entries without a record are reported as orphans and records without an
+// entry as dangling. Entries prefixed with "new-" or "rm-" are left behind
+// by interrupted operations, on repair they are removed with remove. Other
+// orphans are moved into the quarantine directory.
+func CheckDirectory(ctx context.Context, dir, quarantine string, expected []Record, repair bool, remove func(string) error) (snapshot.CheckResult, error) {
+	var result snapshot.CheckResult
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return result, err
+	}
+	found := map[string]struct{}{}
+	for _, entry := range entries {
+		found[entry.Name()] = struct{}{}
+	}
+
+	ids := map[string]struct{}{}
+	for _, r := range expected {
+		ids[r.ID] = struct{}{}
+		if _, ok := found[r.ID]; !ok {
+			result.Dangling = append(result.Dangling, r.Ref())
+		}
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if _, ok := ids[name]; ok {
+			continue
+		}
+		temp := strings.HasPrefix(name, "new-") || strings.HasPrefix(name, "rm-")
+		if temp && time.Since(entry.ModTime()) < tempGracePeriod {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		result.Orphans = append(result.Orphans, path)
+		if !repair {
+			continue
+		}
+
+		if temp {
+			err = remove(path)
+		} else {
+			err = quarantinePath(path, quarantine)
+		}
+		if err != nil {
+			log.G(ctx).WithError(err).WithField("path", path).Warn("Failed to repair orphaned snapshot")
+			continue
+		}
+		result.Repaired = append(result.Repaired, path)
+	}
+
+	return result, nil
+}
+
+// quarantinePath moves path into the quarantine directory, keeping the name
+// of its parent directory to avoid collisions.
+func quarantinePath(path, quarantine string) error {
+	if err := os.MkdirAll(quarantine, 0700); err != nil {
+		return err
+	}
+	target := filepath.Join(quarantine, filepath.Base(filepath.Dir(path))+"-"+filepath.Base(path))
+	return os.Rename(path, target)
+}
+
+// MergeCheckResults combines the results of checking several directories.
+func MergeCheckResults(results ...snapshot.CheckResult) snapshot.CheckResult {
+	var merged snapshot.CheckResult
+	for _, r := range results {
+		merged.Orphans = append(merged.Orphans, r.Orphans...)
+		merged.Dangling = append(merged.Dangling, r.Dangling...)
+		merged.Repaired = append(merged.Repaired, r.Repaired...)
+	}
+	return merged
+}
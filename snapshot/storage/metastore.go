@@ -49,8 +49,13 @@ type MetaStore struct {
 // NewMetaStore returns a snapshot MetaStore for storage of metadata related to
 // a snapshot driver backed by a bolt file database. This implementation is
 // strongly consistent and does all metadata changes in a transaction to prevent
-// against process crashes causing inconsistent metadata state.
+// against process crashes causing inconsistent metadata state. The database
+// is migrated to the current schema, an error is returned if it was written
+// with a newer schema.
 func NewMetaStore(dbfile string) (*MetaStore, error) {
+	if _, err := Migrate(dbfile, false); err != nil {
+		return nil, errors.Wrapf(err, "failed to migrate %s", dbfile)
+	}
 	return &MetaStore{
 		dbfile: dbfile,
 	}, nil
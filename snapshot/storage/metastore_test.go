@@ -5,9 +5,12 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/containerd/containerd/namespaces"
 	"github.com/containerd/containerd/snapshot"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -37,9 +40,18 @@ func MetaStoreSuite(t *testing.T, name string, meta func(root string) (*MetaStor
 	t.Run("CommitExist", makeTest(t, name, meta, inWriteTransaction(testCommitExist)))
 	t.Run("CommitCommitted", makeTest(t, name, meta, inWriteTransaction(testCommitCommitted)))
 	t.Run("CommitReadonly", makeTest(t, name, meta, inWriteTransaction(testCommitReadonly)))
+	t.Run("UpdateInfo", makeTest(t, name, meta, inWriteTransaction(testUpdateInfo)))
+	t.Run("UpdateInfoNotExist", makeTest(t, name, meta, inWriteTransaction(testUpdateInfoNotExist)))
+	t.Run("SetUsage", makeTest(t, name, meta, inWriteTransaction(testSetUsage)))
+	t.Run("RefreshUsage", makeTest(t, name, meta, testRefreshUsage))
 	t.Run("Remove", makeTest(t, name, meta, inWriteTransaction(testRemove)))
 	t.Run("RemoveNotExist", makeTest(t, name, meta, inWriteTransaction(testRemoveNotExist)))
 	t.Run("RemoveWithChildren", makeTest(t, name, meta, inWriteTransaction(testRemoveWithChildren)))
+	t.Run("NamespaceIsolation", makeTest(t, name, meta, inWriteTransaction(testNamespaceIsolation)))
+	t.Run("LinkCommitted", makeTest(t, name, meta, inWriteTransaction(testLinkCommitted)))
+	t.Run("LinkCommittedNotExist", makeTest(t, name, meta, inWriteTransaction(testLinkCommittedNotExist)))
+	t.Run("LinkCommittedForged", makeTest(t, name, meta, inWriteTransaction(testLinkCommittedForged)))
+	t.Run("RecordDiff", makeTest(t, name, meta, inWriteTransaction(testRecordDiff)))
 }
 
 // makeTest creates a testsuite with a writable transaction
@@ -237,13 +249,23 @@ func assertExist(t *testing.T, err error) {
 	}
 }
 
+// assertInfo checks that the timestamps of the actual info are set and
+// compares the remaining fields with expected.
+func assertInfo(t *testing.T, expected, actual snapshot.Info) {
+	if actual.Created.IsZero() || actual.Updated.IsZero() {
+		t.Errorf("Expected timestamps to be set on %q, got %+v", actual.Name, actual)
+	}
+	actual.Created, actual.Updated = time.Time{}, time.Time{}
+	assert.Equal(t, expected, actual)
+}
+
 func testGetInfo(ctx context.Context, t *testing.T, ms *MetaStore) {
 	for key, expected := range baseInfo {
 		_, info, _, err := GetInfo(ctx, key)
 		if err != nil {
 			t.Fatalf("GetInfo on %v failed: %+v", key, err)
 		}
-		assert.Equal(t, expected, info)
+		assertInfo(t, expected, info)
 	}
 }
 
@@ -264,7 +286,10 @@ func testWalk(ctx context.Context, t *testing.T, ms *MetaStore) {
 	if err != nil {
 		t.Fatalf("Walk failed: %+v", err)
 	}
-	assert.Equal(t, baseInfo, found)
+	assert.Equal(t, len(baseInfo), len(found))
+	for key, expected := range baseInfo {
+		assertInfo(t, expected, found[key])
+	}
 }
 
 func testGetActive(ctx context.Context, t *testing.T, ms *MetaStore) {
@@ -471,6 +496,109 @@ func testCommitReadonly(ctx context.Context, t *testing.T, ms *MetaStore) {
 	}
 }
 
+func testUpdateInfo(ctx context.Context, t *testing.T, ms *MetaStore) {
+	labels := map[string]string{"a": "1", "b": "2"}
+	if _, err := CreateActive(ctx, "active-1", "", false, snapshot.WithLabels(labels)); err != nil {
+		t.Fatal(err)
+	}
+	_, created, _, err := GetInfo(ctx, "active-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, labels, created.Labels)
+
+	info, err := UpdateInfo(ctx, snapshot.Info{
+		Name:   "active-1",
+		Labels: map[string]string{"b": "3"},
+	}, "labels.a", "labels.b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"b": "3"}, info.Labels)
+	assert.Equal(t, created.Created, info.Created)
+	assert.False(t, info.Updated.Before(created.Updated))
+
+	info, err = UpdateInfo(ctx, snapshot.Info{
+		Name:   "active-1",
+		Labels: map[string]string{"c": "4"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"c": "4"}, info.Labels)
+
+	if _, err := UpdateInfo(ctx, snapshot.Info{Name: "active-1", Parent: "x"}, "parent"); err == nil {
+		t.Fatal("Expected error updating parent")
+	}
+
+	if _, err := CommitActive(ctx, "active-1", "committed-1", snapshot.Usage{}, snapshot.WithLabels(labels)); err != nil {
+		t.Fatal(err)
+	}
+	_, info, _, err = GetInfo(ctx, "committed-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, labels, info.Labels)
+}
+
+func testUpdateInfoNotExist(ctx context.Context, t *testing.T, ms *MetaStore) {
+	_, err := UpdateInfo(ctx, snapshot.Info{Name: "active-not-exist"})
+	assertNotExist(t, err)
+}
+
+func testSetUsage(ctx context.Context, t *testing.T, ms *MetaStore) {
+	if err := basePopulate(ctx, ms); err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+
+	expected := snapshot.Usage{Inodes: 2, Size: 4096}
+	if err := SetUsage(ctx, "active-1", expected); err != nil {
+		t.Fatal(err)
+	}
+	_, _, usage, err := GetInfo(ctx, "active-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, expected, usage)
+
+	assertNotActive(t, SetUsage(ctx, "committed-1", expected))
+	assertNotExist(t, SetUsage(ctx, "active-not-exist", expected))
+}
+
+func testRefreshUsage(ctx context.Context, t *testing.T, ms *MetaStore) {
+	inWriteTransaction(func(ctx context.Context, t *testing.T, ms *MetaStore) {
+		if err := basePopulate(ctx, ms); err != nil {
+			t.Fatalf("Populate failed: %+v", err)
+		}
+	})(ctx, t, ms)
+
+	var ids []string
+	err := ms.RefreshUsage(ctx, func(id string) (snapshot.Usage, error) {
+		ids = append(ids, id)
+		return snapshot.Usage{Inodes: 1, Size: 512}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the writable active snapshots
+	assert.Len(t, ids, 3)
+
+	inReadTransaction(func(ctx context.Context, t *testing.T, ms *MetaStore) {
+		for _, key := range []string{"active-1", "active-2", "active-3"} {
+			_, _, usage, err := GetInfo(ctx, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, snapshot.Usage{Inodes: 1, Size: 512}, usage)
+		}
+		_, _, usage, err := GetInfo(ctx, "active-4")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, snapshot.Usage{}, usage)
+	}, nil)(ctx, t, ms)
+}
+
 func testRemove(ctx context.Context, t *testing.T, ms *MetaStore) {
 	a1, err := CreateActive(ctx, "active-1", "", false)
 	if err != nil {
@@ -550,3 +678,213 @@ func testRemoveNotExist(ctx context.Context, t *testing.T, ms *MetaStore) {
 	_, _, err := Remove(ctx, "does-not-exist")
 	assertNotExist(t, err)
 }
+
+func testNamespaceIsolation(ctx context.Context, t *testing.T, ms *MetaStore) {
+	if err := basePopulate(ctx, ms); err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+
+	octx := namespaces.WithNamespace(ctx, "testing-other")
+	if err := WalkInfo(octx, func(ctx context.Context, info snapshot.Info) error {
+		t.Errorf("Unexpected snapshot %q in other namespace", info.Name)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	_, _, _, err := GetInfo(octx, "committed-1")
+	assertNotExist(t, err)
+
+	// the same key may be used in both namespaces
+	if _, err := CreateActive(octx, "active-1", "", false); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = Remove(octx, "active-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := GetInfo(ctx, "active-1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// chainPopulate commits the verified layer snapshots chain-1 and chain-2, on
+// top of chain-1, named by their chain IDs, and the active snapshot
+// chain-active on top of chain-2.
+func chainPopulate(ctx context.Context) (chain1, chain2 string, err error) {
+	var (
+		diff1 = digest.FromString("layer-1")
+		diff2 = digest.FromString("layer-2")
+	)
+	chain1 = identity.ChainID([]digest.Digest{diff1}).String()
+	chain2 = identity.ChainID([]digest.Digest{diff1, diff2}).String()
+
+	for _, l := range []struct {
+		parent, name string
+		diff         digest.Digest
+	}{
+		{"", chain1, diff1},
+		{chain1, chain2, diff2},
+	} {
+		key := "extract " + l.name
+		if _, err := CreateActive(ctx, key, l.parent, false); err != nil {
+			return "", "", errors.Wrap(err, "failed to create active")
+		}
+		if err := RecordDiff(ctx, key, l.diff); err != nil {
+			return "", "", errors.Wrap(err, "failed to record diff")
+		}
+		if _, err := CommitActive(ctx, key, l.name, snapshot.Usage{}); err != nil {
+			return "", "", errors.Wrap(err, "failed to commit active")
+		}
+	}
+	if _, err := CreateActive(ctx, "chain-active", chain2, false); err != nil {
+		return "", "", errors.Wrap(err, "failed to create active")
+	}
+	return chain1, chain2, nil
+}
+
+func testLinkCommitted(ctx context.Context, t *testing.T, ms *MetaStore) {
+	chain1, chain2, err := chainPopulate(ctx)
+	if err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+
+	octx := namespaces.WithNamespace(ctx, "testing-other")
+	if err := LinkCommitted(octx, chain1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := LinkCommitted(octx, chain2, chain1); err != nil {
+		t.Fatal(err)
+	}
+	assertExist(t, LinkCommitted(octx, chain2, chain1))
+
+	id, info, _, err := GetInfo(ctx, chain2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lid, linfo, _, err := GetInfo(octx, chain2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lid != id {
+		t.Fatalf("Expected linked snapshot to share identifier %s, got %s", id, lid)
+	}
+	assert.Equal(t, info, linfo)
+
+	// the parent cannot be removed while the linked child exists
+	if _, _, err := Remove(octx, chain1); err == nil {
+		t.Fatal("Expected removal of snapshot with children to error")
+	}
+
+	if _, _, err := Remove(octx, chain2); err != nil {
+		t.Fatal(err)
+	}
+	referenced, err := Referenced(octx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !referenced {
+		t.Fatal("Expected snapshot to still be referenced by the original namespace")
+	}
+
+	if _, _, err := Remove(ctx, "chain-active"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Remove(ctx, chain2); err != nil {
+		t.Fatal(err)
+	}
+	referenced, err = Referenced(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if referenced {
+		t.Fatal("Expected snapshot to no longer be referenced")
+	}
+}
+
+func testLinkCommittedNotExist(ctx context.Context, t *testing.T, ms *MetaStore) {
+	if err := basePopulate(ctx, ms); err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+	chain1, chain2, err := chainPopulate(ctx)
+	if err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+
+	octx := namespaces.WithNamespace(ctx, "testing-other")
+	// snapshots without a recorded chain ID are never shared
+	assertNotExist(t, LinkCommitted(octx, "committed-1", ""))
+	assertNotExist(t, LinkCommitted(octx, "active-1", ""))
+	// the parent must be available in the namespace
+	assertNotExist(t, LinkCommitted(octx, chain2, chain1))
+}
+
+func testLinkCommittedForged(ctx context.Context, t *testing.T, ms *MetaStore) {
+	chain1 := identity.ChainID([]digest.Digest{digest.FromString("layer-1")}).String()
+
+	// commit arbitrary content under the chain ID, without a recorded diff
+	if _, err := CreateActive(ctx, "forge", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitActive(ctx, "forge", chain1, snapshot.Usage{}); err != nil {
+		t.Fatal(err)
+	}
+	octx := namespaces.WithNamespace(ctx, "testing-other")
+	assertNotExist(t, LinkCommitted(octx, chain1, ""))
+
+	// a recorded diff which does not match the name is not shared either
+	if _, err := CreateActive(ctx, "forge-2", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordDiff(ctx, "forge-2", digest.FromString("other")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitActive(ctx, "forge-2", "forged-2", snapshot.Usage{}); err != nil {
+		t.Fatal(err)
+	}
+	assertNotExist(t, LinkCommitted(octx, chain1, ""))
+	assertNotExist(t, LinkCommitted(octx, "forged-2", ""))
+
+	// on top of an unverified parent, the chain ID is unknown
+	if _, err := CreateActive(ctx, "forge-3", chain1, false); err != nil {
+		t.Fatal(err)
+	}
+	diff2 := digest.FromString("layer-2")
+	if err := RecordDiff(ctx, "forge-3", diff2); err != nil {
+		t.Fatal(err)
+	}
+	chain2 := identity.ChainID([]digest.Digest{digest.Digest(chain1), diff2}).String()
+	if _, err := CommitActive(ctx, "forge-3", chain2, snapshot.Usage{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateActive(octx, "extract", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordDiff(octx, "extract", digest.FromString("layer-1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CommitActive(octx, "extract", chain1, snapshot.Usage{}); err != nil {
+		t.Fatal(err)
+	}
+	assertNotExist(t, LinkCommitted(octx, chain2, chain1))
+}
+
+func testRecordDiff(ctx context.Context, t *testing.T, ms *MetaStore) {
+	if err := basePopulate(ctx, ms); err != nil {
+		t.Fatalf("Populate failed: %+v", err)
+	}
+
+	diff := digest.FromString("layer")
+	if err := RecordDiff(ctx, "active-1", diff); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordDiff(ctx, "active-1", diff); err == nil {
+		t.Fatal("Expected recording a second diff to error")
+	}
+	if err := RecordDiff(ctx, "active-4", diff); err == nil {
+		t.Fatal("Expected recording a diff of a readonly snapshot to error")
+	}
+	if err := RecordDiff(ctx, "committed-1", diff); errors.Cause(err) != snapshot.ErrSnapshotNotActive {
+		t.Fatalf("Expected not active error, got %v", err)
+	}
+	assertNotExist(t, RecordDiff(ctx, "does-not-exist", diff))
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/metadata/schema"
+	"github.com/pkg/errors"
+)
+
+// dbSchema is the schema of the snapshot metadata layout. The version must
+// be increased, with a migration, whenever the encoding of snapshots
+// changes.
+var dbSchema = schema.Schema{
+	Bucket:  bucketKeyStorageVersion,
+	Version: 1,
+}
+
+// Migrate migrates the snapshot metadata database dbfile to the current
+// schema. NewMetaStore migrates the database, with dryRun, the database is
+// left unchanged.
+func Migrate(dbfile string, dryRun bool) (schema.Result, error) {
+	db, err := bolt.Open(dbfile, 0600, nil)
+	if err != nil {
+		return schema.Result{}, errors.Wrap(err, "failed to open database file")
+	}
+	defer db.Close()
+
+	return dbSchema.Update(db, dryRun)
+}
@@ -17,9 +17,15 @@ import proto1 "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import _ "github.com/gogo/protobuf/types"
+
+import time "time"
+
+import github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 
 import strings "strings"
 import reflect "reflect"
+import github_com_gogo_protobuf_sortkeys "github.com/gogo/protobuf/sortkeys"
 
 import io "io"
 
@@ -27,6 +33,7 @@ import io "io"
 var _ = proto1.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -74,6 +81,20 @@ type Snapshot struct {
 	// Only valid for committed snapshots, active snapshots must read the
 	// current usage from the disk.
 	Size_ int64 `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	// Labels are arbitrary data on the snapshot.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// CreatedAt is the time the snapshot was created or committed.
+	CreatedAt time.Time `protobuf:"bytes,9,opt,name=created_at,json=createdAt,stdtime" json:"created_at"`
+	// UpdatedAt is the time the snapshot was last updated.
+	UpdatedAt time.Time `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,stdtime" json:"updated_at"`
+	// DiffID is the uncompressed digest of the layer applied to the snapshot
+	// by the daemon, see RecordDiff. It is never set from client input.
+	DiffID string `protobuf:"bytes,11,opt,name=diff_id,json=diffId,proto3" json:"diff_id,omitempty"`
+	// ChainID is the chain ID of the layers of a committed snapshot, set on
+	// commit when the daemon recorded the diff applied to the snapshot and
+	// the parent, if any, has a chain ID. Only snapshots with a chain ID are
+	// shared between namespaces.
+	ChainID string `protobuf:"bytes,12,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 }
 
 func (m *Snapshot) Reset()                    { *m = Snapshot{} }
@@ -135,6 +156,51 @@ func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRecord(dAtA, i, uint64(m.Size_))
 	}
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x42
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovRecord(uint64(len(k))) + 1 + len(v) + sovRecord(uint64(len(v)))
+			i = encodeVarintRecord(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRecord(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRecord(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintRecord(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)))
+	n1, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.CreatedAt, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintRecord(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)))
+	n2, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.UpdatedAt, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if len(m.DiffID) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRecord(dAtA, i, uint64(len(m.DiffID)))
+		i += copy(dAtA[i:], m.DiffID)
+	}
+	if len(m.ChainID) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRecord(dAtA, i, uint64(len(m.ChainID)))
+		i += copy(dAtA[i:], m.ChainID)
+	}
 	return i, nil
 }
 
@@ -187,6 +253,26 @@ func (m *Snapshot) Size() (n int) {
 	if m.Size_ != 0 {
 		n += 1 + sovRecord(uint64(m.Size_))
 	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovRecord(uint64(len(k))) + 1 + len(v) + sovRecord(uint64(len(v)))
+			n += mapEntrySize + 1 + sovRecord(uint64(mapEntrySize))
+		}
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.CreatedAt)
+	n += 1 + l + sovRecord(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.UpdatedAt)
+	n += 1 + l + sovRecord(uint64(l))
+	l = len(m.DiffID)
+	if l > 0 {
+		n += 1 + l + sovRecord(uint64(l))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovRecord(uint64(l))
+	}
 	return n
 }
 
@@ -207,6 +293,16 @@ func (this *Snapshot) String() string {
 	if this == nil {
 		return "nil"
 	}
+	keysForLabels := make([]string, 0, len(this.Labels))
+	for k, _ := range this.Labels {
+		keysForLabels = append(keysForLabels, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabels)
+	mapStringForLabels := "map[string]string{"
+	for _, k := range keysForLabels {
+		mapStringForLabels += fmt.Sprintf("%v: %v,", k, this.Labels[k])
+	}
+	mapStringForLabels += "}"
 	s := strings.Join([]string{`&Snapshot{`,
 		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
 		`Parent:` + fmt.Sprintf("%v", this.Parent) + `,`,
@@ -214,6 +310,11 @@ func (this *Snapshot) String() string {
 		`Readonly:` + fmt.Sprintf("%v", this.Readonly) + `,`,
 		`Inodes:` + fmt.Sprintf("%v", this.Inodes) + `,`,
 		`Size_:` + fmt.Sprintf("%v", this.Size_) + `,`,
+		`Labels:` + mapStringForLabels + `,`,
+		`CreatedAt:` + strings.Replace(strings.Replace(this.CreatedAt.String(), "Timestamp", "google_protobuf1.Timestamp", 1), `&`, ``, 1) + `,`,
+		`UpdatedAt:` + strings.Replace(strings.Replace(this.UpdatedAt.String(), "Timestamp", "google_protobuf1.Timestamp", 1), `&`, ``, 1) + `,`,
+		`DiffID:` + fmt.Sprintf("%v", this.DiffID) + `,`,
+		`ChainID:` + fmt.Sprintf("%v", this.ChainID) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -380,6 +481,240 @@ func (m *Snapshot) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var keykey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				keykey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			var stringLenmapkey uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLenmapkey |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLenmapkey := int(stringLenmapkey)
+			if intStringLenmapkey < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postStringIndexmapkey := iNdEx + intStringLenmapkey
+			if postStringIndexmapkey > l {
+				return io.ErrUnexpectedEOF
+			}
+			mapkey := string(dAtA[iNdEx:postStringIndexmapkey])
+			iNdEx = postStringIndexmapkey
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			if iNdEx < postIndex {
+				var valuekey uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					valuekey |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				var stringLenmapvalue uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRecord
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLenmapvalue := int(stringLenmapvalue)
+				if intStringLenmapvalue < 0 {
+					return ErrInvalidLengthRecord
+				}
+				postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+				if postStringIndexmapvalue > l {
+					return io.ErrUnexpectedEOF
+				}
+				mapvalue := string(dAtA[iNdEx:postStringIndexmapvalue])
+				iNdEx = postStringIndexmapvalue
+				m.Labels[mapkey] = mapvalue
+			} else {
+				var mapvalue string
+				m.Labels[mapkey] = mapvalue
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiffID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DiffID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecord(dAtA[iNdEx:])
@@ -511,26 +846,38 @@ func init() {
 }
 
 var fileDescriptorRecord = []byte{
-	// 334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xbb, 0x4e, 0xf3, 0x30,
-	0x1c, 0xc5, 0xe3, 0x7c, 0xf9, 0x42, 0x6b, 0x41, 0x55, 0x2c, 0x54, 0x59, 0x11, 0x32, 0x16, 0x53,
-	0xc4, 0x90, 0x70, 0x79, 0x82, 0xde, 0x86, 0xaa, 0xaa, 0x90, 0x42, 0xc5, 0x9e, 0xc6, 0x56, 0x6a,
-	0x41, 0xed, 0xca, 0x31, 0x95, 0x60, 0x62, 0x44, 0x7d, 0x87, 0x4e, 0xf0, 0x08, 0x4c, 0x3c, 0x41,
-	0x47, 0x46, 0x26, 0x44, 0xf3, 0x24, 0x28, 0x69, 0xb9, 0x0c, 0x6c, 0xe7, 0xf7, 0xf7, 0x4f, 0xe7,
-	0x48, 0x86, 0x9d, 0x54, 0x98, 0xf1, 0xcd, 0x28, 0x48, 0xd4, 0x24, 0x4c, 0x94, 0x34, 0xb1, 0x90,
-	0x5c, 0xb3, 0xdf, 0x31, 0x93, 0xf1, 0x34, 0x1b, 0x2b, 0x13, 0x66, 0x46, 0xe9, 0x38, 0xe5, 0xe1,
-	0x54, 0x2b, 0xa3, 0x42, 0xcd, 0x13, 0xa5, 0x59, 0x50, 0x02, 0x6a, 0xfc, 0xf8, 0xc1, 0x97, 0x1f,
-	0xcc, 0x4e, 0xbc, 0xbd, 0x54, 0xa5, 0x6a, 0xed, 0x17, 0x69, 0x6d, 0x1f, 0x3e, 0x03, 0x58, 0xb9,
-	0xd8, 0x58, 0xa8, 0x01, 0x6d, 0xc1, 0x30, 0xa0, 0xc0, 0x77, 0x5a, 0x6e, 0xfe, 0x7e, 0x60, 0xf7,
-	0x3a, 0x91, 0x2d, 0x18, 0x6a, 0x40, 0x77, 0x1a, 0x6b, 0x2e, 0x0d, 0xb6, 0x29, 0xf0, 0xab, 0xd1,
-	0x86, 0xd0, 0x31, 0x74, 0xae, 0x84, 0x64, 0xd8, 0xa1, 0xc0, 0xaf, 0x9d, 0xee, 0x07, 0x7f, 0x2f,
-	0x07, 0x7d, 0x21, 0x59, 0x54, 0x9a, 0xc8, 0x83, 0x15, 0xcd, 0x63, 0xa6, 0xe4, 0xf5, 0x2d, 0xfe,
-	0x4f, 0x81, 0x5f, 0x89, 0xbe, 0xb9, 0x58, 0x11, 0x52, 0x31, 0x9e, 0x61, 0x97, 0x02, 0xff, 0x5f,
-	0xb4, 0x21, 0x84, 0xa0, 0x93, 0x89, 0x3b, 0x8e, 0xb7, 0xca, 0x6b, 0x99, 0x8f, 0x22, 0xe8, 0xf4,
-	0xd7, 0x7d, 0x6e, 0xb3, 0x3d, 0xec, 0x5d, 0x76, 0xeb, 0x96, 0x57, 0x9b, 0x2f, 0x28, 0x2c, 0xae,
-	0xcd, 0xc4, 0x88, 0x19, 0x47, 0x14, 0x56, 0xdb, 0xe7, 0x83, 0x41, 0x6f, 0x38, 0xec, 0x76, 0xea,
-	0xc0, 0xdb, 0x9d, 0x2f, 0xe8, 0x4e, 0xf1, 0xdc, 0x56, 0x93, 0x89, 0x30, 0x86, 0x33, 0x6f, 0xfb,
-	0xe1, 0x91, 0x58, 0x2f, 0x4f, 0xa4, 0xec, 0x6a, 0xe1, 0xe5, 0x8a, 0x58, 0x6f, 0x2b, 0x62, 0xdd,
-	0xe7, 0x04, 0x2c, 0x73, 0x02, 0x5e, 0x73, 0x02, 0x3e, 0x72, 0x02, 0x46, 0x6e, 0xf9, 0x57, 0x67,
-	0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xae, 0x6e, 0x6e, 0xcd, 0xa1, 0x01, 0x00, 0x00,
+	// 524 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x41, 0x8f, 0xd2, 0x40,
+	0x14, 0xc7, 0x19, 0xe8, 0x96, 0x32, 0xac, 0x1b, 0x9c, 0x6c, 0x48, 0xd3, 0x98, 0xb6, 0xd1, 0xc4,
+	0x34, 0xc6, 0x14, 0xc5, 0x8b, 0x7a, 0x03, 0xca, 0xa1, 0x59, 0x37, 0x26, 0x23, 0xf1, 0xba, 0x19,
+	0x3a, 0x43, 0x99, 0x2c, 0x74, 0x48, 0x3b, 0x90, 0xe0, 0xc9, 0xa3, 0xd9, 0x78, 0xf0, 0x0b, 0xec,
+	0x49, 0x3f, 0x85, 0x9f, 0x80, 0xa3, 0x47, 0x4f, 0xe8, 0xf6, 0x93, 0x98, 0x4e, 0x8b, 0xee, 0x41,
+	0x0f, 0xde, 0xde, 0xff, 0xbd, 0xdf, 0xfb, 0xb7, 0xf3, 0x7f, 0x30, 0x88, 0xb9, 0x9c, 0xaf, 0xa7,
+	0x7e, 0x24, 0x96, 0xbd, 0x48, 0x24, 0x92, 0xf0, 0x84, 0xa5, 0xf4, 0x76, 0x99, 0x25, 0x64, 0x95,
+	0xcd, 0x85, 0xec, 0x65, 0x52, 0xa4, 0x24, 0x66, 0xbd, 0x55, 0x2a, 0xa4, 0xe8, 0xa5, 0x2c, 0x12,
+	0x29, 0xf5, 0x95, 0x40, 0xdd, 0x3f, 0xbc, 0x7f, 0xe0, 0xfd, 0xcd, 0x53, 0xeb, 0x34, 0x16, 0xb1,
+	0x28, 0xf9, 0xa2, 0x2a, 0x69, 0xcb, 0x89, 0x85, 0x88, 0x17, 0x95, 0xd1, 0x74, 0x3d, 0xeb, 0x49,
+	0xbe, 0x64, 0x99, 0x24, 0xcb, 0x55, 0x09, 0xdc, 0xff, 0xa8, 0x41, 0xe3, 0x4d, 0x65, 0x83, 0xba,
+	0xb0, 0xce, 0xa9, 0x09, 0x5c, 0xe0, 0x69, 0x43, 0x3d, 0xdf, 0x3b, 0xf5, 0x30, 0xc0, 0x75, 0x4e,
+	0x51, 0x17, 0xea, 0x2b, 0x92, 0xb2, 0x44, 0x9a, 0x75, 0x17, 0x78, 0x2d, 0x5c, 0x29, 0xf4, 0x04,
+	0x6a, 0x97, 0x3c, 0xa1, 0xa6, 0xe6, 0x02, 0xef, 0xa4, 0x7f, 0xcf, 0xff, 0xfb, 0xaf, 0xf9, 0x67,
+	0x3c, 0xa1, 0x58, 0x91, 0xc8, 0x82, 0x46, 0xca, 0x08, 0x15, 0xc9, 0x62, 0x6b, 0x1e, 0xb9, 0xc0,
+	0x33, 0xf0, 0x6f, 0x5d, 0x7c, 0x85, 0x27, 0x82, 0xb2, 0xcc, 0xd4, 0x5d, 0xe0, 0x35, 0x70, 0xa5,
+	0x10, 0x82, 0x5a, 0xc6, 0xdf, 0x31, 0xb3, 0xa9, 0xba, 0xaa, 0x46, 0x01, 0xd4, 0x17, 0x64, 0xca,
+	0x16, 0x99, 0x69, 0xb8, 0x0d, 0xaf, 0xdd, 0x7f, 0xfc, 0xaf, 0x6f, 0x1f, 0xde, 0xe6, 0xbf, 0x52,
+	0xf8, 0x38, 0x91, 0xe9, 0x16, 0x57, 0xbb, 0x68, 0x04, 0x61, 0x94, 0x32, 0x22, 0x19, 0xbd, 0x20,
+	0xd2, 0x6c, 0xb9, 0xc0, 0x6b, 0xf7, 0x2d, 0xbf, 0x8c, 0xcc, 0x3f, 0x44, 0xe6, 0x4f, 0x0e, 0x91,
+	0x0d, 0x8d, 0xdd, 0xde, 0xa9, 0x7d, 0xfa, 0xe1, 0x00, 0xdc, 0xaa, 0xf6, 0x06, 0xb2, 0x30, 0x59,
+	0xaf, 0xe8, 0xc1, 0x04, 0xfe, 0x8f, 0x49, 0xb5, 0x37, 0x90, 0xe8, 0x01, 0x6c, 0x52, 0x3e, 0x9b,
+	0x5d, 0x70, 0x6a, 0xb6, 0x8b, 0x88, 0x87, 0x30, 0xdf, 0x3b, 0x7a, 0xc0, 0x67, 0xb3, 0x30, 0xc0,
+	0x7a, 0x31, 0x0a, 0x29, 0x7a, 0x08, 0x8d, 0x68, 0x4e, 0x78, 0x52, 0x50, 0xc7, 0x8a, 0x6a, 0xe7,
+	0x7b, 0xa7, 0x39, 0x2a, 0x7a, 0x61, 0x80, 0x9b, 0x6a, 0x18, 0x52, 0xeb, 0x05, 0x6c, 0xdf, 0x7a,
+	0x2d, 0xea, 0xc0, 0xc6, 0x25, 0xdb, 0xaa, 0xb3, 0xb6, 0x70, 0x51, 0xa2, 0x53, 0x78, 0xb4, 0x21,
+	0x8b, 0x35, 0xab, 0xce, 0x59, 0x8a, 0x97, 0xf5, 0xe7, 0xe0, 0x11, 0x86, 0xda, 0x59, 0x79, 0x27,
+	0x7d, 0x30, 0x9a, 0x84, 0x6f, 0xc7, 0x9d, 0x9a, 0x75, 0x72, 0x75, 0xed, 0xc2, 0xa2, 0x3b, 0x88,
+	0x24, 0xdf, 0x30, 0xe4, 0xc2, 0xd6, 0xe8, 0xf5, 0xf9, 0x79, 0x38, 0x99, 0x8c, 0x83, 0x0e, 0xb0,
+	0xee, 0x5e, 0x5d, 0xbb, 0x77, 0x8a, 0xf1, 0x48, 0x2c, 0x97, 0x5c, 0x4a, 0x46, 0xad, 0xe3, 0x0f,
+	0x9f, 0xed, 0xda, 0xd7, 0x2f, 0xb6, 0xf2, 0x1a, 0x9a, 0xbb, 0x1b, 0xbb, 0xf6, 0xfd, 0xc6, 0xae,
+	0xbd, 0xcf, 0x6d, 0xb0, 0xcb, 0x6d, 0xf0, 0x2d, 0xb7, 0xc1, 0xcf, 0xdc, 0x06, 0x53, 0x5d, 0xc5,
+	0xf3, 0xec, 0xd7, 0x00, 0xa5, 0x37, 0xbf, 0x96, 0x1a, 0x03, 0x00, 0x00,
 }
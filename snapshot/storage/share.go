@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshot"
+	db "github.com/containerd/containerd/snapshot/storage/proto"
+	"github.com/gogo/protobuf/proto"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/identity"
+	"github.com/pkg/errors"
+)
+
+// RecordDiff records that the layer with the uncompressed digest diffID was
+// applied to the active snapshot key by the daemon. Once committed, the
+// snapshot gets the chain ID of the layers if its parent has one, and may be
+// shared with other namespaces. A diff can only be recorded once, on a
+// writable snapshot. The provided context must contain a writable
+// transaction.
+func RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	if err := diffID.Validate(); err != nil {
+		return err
+	}
+	return withBucket(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
+		var ss db.Snapshot
+		if err := getSnapshot(bkt, key, &ss); err != nil {
+			return errors.Wrap(err, "failed to get active snapshot")
+		}
+		if ss.Kind != db.KindActive {
+			return snapshot.ErrSnapshotNotActive
+		}
+		if ss.Readonly {
+			return errors.Errorf("active snapshot is readonly")
+		}
+		if ss.DiffID != "" {
+			return errors.Errorf("diff already recorded for %q", key)
+		}
+		ss.DiffID = diffID.String()
+		return putSnapshot(bkt, key, &ss)
+	})
+}
+
+// chainID returns the chain ID of a layer diffID applied on top of the
+// committed snapshot parent. If the parent has no chain ID, its content is
+// unknown and an empty chain ID is returned.
+func chainID(bkt *bolt.Bucket, parent string, diffID digest.Digest) (digest.Digest, error) {
+	if parent == "" {
+		return diffID, nil
+	}
+	var ps db.Snapshot
+	if err := getSnapshot(bkt, parent, &ps); err != nil {
+		return "", errors.Wrap(err, "failed to get parent snapshot")
+	}
+	if ps.ChainID == "" {
+		return "", nil
+	}
+	return identity.ChainID([]digest.Digest{digest.Digest(ps.ChainID), diffID}), nil
+}
+
+// LinkCommitted makes a committed snapshot of another namespace with the
+// chain ID name available in the namespace of ctx under that name, as a
+// child of parent. Only snapshots with a chain ID recorded by the daemon are
+// linked, never a snapshot merely named name, and parent must itself have
+// the chain ID parent in the namespace of ctx. The linked snapshot shares the
+// identifier, and so the storage, of the original. If no such snapshot
+// exists, an error wrapping snapshot.ErrSnapshotNotExist is returned. The
+// provided context must contain a writable transaction.
+func LinkCommitted(ctx context.Context, name, parent string) error {
+	namespace, err := namespaces.NamespaceRequired(ctx)
+	if err != nil {
+		return err
+	}
+
+	return createBucketIfNotExists(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
+		if len(bkt.Get([]byte(name))) != 0 {
+			return snapshot.ErrSnapshotExist
+		}
+
+		var parentS db.Snapshot
+		if parent != "" {
+			if err := getSnapshot(bkt, parent, &parentS); err != nil {
+				return errors.Wrap(err, "failed to get parent snapshot")
+			}
+			if parentS.Kind != db.KindCommitted {
+				return errors.Wrap(snapshot.ErrSnapshotNotCommitted, "parent is not committed snapshot")
+			}
+			if parentS.ChainID != parent {
+				return errors.Wrapf(snapshot.ErrSnapshotNotExist, "parent %q has no matching chain ID", parent)
+			}
+		}
+
+		ss, err := findCommitted(ctx, namespace, name)
+		if err != nil {
+			return err
+		}
+		ss.Parent = parent
+
+		if err := putSnapshot(bkt, name, ss); err != nil {
+			return err
+		}
+		if parent != "" {
+			if err := pbkt.Put(parentKey(parentS.ID, ss.ID), []byte(name)); err != nil {
+				return errors.Wrap(err, "failed to write parent link")
+			}
+		}
+
+		return nil
+	})
+}
+
+// findCommitted returns a committed snapshot with the chain ID chain from
+// any namespace other than exclude.
+func findCommitted(ctx context.Context, exclude, chain string) (*db.Snapshot, error) {
+	t, ok := ctx.Value(transactionKey{}).(*boltFileTransactor)
+	if !ok {
+		return nil, ErrNoTransaction
+	}
+
+	var found *db.Snapshot
+	if err := t.tx.Bucket(bucketKeyStorageVersion).ForEach(func(ns, v []byte) error {
+		if v != nil || found != nil || string(ns) == exclude {
+			return nil
+		}
+		bkt := t.tx.Bucket(bucketKeyStorageVersion).Bucket(ns).Bucket(bucketKeySnapshot)
+		if bkt == nil {
+			return nil
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			// skip nested buckets
+			if v == nil || found != nil {
+				return nil
+			}
+			var ss db.Snapshot
+			if err := proto.Unmarshal(v, &ss); err != nil {
+				return errors.Wrap(err, "failed to unmarshal snapshot")
+			}
+			if ss.Kind == db.KindCommitted && ss.ChainID == chain {
+				found = &ss
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return nil, errors.Wrapf(snapshot.ErrSnapshotNotExist, "no committed snapshot with chain ID %q to share", chain)
+	}
+	return found, nil
+}
+
+// Referenced reports whether the snapshot identifier id is used by a snapshot
+// in any namespace. Snapshotters must call it after Remove and only release
+// the storage of id when it is no longer referenced, as committed snapshots
+// may be shared between namespaces. The provided context must contain a
+// transaction.
+func Referenced(ctx context.Context, id string) (bool, error) {
+	records, err := Records(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range records {
+		if r.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LinkShared shares the committed snapshot with the chain ID given by the
+// snapshot.LabelSnapshotRef label in opts, if any, with the namespace of ctx
+// as a child of parent, see LinkCommitted. When the snapshot was shared, an error wrapping
+// snapshot.ErrSnapshotExist is returned and Prepare should return it to the
+// caller. Otherwise, nil is returned and the snapshot should be prepared.
+func (ms *MetaStore) LinkShared(ctx context.Context, parent string, opts ...snapshot.Opt) error {
+	var base snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return err
+		}
+	}
+	name := base.Labels[snapshot.LabelSnapshotRef]
+	if name == "" {
+		return nil
+	}
+
+	ctx, t, err := ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	if err := LinkCommitted(ctx, name, parent); err != nil {
+		if rerr := t.Rollback(); rerr != nil {
+			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+		}
+		if snapshot.IsNotExist(err) || snapshot.IsExist(err) || snapshot.IsNotCommitted(err) {
+			// prepare as usual, which reports any conflicts
+			return nil
+		}
+		return errors.Wrap(err, "failed to share snapshot")
+	}
+	if err := t.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit")
+	}
+
+	return errors.Wrapf(snapshot.ErrSnapshotExist, "target snapshot %q", name)
+}
+
+// RecordDiff records the diff applied to the active snapshot key in its own
+// transaction, see RecordDiff.
+func (ms *MetaStore) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	ctx, t, err := ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	if err := RecordDiff(ctx, key, diffID); err != nil {
+		if rerr := t.Rollback(); rerr != nil {
+			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+		}
+		return err
+	}
+	return t.Commit()
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshot"
+	db "github.com/containerd/containerd/snapshot/storage/proto"
+	"github.com/pkg/errors"
+)
+
+// Config configures the snapshotters built on the metadata store.
+type Config struct {
+	// UsageRefreshInterval enables usage estimates for active snapshots,
+	// refreshed in the background at the interval, such as "1m". By
+	// default, the usage of active snapshots is computed on every request.
+	UsageRefreshInterval string `toml:"usage_refresh_interval"`
+}
+
+// Opts returns the options set by the configuration, the estimates are
+// refreshed until ctx is done.
+func (c *Config) Opts(ctx context.Context) ([]Opt, error) {
+	var opts []Opt
+	if c.UsageRefreshInterval != "" {
+		d, err := time.ParseDuration(c.UsageRefreshInterval)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid usage refresh interval")
+		}
+		opts = append(opts, WithUsageEstimates(ctx, d))
+	}
+	return opts, nil
+}
+
+// Options are the options of the snapshotters built on the metadata store.
+type Options struct {
+	// UsageRefreshInterval is the interval at which the usage estimates of
+	// active snapshots are refreshed, zero if the usage is computed on
+	// every request.
+	UsageRefreshInterval time.Duration
+
+	// UsageRefreshContext stops the refresh of the estimates when done.
+	UsageRefreshContext context.Context
+}
+
+// Opt configures a snapshotter built on the metadata store.
+type Opt func(*Options)
+
+// WithUsageEstimates reports the usage of active snapshots from an estimate
+// refreshed at every interval until ctx is done, rather than scanning the
+// snapshot on every call to Usage.
+func WithUsageEstimates(ctx context.Context, interval time.Duration) Opt {
+	return func(o *Options) {
+		o.UsageRefreshContext = ctx
+		o.UsageRefreshInterval = interval
+	}
+}
+
+// RefreshUsageWith starts the refresh of the usage estimates with usageFn in
+// the background, if enabled by the options.
+func (ms *MetaStore) RefreshUsageWith(o Options, usageFn func(id string) (snapshot.Usage, error)) {
+	if o.UsageRefreshInterval > 0 {
+		go ms.RefreshUsageEvery(o.UsageRefreshContext, o.UsageRefreshInterval, usageFn)
+	}
+}
+
+// SetUsage stores the usage of the active snapshot key, as returned by
+// GetInfo. This allows snapshotters to report an estimate rather than
+// computing the usage of active snapshots on every request. The provided
+// context must contain a writable transaction.
+func SetUsage(ctx context.Context, key string, usage snapshot.Usage) error {
+	return withBucket(ctx, func(ctx context.Context, bkt, pbkt *bolt.Bucket) error {
+		var ss db.Snapshot
+		if err := getSnapshot(bkt, key, &ss); err != nil {
+			return err
+		}
+		if ss.Kind != db.KindActive {
+			return snapshot.ErrSnapshotNotActive
+		}
+
+		ss.Inodes = usage.Inodes
+		ss.Size_ = usage.Size
+
+		return putSnapshot(bkt, key, &ss)
+	})
+}
+
+// RefreshUsage computes the usage of every writable active snapshot, in all
+// namespaces, with usageFn and stores it with SetUsage. The usage is computed
+// outside of any transaction, snapshots removed or committed in the meantime
+// are skipped.
+func (ms *MetaStore) RefreshUsage(ctx context.Context, usageFn func(id string) (snapshot.Usage, error)) error {
+	refs, err := ms.writableActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	usages := make(map[Record]snapshot.Usage, len(refs))
+	for _, ref := range refs {
+		usage, err := usageFn(ref.ID)
+		if err != nil {
+			// the snapshot may have been removed in the meantime
+			log.G(ctx).WithError(err).WithField("key", ref.Key).Debug("Failed to get snapshot usage")
+			continue
+		}
+		usages[ref] = usage
+	}
+
+	ctx, t, err := ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	for ref, usage := range usages {
+		nctx := namespaces.WithNamespace(ctx, ref.Namespace)
+		id, _, _, err := GetInfo(nctx, ref.Key)
+		if err != nil || id != ref.ID {
+			continue
+		}
+		if err := SetUsage(nctx, ref.Key, usage); err != nil && !snapshot.IsNotActive(err) {
+			t.Rollback()
+			return err
+		}
+	}
+	return t.Commit()
+}
+
+// RefreshUsageEvery calls RefreshUsage immediately and then at every
+// interval until ctx is done. Errors are logged.
+func (ms *MetaStore) RefreshUsageEvery(ctx context.Context, interval time.Duration, usageFn func(id string) (snapshot.Usage, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := ms.RefreshUsage(ctx, usageFn); err != nil {
+			log.G(ctx).WithError(err).Warn("Failed to refresh snapshot usage")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writableActive returns all writable active snapshots in all namespaces.
+func (ms *MetaStore) writableActive(ctx context.Context) ([]Record, error) {
+	ctx, t, err := ms.TransactionContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Rollback()
+
+	records, err := Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []Record
+	for _, r := range records {
+		if r.Kind == snapshot.KindActive && !r.Readonly {
+			active = append(active, r)
+		}
+	}
+	return active, nil
+}
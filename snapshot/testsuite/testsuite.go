@@ -23,6 +23,8 @@ func SnapshotterSuite(t *testing.T, name string, snapshotterFn func(ctx context.
 	t.Run("StatComitted", makeTest(t, name, snapshotterFn, checkSnapshotterStatCommitted))
 	t.Run("TransitivityTest", makeTest(t, name, snapshotterFn, checkSnapshotterTransitivity))
 	t.Run("PreareViewFailingtest", makeTest(t, name, snapshotterFn, checkSnapshotterPrepareView))
+	t.Run("Update", makeTest(t, name, snapshotterFn, checkUpdate))
+	t.Run("OpaqueDirectory", makeTest(t, name, snapshotterFn, checkOpaqueDirectory))
 }
 
 func makeTest(t *testing.T, name string, snapshotterFn func(ctx context.Context, root string) (snapshot.Snapshotter, func(), error), fn func(ctx context.Context, t *testing.T, snapshotter snapshot.Snapshotter, work string)) func(t *testing.T) {
@@ -404,3 +406,125 @@ func checkSnapshotterPrepareView(ctx context.Context, t *testing.T, snapshotter
 	assert.NotNil(t, err)
 
 }
+
+// checkUpdate tests setting labels on creation and commit and updating them.
+func checkUpdate(ctx context.Context, t *testing.T, snapshotter snapshot.Snapshotter, work string) {
+	preparing := filepath.Join(work, "preparing")
+	labels := map[string]string{"a": "1", "b": "2"}
+	if _, err := snapshotter.Prepare(ctx, preparing, "", snapshot.WithLabels(labels)); err != nil {
+		t.Fatal(err)
+	}
+
+	si, err := snapshotter.Stat(ctx, preparing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, labels, si.Labels)
+	assert.False(t, si.Created.IsZero())
+	assert.Equal(t, si.Created, si.Updated)
+
+	committed := filepath.Join(work, "committed")
+	if err := snapshotter.Commit(ctx, committed, preparing, snapshot.WithLabels(map[string]string{"c": "3"})); err != nil {
+		t.Fatal(err)
+	}
+
+	si, err = snapshotter.Stat(ctx, committed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"c": "3"}, si.Labels)
+
+	updated, err := snapshotter.Update(ctx, snapshot.Info{
+		Name:   committed,
+		Labels: map[string]string{"d": "4"},
+	}, "labels.c", "labels.d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"d": "4"}, updated.Labels)
+	assert.Equal(t, si.Created, updated.Created)
+	assert.False(t, updated.Updated.Before(si.Updated))
+
+	updated, err = snapshotter.Update(ctx, snapshot.Info{
+		Name:   committed,
+		Labels: map[string]string{"e": "5"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]string{"e": "5"}, updated.Labels)
+
+	si, err = snapshotter.Stat(ctx, committed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, updated, si)
+
+	if _, err := snapshotter.Update(ctx, snapshot.Info{Name: committed, Parent: "x"}, "parent"); err == nil {
+		t.Fatal("expected error updating parent")
+	}
+	if _, err := snapshotter.Update(ctx, snapshot.Info{Name: "not-exist"}); !snapshot.IsNotExist(err) {
+		t.Fatalf("expected not exist error, got %v", err)
+	}
+}
+
+// A directory removed and created again must not show the files of the
+// parent once committed, such as with overlay opaque directories.
+func checkOpaqueDirectory(ctx context.Context, t *testing.T, snapshotter snapshot.Snapshotter, work string) {
+	preparing, err := snapshotterPrepareMount(ctx, snapshotter, "preparing", "", work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.Unmount(t, preparing)
+
+	if err := os.Mkdir(filepath.Join(preparing, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(preparing, "dir", "a"), []byte("a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(work, "base")
+	if err := snapshotter.Commit(ctx, base, preparing); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := snapshotterPrepareMount(ctx, snapshotter, "next", base, work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.Unmount(t, next)
+
+	if err := os.RemoveAll(filepath.Join(next, "dir")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(next, "dir"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(next, "dir", "b"), []byte("b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	top := filepath.Join(work, "top")
+	if err := snapshotter.Commit(ctx, top, next); err != nil {
+		t.Fatal(err)
+	}
+
+	view := filepath.Join(work, "view")
+	if err := os.MkdirAll(view, 0777); err != nil {
+		t.Fatal(err)
+	}
+	mounts, err := snapshotter.View(ctx, view, top)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mount.MountAll(mounts, view); err != nil {
+		t.Fatal(err)
+	}
+	defer testutil.Unmount(t, view)
+
+	if err := fstest.CheckDirectoryEqualWithApplier(view, fstest.Apply(
+		fstest.CreateDir("/dir", 0755),
+		fstest.CreateFile("/dir/b", []byte("b\n"), 0644),
+	)); err != nil {
+		t.Fatalf("Failure reason: %+v", err)
+	}
+}
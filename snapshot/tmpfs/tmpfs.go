@@ -0,0 +1,502 @@
+// +build linux
+
+package tmpfs
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/containerd/fs"
+	"github.com/containerd/containerd/log"
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/plugin"
+	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/storage"
+	units "github.com/docker/go-units"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+func init() {
+	plugin.Register("snapshot-tmpfs", &plugin.Registration{
+		Type:   plugin.SnapshotPlugin,
+		Config: &Config{},
+		Init: func(ic *plugin.InitContext) (interface{}, error) {
+			var size int64
+			if s := ic.Config.(*Config).Size; s != "" {
+				var err error
+				size, err = units.RAMInBytes(s)
+				if err != nil || size <= 0 {
+					return nil, errors.Errorf("invalid tmpfs size %q", s)
+				}
+			}
+			return NewSnapshotter(filepath.Join(ic.Root, "snapshot", "tmpfs"), size)
+		},
+	})
+}
+
+// Config configures the tmpfs snapshotter.
+type Config struct {
+	// Size is the default size limit of the tmpfs of active snapshots, in
+	// bytes or in a human readable form such as "512MB". If empty, the
+	// default size of tmpfs, half of the memory, applies.
+	Size string `toml:"size"`
+}
+
+// LabelSize is the snapshot label setting the size limit of the tmpfs of an
+// active snapshot, in bytes or in a human readable form such as "512MB". It
+// is only applied on Prepare.
+const LabelSize = "containerd.io/snapshot/tmpfs.size"
+
+// WithSize limits the tmpfs of an active snapshot to size bytes by setting
+// the LabelSize label.
+func WithSize(size int64) snapshot.Opt {
+	return func(info *snapshot.Info) error {
+		labels := map[string]string{}
+		for k, v := range info.Labels {
+			labels[k] = v
+		}
+		labels[LabelSize] = strconv.FormatInt(size, 10)
+		info.Labels = labels
+		return nil
+	}
+}
+
+type snapshotter struct {
+	root string
+	ms   *storage.MetaStore
+	size int64
+}
+
+// NewSnapshotter returns a Snapshotter which uses overlayfs with the upper
+// and work directories of active snapshots on a tmpfs, limited to size
+// bytes unless set by LabelSize. A size of 0 uses the default size of tmpfs.
+// Committed snapshots are copied to, and stored under, the provided root.
+//
+// The contents of active snapshots do not survive a reboot.
+func NewSnapshotter(root string, size int64) (snapshot.Snapshotter, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	supportsDType, err := fs.SupportsDType(root)
+	if err != nil {
+		return nil, err
+	}
+	if !supportsDType {
+		return nil, fmt.Errorf("%s does not support d_type. If the backing filesystem is xfs, please reformat with ftype=1 to enable d_type support.", root)
+	}
+	ms, err := storage.NewMetaStore(filepath.Join(root, "metadata.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Mkdir(filepath.Join(root, "snapshots"), 0700); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+
+	return &snapshotter{
+		root: root,
+		ms:   ms,
+		size: size,
+	}, nil
+}
+
+// Stat returns the info for an active or committed snapshot by name or
+// key.
+//
+// Should be used for parent resolution, existence checks and to discern
+// the kind of snapshot.
+func (o *snapshotter) Stat(ctx context.Context, key string) (snapshot.Info, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+	defer t.Rollback()
+	_, info, _, err := storage.GetInfo(ctx, key)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
+// Update updates the labels of an active or committed snapshot.
+func (o *snapshotter) Update(ctx context.Context, info snapshot.Info, fieldpaths ...string) (snapshot.Info, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return snapshot.Info{}, err
+	}
+
+	info, err = storage.UpdateInfo(ctx, info, fieldpaths...)
+	if err != nil {
+		if rerr := t.Rollback(); rerr != nil {
+			log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+		}
+		return snapshot.Info{}, err
+	}
+
+	if err := t.Commit(); err != nil {
+		return snapshot.Info{}, err
+	}
+
+	return info, nil
+}
+
+// Usage returns the resources taken by the snapshot identified by key.
+//
+// For active snapshots, this will scan the usage of the upper directory on
+// the tmpfs. For committed snapshots, the value is returned from the
+// metadata database.
+func (o *snapshotter) Usage(ctx context.Context, key string) (snapshot.Usage, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.Usage{}, err
+	}
+	id, info, usage, err := storage.GetInfo(ctx, key)
+	t.Rollback() // transaction no longer needed at this point.
+	if err != nil {
+		return snapshot.Usage{}, err
+	}
+
+	if info.Kind == snapshot.KindActive {
+		du, err := fs.DiskUsage(o.upperPath(id))
+		if err != nil {
+			return snapshot.Usage{}, err
+		}
+		usage = snapshot.Usage(du)
+	}
+
+	return usage, nil
+}
+
+func (o *snapshotter) Prepare(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	if err := o.ms.LinkShared(ctx, parent, opts...); err != nil {
+		return nil, err
+	}
+	return o.createActive(ctx, key, parent, false, opts)
+}
+
+// RecordDiff records the layer applied to the active snapshot key, allowing
+// it to be shared once committed.
+func (o *snapshotter) RecordDiff(ctx context.Context, key string, diffID digest.Digest) error {
+	return o.ms.RecordDiff(ctx, key, diffID)
+}
+
+func (o *snapshotter) View(ctx context.Context, key, parent string, opts ...snapshot.Opt) ([]mount.Mount, error) {
+	return o.createActive(ctx, key, parent, true, opts)
+}
+
+// Mounts returns the mounts for the transaction identified by key. Can be
+// called on an read-write or readonly transaction.
+//
+// This can be used to recover mounts after calling View or Prepare.
+func (o *snapshotter) Mounts(ctx context.Context, key string) ([]mount.Mount, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	active, err := storage.GetActive(ctx, key)
+	t.Rollback()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get active mount")
+	}
+	return o.mounts(active), nil
+}
+
+// Commit copies the upper directory of the active snapshot key from the
+// tmpfs to the root and releases the tmpfs.
+func (o *snapshotter) Commit(ctx context.Context, name, key string, opts ...snapshot.Opt) (err error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	var td string
+	defer func() {
+		if err != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+			if td != "" {
+				if err1 := os.RemoveAll(td); err1 != nil {
+					log.G(ctx).WithError(err1).WithField("path", td).Warn("Failed to remove temporary directory")
+				}
+			}
+		}
+	}()
+
+	// grab the existing id
+	id, _, _, err := storage.GetInfo(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	usage, err := fs.DiskUsage(o.upperPath(id))
+	if err != nil {
+		return err
+	}
+
+	if _, err = storage.CommitActive(ctx, key, name, snapshot.Usage(usage), opts...); err != nil {
+		return errors.Wrap(err, "failed to commit snapshot")
+	}
+
+	td, err = ioutil.TempDir(filepath.Join(o.root, "snapshots"), "new-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp dir")
+	}
+	if err = fs.CopyDir(filepath.Join(td, "fs"), o.upperPath(id)); err != nil {
+		return errors.Wrap(err, "failed to copy snapshot from tmpfs")
+	}
+
+	path := filepath.Join(o.root, "snapshots", id)
+	if err = unix.Unmount(path, 0); err != nil {
+		return errors.Wrap(err, "failed to unmount tmpfs")
+	}
+	// remove the mount point, the snapshot directory is replaced by the copy
+	if err = os.Remove(path); err == nil {
+		err = os.Rename(td, path)
+	}
+	if err != nil {
+		// the tmpfs is gone, the copy is the only remaining content
+		log.G(ctx).WithError(err).WithField("path", td).Error("Failed to move committed snapshot into place")
+		td = ""
+		return errors.Wrap(err, "failed to rename")
+	}
+	td = ""
+
+	if err = t.Commit(); err != nil {
+		// May cause inconsistent data on disk
+		log.G(ctx).WithError(err).WithField("path", path).Error("Failed to commit after moving snapshot from tmpfs")
+		return errors.Wrap(err, "failed to commit")
+	}
+	return nil
+}
+
+// Remove abandons the transaction identified by key. All resources
+// associated with the key will be removed.
+func (o *snapshotter) Remove(ctx context.Context, key string) (err error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil && t != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+		}
+	}()
+
+	id, _, err := storage.Remove(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove")
+	}
+
+	shared, err := storage.Referenced(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to check snapshot references")
+	}
+
+	err = t.Commit()
+	t = nil
+	if err != nil {
+		return errors.Wrap(err, "failed to commit")
+	}
+	if shared {
+		// the storage is still used by another namespace
+		return nil
+	}
+
+	path := filepath.Join(o.root, "snapshots", id)
+	if err := removeDir(path); err != nil {
+		// Must be cleaned up, orphans are reported by Check
+		log.G(ctx).WithError(err).WithField("path", path).Warnf("Failed to remove root filesystem")
+	}
+
+	return nil
+}
+
+// Walk the committed snapshots.
+func (o *snapshotter) Walk(ctx context.Context, fn func(context.Context, snapshot.Info) error) error {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer t.Rollback()
+	return storage.WalkInfo(ctx, fn)
+}
+
+// Check compares the metadata with the snapshot directories, see
+// snapshot.Checker. Orphaned directories are quarantined under the root.
+func (o *snapshotter) Check(ctx context.Context, repair bool) (snapshot.CheckResult, error) {
+	ctx, t, err := o.ms.TransactionContext(ctx, false)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+	defer t.Rollback()
+
+	records, err := storage.Records(ctx)
+	if err != nil {
+		return snapshot.CheckResult{}, err
+	}
+
+	return storage.CheckDirectory(ctx, filepath.Join(o.root, "snapshots"), filepath.Join(o.root, "quarantine"), records, repair, removeDir)
+}
+
+func (o *snapshotter) createActive(ctx context.Context, key, parent string, readonly bool, opts []snapshot.Opt) (_ []mount.Mount, err error) {
+	size, err := o.tmpfsSize(readonly, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, t, err := o.ms.TransactionContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var path string
+	defer func() {
+		if err != nil {
+			if rerr := t.Rollback(); rerr != nil {
+				log.G(ctx).WithError(rerr).Warn("Failure rolling back transaction")
+			}
+			if path != "" {
+				if err1 := removeDir(path); err1 != nil {
+					err = errors.Wrapf(err, "failed to remove path: %v", err1)
+				}
+			}
+		}
+	}()
+
+	active, err := storage.CreateActive(ctx, key, parent, readonly, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create active")
+	}
+
+	// the identifier is unique, the directory is created in place as a
+	// tmpfs mount point cannot be renamed.
+	path = filepath.Join(o.root, "snapshots", active.ID)
+	if err = os.Mkdir(path, 0700); err != nil {
+		return nil, err
+	}
+	if !readonly {
+		options := "mode=0700"
+		if size > 0 {
+			options += fmt.Sprintf(",size=%d", size)
+		}
+		if err = unix.Mount("tmpfs", path, "tmpfs", 0, options); err != nil {
+			return nil, errors.Wrap(err, "failed to mount tmpfs")
+		}
+		if err = os.Mkdir(filepath.Join(path, "work"), 0700); err != nil {
+			return nil, err
+		}
+	}
+	if err = os.Mkdir(filepath.Join(path, "fs"), 0711); err != nil {
+		return nil, err
+	}
+
+	if err = t.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit failed")
+	}
+
+	return o.mounts(active), nil
+}
+
+// tmpfsSize returns the size of the tmpfs of a new active snapshot, as
+// requested by opts or configured by default.
+func (o *snapshotter) tmpfsSize(readonly bool, opts []snapshot.Opt) (int64, error) {
+	var info snapshot.Info
+	for _, opt := range opts {
+		if err := opt(&info); err != nil {
+			return 0, err
+		}
+	}
+	value, ok := info.Labels[LabelSize]
+	if !ok || readonly {
+		return o.size, nil
+	}
+
+	size, err := units.RAMInBytes(value)
+	if err != nil || size <= 0 {
+		return 0, errors.Errorf("invalid tmpfs size %q", value)
+	}
+	return size, nil
+}
+
+// removeDir removes the snapshot directory path, unmounting its tmpfs if
+// mounted.
+func removeDir(path string) error {
+	if err := unix.Unmount(path, 0); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+		return errors.Wrap(err, "failed to unmount tmpfs")
+	}
+	return os.RemoveAll(path)
+}
+
+func (o *snapshotter) mounts(active storage.Active) []mount.Mount {
+	if len(active.ParentIDs) == 0 {
+		// if we only have one layer/no parents then just return a bind mount as overlay
+		// will not work
+		roFlag := "rw"
+		if active.Readonly {
+			roFlag = "ro"
+		}
+
+		return []mount.Mount{
+			{
+				Source: o.upperPath(active.ID),
+				Type:   "bind",
+				Options: []string{
+					roFlag,
+					"rbind",
+				},
+			},
+		}
+	}
+	var options []string
+
+	if !active.Readonly {
+		options = append(options,
+			fmt.Sprintf("workdir=%s", o.workPath(active.ID)),
+			fmt.Sprintf("upperdir=%s", o.upperPath(active.ID)),
+		)
+	} else if len(active.ParentIDs) == 1 {
+		return []mount.Mount{
+			{
+				Source: o.upperPath(active.ParentIDs[0]),
+				Type:   "bind",
+				Options: []string{
+					"ro",
+					"rbind",
+				},
+			},
+		}
+	}
+
+	parentPaths := make([]string, len(active.ParentIDs))
+	for i := range active.ParentIDs {
+		parentPaths[i] = o.upperPath(active.ParentIDs[i])
+	}
+
+	options = append(options, fmt.Sprintf("lowerdir=%s", strings.Join(parentPaths, ":")))
+	return []mount.Mount{
+		{
+			Type:    "overlay",
+			Source:  "overlay",
+			Options: options,
+		},
+	}
+}
+
+func (o *snapshotter) upperPath(id string) string {
+	return filepath.Join(o.root, "snapshots", id, "fs")
+}
+
+func (o *snapshotter) workPath(id string) string {
+	return filepath.Join(o.root, "snapshots", id, "work")
+}
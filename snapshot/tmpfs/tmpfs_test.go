@@ -0,0 +1,130 @@
+// +build linux
+
+package tmpfs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/containerd/containerd/mount"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/snapshot"
+	"github.com/containerd/containerd/snapshot/testsuite"
+	"github.com/containerd/containerd/testutil"
+)
+
+func newSnapshotter(ctx context.Context, root string) (snapshot.Snapshotter, func(), error) {
+	snapshotter, err := NewSnapshotter(root, 64*1024*1024)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return snapshotter, func() {
+		unmountAll(root)
+	}, nil
+}
+
+// unmountAll unmounts the tmpfs of the snapshots left under root.
+func unmountAll(root string) {
+	mounts, err := mount.Self()
+	if err != nil {
+		return
+	}
+	for _, m := range mounts {
+		if strings.HasPrefix(m.Mountpoint, root+"/") {
+			syscall.Unmount(m.Mountpoint, 0)
+		}
+	}
+}
+
+func TestTmpfs(t *testing.T) {
+	testutil.RequiresRoot(t)
+	testsuite.SnapshotterSuite(t, "Tmpfs", newSnapshotter)
+}
+
+func TestTmpfsCommit(t *testing.T) {
+	testutil.RequiresRoot(t)
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-tmpfs-test")
+	root, err := ioutil.TempDir("", "tmpfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	o, cleanup, err := newSnapshotter(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	mounts, err := o.Prepare(ctx, "/tmp/test", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upper := mounts[0].Source
+	var sfs syscall.Statfs_t
+	if err := syscall.Statfs(upper, &sfs); err != nil {
+		t.Fatal(err)
+	}
+	if sfs.Type != 0x01021994 { // TMPFS_MAGIC
+		t.Fatalf("expected active snapshot on tmpfs, got filesystem type %x", sfs.Type)
+	}
+	if err := ioutil.WriteFile(filepath.Join(upper, "foo"), []byte("hi"), 0660); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Commit(ctx, "base", "/tmp/test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := syscall.Statfs(upper, &sfs); err != nil {
+		t.Fatal(err)
+	}
+	if sfs.Type == 0x01021994 {
+		t.Fatal("expected committed snapshot on disk")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(upper, "foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := string(data); e != "hi" {
+		t.Errorf("expected file contents hi but got %q", e)
+	}
+}
+
+func TestTmpfsSize(t *testing.T) {
+	testutil.RequiresRoot(t)
+	ctx := namespaces.WithNamespace(context.Background(), "snapshotter-tmpfs-test")
+	root, err := ioutil.TempDir("", "tmpfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	o, cleanup, err := newSnapshotter(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	mounts, err := o.Prepare(ctx, "/tmp/test", "", WithSize(1024*1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(mounts[0].Source, "small"), make([]byte, 512*1024), 0660); err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(mounts[0].Source, "large"), make([]byte, 1024*1024), 0660)
+	if err == nil {
+		t.Fatal("expected write beyond the tmpfs size to fail")
+	}
+
+	if err := o.Remove(ctx, "/tmp/test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Dir(mounts[0].Source)); !os.IsNotExist(err) {
+		t.Errorf("expected snapshot directory to be removed: %v", err)
+	}
+}